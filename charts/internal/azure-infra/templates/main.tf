@@ -108,16 +108,109 @@ resource "azurerm_subnet_nat_gateway_association" "nat-worker-subnet-association
 }
 {{- end }}
 
+{{ if .Values.outboundRule -}}
+#===============================================
+#= Load Balancer Outbound Rule
+#===============================================
+
+resource "azurerm_public_ip" "outbound" {
+  name                = "{{ required "clusterName is required" .Values.clusterName }}-outbound-ip"
+  location            = "{{ required "azure.region is required" .Values.azure.region }}"
+  {{ if .Values.create.resourceGroup -}}
+  resource_group_name = "${azurerm_resource_group.rg.name}"
+  {{- else -}}
+  resource_group_name = "${data.azurerm_resource_group.rg.name}"
+  {{- end }}
+  allocation_method   = "Static"
+  sku                 = "Standard"
+}
+
+# The load balancer is shared with the cloud-controller-manager which adds the frontend ip configurations, rules
+# and probes of the services of type LoadBalancer and the nodes to the backend pool. It has the name of the standard
+# load balancer of the cloud-controller-manager, hence the outbound rule can only be configured when the cluster is
+# created, i.e. before the cloud-controller-manager creates its own load balancer.
+resource "azurerm_lb" "outbound" {
+  name                = "{{ required "clusterName is required" .Values.clusterName }}"
+  location            = "{{ required "azure.region is required" .Values.azure.region }}"
+  {{ if .Values.create.resourceGroup -}}
+  resource_group_name = "${azurerm_resource_group.rg.name}"
+  {{- else -}}
+  resource_group_name = "${data.azurerm_resource_group.rg.name}"
+  {{- end }}
+  sku                 = "Standard"
+
+  frontend_ip_configuration {
+    name                 = "outbound"
+    public_ip_address_id = "${azurerm_public_ip.outbound.id}"
+  }
+
+  lifecycle {
+    ignore_changes = ["frontend_ip_configuration"]
+  }
+}
+
+resource "azurerm_lb_backend_address_pool" "outbound" {
+  name                = "{{ required "clusterName is required" .Values.clusterName }}"
+  {{ if .Values.create.resourceGroup -}}
+  resource_group_name = "${azurerm_resource_group.rg.name}"
+  {{- else -}}
+  resource_group_name = "${data.azurerm_resource_group.rg.name}"
+  {{- end }}
+  loadbalancer_id     = "${azurerm_lb.outbound.id}"
+}
+
+resource "azurerm_lb_outbound_rule" "outbound" {
+  name                     = "outbound"
+  {{ if .Values.create.resourceGroup -}}
+  resource_group_name      = "${azurerm_resource_group.rg.name}"
+  {{- else -}}
+  resource_group_name      = "${data.azurerm_resource_group.rg.name}"
+  {{- end }}
+  loadbalancer_id          = "${azurerm_lb.outbound.id}"
+  backend_address_pool_id  = "${azurerm_lb_backend_address_pool.outbound.id}"
+  protocol                 = "All"
+  allocated_outbound_ports = {{ required "outboundRule.allocatedOutboundPorts is required" .Values.outboundRule.allocatedOutboundPorts }}
+  idle_timeout_in_minutes  = {{ required "outboundRule.idleTimeoutInMinutes is required" .Values.outboundRule.idleTimeoutInMinutes }}
+
+  frontend_ip_configuration {
+    name = "outbound"
+  }
+}
+{{- end }}
+
 {{ if .Values.identity -}}
 #===============================================
 #= Identity
 #===============================================
 
+{{ if .Values.create.identity -}}
+resource "azurerm_user_assigned_identity" "identity" {
+  name                = "{{ required "identity.name is required" .Values.identity.name }}"
+  {{ if .Values.create.resourceGroup -}}
+  resource_group_name = "${azurerm_resource_group.rg.name}"
+  {{- else -}}
+  resource_group_name = "${data.azurerm_resource_group.rg.name}"
+  {{- end}}
+  location            = "{{ required "azure.region is required" .Values.azure.region }}"
+}
+{{- else -}}
 data "azurerm_user_assigned_identity" "identity" {
   name                = "{{ required "identity.name is required" .Values.identity.name }}"
   resource_group_name = "{{ required "identity.resourceGroup is required" .Values.identity.resourceGroup }}"
 }
 {{- end }}
+{{ range $registry := .Values.containerRegistries }}
+resource "azurerm_role_assignment" "acr-pull-{{ required "containerRegistries[].key is required" $registry.key }}" {
+  scope                = "{{ required "containerRegistries[].id is required" $registry.id }}"
+  role_definition_name = "AcrPull"
+  {{ if $.Values.create.identity -}}
+  principal_id         = "${azurerm_user_assigned_identity.identity.principal_id}"
+  {{- else -}}
+  principal_id         = "${data.azurerm_user_assigned_identity.identity.principal_id}"
+  {{- end }}
+}
+{{ end }}
+{{- end }}
 
 {{ if .Values.create.availabilitySet -}}
 #===============================================
@@ -186,6 +279,15 @@ output "{{ .Values.outputKeys.availabilitySetName }}" {
 }
 {{- end}}
 {{ if .Values.identity -}}
+{{ if .Values.create.identity -}}
+output "{{ .Values.outputKeys.identityID }}" {
+  value = "${azurerm_user_assigned_identity.identity.id}"
+}
+
+output "{{ .Values.outputKeys.identityClientID }}" {
+  value = "${azurerm_user_assigned_identity.identity.client_id}"
+}
+{{- else -}}
 output "{{ .Values.outputKeys.identityID }}" {
   value = "${data.azurerm_user_assigned_identity.identity.id}"
 }
@@ -193,4 +295,5 @@ output "{{ .Values.outputKeys.identityID }}" {
 output "{{ .Values.outputKeys.identityClientID }}" {
   value = "${data.azurerm_user_assigned_identity.identity.client_id}"
 }
+{{- end }}
 {{- end }}
\ No newline at end of file
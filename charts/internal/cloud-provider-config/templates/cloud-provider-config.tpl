@@ -11,20 +11,26 @@ vnetResourceGroup: "{{ .Values.vnetResourceGroup }}"
 {{- end }}
 {{- if hasKey .Values "availabilitySetName" }}
 primaryAvailabilitySetName: "{{ .Values.availabilitySetName }}"
-loadBalancerSku: "basic"
-{{- else }}
-loadBalancerSku: "standard"
+{{- end }}
+loadBalancerSku: "{{ .Values.loadBalancerSku }}"
+{{- if .Values.loadBalancer }}
+{{- if hasKey .Values.loadBalancer "disableOutboundSNAT" }}
+disableOutboundSNAT: {{ .Values.loadBalancer.disableOutboundSNAT }}
+{{- end }}
+{{- if hasKey .Values.loadBalancer "preConfiguredBackendPoolType" }}
+preConfiguredBackendPoolLoadBalancerTypes: "{{ .Values.loadBalancer.preConfiguredBackendPoolType }}"
+{{- end }}
 {{- end }}
 cloudProviderBackoff: true
-cloudProviderBackoffRetries: 6
-cloudProviderBackoffExponent: 1.5
-cloudProviderBackoffDuration: 5
-cloudProviderBackoffJitter: 1.0
+cloudProviderBackoffRetries: {{ .Values.backoff.retries }}
+cloudProviderBackoffExponent: {{ .Values.backoff.exponent }}
+cloudProviderBackoffDuration: {{ .Values.backoff.durationSeconds }}
+cloudProviderBackoffJitter: {{ .Values.backoff.jitter }}
 cloudProviderRateLimit: true
-cloudProviderRateLimitQPS: {{ ( max .Values.maxNodes 10 ) }}
-cloudProviderRateLimitBucket: 100
-cloudProviderRateLimitQPSWrite: {{ ( max .Values.maxNodes 10 ) }}
-cloudProviderRateLimitBucketWrite: 100
+cloudProviderRateLimitQPS: {{ .Values.rateLimit.qps }}
+cloudProviderRateLimitBucket: {{ .Values.rateLimit.bucket }}
+cloudProviderRateLimitQPSWrite: {{ .Values.rateLimit.qpsWrite }}
+cloudProviderRateLimitBucketWrite: {{ .Values.rateLimit.bucketWrite }}
 {{- if semverCompare ">= 1.14" .Values.kubernetesVersion }}
 cloudProviderBackoffMode: v2
 {{- end }}
@@ -0,0 +1,6 @@
+{{- define "csi-driver-controller.sidecarArgs" -}}
+- --csi-address=$(ADDRESS)
+- --kubeconfig=/var/lib/csi-driver-controller/kubeconfig
+- --leader-election=true
+- --leader-election-namespace=kube-system
+{{- end -}}
@@ -28,6 +28,7 @@ import (
 	"github.com/gardener/gardener-extension-provider-azure/pkg/controller/healthcheck"
 	azureinfrastructure "github.com/gardener/gardener-extension-provider-azure/pkg/controller/infrastructure"
 	azureworker "github.com/gardener/gardener-extension-provider-azure/pkg/controller/worker"
+	azurecontrolplanewebhook "github.com/gardener/gardener-extension-provider-azure/pkg/webhook/controlplane"
 	azurecontrolplaneexposure "github.com/gardener/gardener-extension-provider-azure/pkg/webhook/controlplaneexposure"
 
 	druidv1alpha1 "github.com/gardener/etcd-druid/api/v1alpha1"
@@ -154,10 +155,18 @@ func NewControllerManagerCommand(ctx context.Context) *cobra.Command {
 			// add types required for Azure Health check
 			scheme.AddKnownTypes(machinev1alpha1.SchemeGroupVersion,
 				&machinev1alpha1.MachineDeploymentList{},
+				&machinev1alpha1.Machine{},
+				&machinev1alpha1.MachineList{},
 			)
 
 			configFileOpts.Completed().ApplyETCDStorage(&azurecontrolplaneexposure.DefaultAddOptions.ETCDStorage)
+			configFileOpts.Completed().ApplyKubeAPIServerExposure(&azurecontrolplaneexposure.DefaultAddOptions.KubeAPIServerExposure)
+			configFileOpts.Completed().ApplyKubeAPIServerExposure(&azurecontrolplane.DefaultAddOptions.KubeAPIServerExposure)
 			configFileOpts.Completed().ApplyHealthCheckConfig(&healthcheck.DefaultAddOptions.HealthCheckConfig)
+			configFileOpts.Completed().ApplyVirtualMachineHealthCheck(&healthcheck.DefaultAddOptions.VirtualMachineHealthCheck)
+			configFileOpts.Completed().ApplyCloudControllerManager(&azurecontrolplane.DefaultAddOptions.CloudControllerManager)
+			configFileOpts.Completed().ApplyKMS(&azurecontrolplane.DefaultAddOptions.KMS)
+			configFileOpts.Completed().ApplyAcrCredentialProvider(&azurecontrolplanewebhook.DefaultAddOptions.AcrCredentialProvider)
 			healthCheckCtrlOpts.Completed().Apply(&healthcheck.DefaultAddOptions.Controller)
 			backupBucketCtrlOpts.Completed().Apply(&azurebackupbucket.DefaultAddOptions.Controller)
 			backupEntryCtrlOpts.Completed().Apply(&azurebackupentry.DefaultAddOptions.Controller)
@@ -0,0 +1,95 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"context"
+	"fmt"
+
+	providerazure "github.com/gardener/gardener-extension-provider-azure/pkg/azure"
+	"github.com/gardener/gardener-extension-provider-azure/pkg/scheduledevents"
+
+	controllercmd "github.com/gardener/gardener-extensions/pkg/controller/cmd"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const name = "scheduled-events-handler-azure"
+
+var log = logf.Log.WithName("gardener-extension-scheduled-events-handler-azure")
+
+// NewScheduledEventsHandlerCommand creates a new command for running the handler of the Azure scheduled events of a node.
+func NewScheduledEventsHandlerCommand(ctx context.Context) *cobra.Command {
+	var (
+		restOpts = &controllercmd.RESTOptions{}
+
+		metadataEndpoint string
+		eventTypes       []string
+		opts             = scheduledevents.Options{}
+	)
+
+	cmd := &cobra.Command{
+		Use: fmt.Sprintf("scheduled-events-handler-%s", providerazure.Type),
+
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := restOpts.Complete(); err != nil {
+				controllercmd.LogErrAndExit(err, "error completing options")
+			}
+			if len(opts.NodeName) == 0 {
+				controllercmd.LogErrAndExit(fmt.Errorf("--node-name must be set"), "error completing options")
+			}
+			for _, eventType := range eventTypes {
+				opts.EventTypes = append(opts.EventTypes, scheduledevents.EventType(eventType))
+			}
+
+			restConfig := restOpts.Completed().Config
+			c, err := client.New(restConfig, client.Options{})
+			if err != nil {
+				controllercmd.LogErrAndExit(err, "could not create client")
+			}
+			clientset, err := kubernetes.NewForConfig(restConfig)
+			if err != nil {
+				controllercmd.LogErrAndExit(err, "could not create clientset")
+			}
+
+			broadcaster := record.NewBroadcaster()
+			broadcaster.StartRecordingToSink(&corev1client.EventSinkImpl{Interface: clientset.CoreV1().Events("")})
+			recorder := broadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: name, Host: opts.NodeName})
+
+			handler := scheduledevents.NewHandler(c, clientset.CoreV1(), scheduledevents.NewMetadataClient(metadataEndpoint), recorder, log, opts)
+			handler.Start(ctx)
+		},
+	}
+
+	var defaultEventTypes []string
+	for _, eventType := range scheduledevents.DefaultEventTypes {
+		defaultEventTypes = append(defaultEventTypes, string(eventType))
+	}
+
+	restOpts.AddFlags(cmd.Flags())
+	cmd.Flags().StringVar(&opts.NodeName, "node-name", "", "name of the node on which the handler runs")
+	cmd.Flags().StringSliceVar(&eventTypes, "event-types", defaultEventTypes, "types of the scheduled events before which the node is drained")
+	cmd.Flags().DurationVar(&opts.DrainTimeout, "drain-timeout", scheduledevents.DefaultDrainTimeout, "maximum duration of the drain of the node")
+	cmd.Flags().DurationVar(&opts.PollInterval, "poll-interval", scheduledevents.DefaultPollInterval, "interval in which the scheduled events are polled")
+	cmd.Flags().StringVar(&metadataEndpoint, "metadata-endpoint", scheduledevents.DefaultMetadataEndpoint, "endpoint of the scheduled events in the Azure Instance Metadata Service")
+
+	return cmd
+}
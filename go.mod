@@ -12,6 +12,7 @@ require (
 	github.com/gardener/gardener v1.1.1-0.20200330051317-a326f96cf32b
 	github.com/gardener/gardener-extension-networking-calico v1.3.0
 	github.com/gardener/gardener-extensions v1.5.1-0.20200330101454-c65957bd80b5
+	github.com/gardener/gardener-resource-manager v0.10.0
 	github.com/gardener/machine-controller-manager v0.26.0
 	github.com/go-logr/logr v0.1.0
 	github.com/gobuffalo/packr/v2 v2.1.0
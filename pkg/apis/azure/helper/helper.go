@@ -68,16 +68,16 @@ func FindAvailabilitySetByPurpose(availabilitySets []api.AvailabilitySet, purpos
 	return nil, fmt.Errorf("cannot find availability set with purpose %q", purpose)
 }
 
-// FindMachineImage takes a list of machine images and tries to find the first entry
-// whose name, version, and zone matches with the given name, version, and zone. If no such entry is
-// found then an error will be returned.
-func FindMachineImage(machineImages []api.MachineImage, name, version string) (*api.MachineImage, error) {
+// FindMachineImage takes a list of machine images and tries to find the first entry whose name and version matches
+// with the given name and version and which can be used for machines of the given machine type (see
+// IsMachineImageVersionCompatible). If no such entry is found then an error will be returned.
+func FindMachineImage(machineImages []api.MachineImage, name, version string, machineType *api.MachineType) (*api.MachineImage, error) {
 	for _, machineImage := range machineImages {
-		if machineImage.Name == name && machineImage.Version == version {
+		if machineImage.Name == name && machineImage.Version == version && isCompatible(machineImage.Architecture, machineImage.HyperVGeneration, machineType) {
 			return &machineImage, nil
 		}
 	}
-	return nil, fmt.Errorf("no machine image with name %q, version %q found", name, version)
+	return nil, fmt.Errorf("no machine image with name %q and version %q found which is compatible with the machine type", name, version)
 }
 
 // FindDomainCountByRegion takes a region and the domain counts and finds the count for the given region.
@@ -90,27 +90,157 @@ func FindDomainCountByRegion(domainCounts []api.DomainCount, region string) (int
 	return 0, fmt.Errorf("could not find a domain count for region %s", region)
 }
 
-// FindImageFromCloudProfile takes a list of machine images, and the desired image name and version. It tries
-// to find the image with the given name and version. If it cannot be found then an error
-// is returned.
-func FindImageFromCloudProfile(cloudProfileConfig *api.CloudProfileConfig, imageName, imageVersion string) (*api.MachineImage, error) {
+// FindRegion takes a list of regions and tries to find the first entry whose name matches with the given name.
+// If no such entry is found then an error will be returned.
+func FindRegion(regions []api.Region, name string) (*api.Region, error) {
+	for _, region := range regions {
+		if region.Name == name {
+			return &region, nil
+		}
+	}
+	return nil, fmt.Errorf("no region with name %q found", name)
+}
+
+// FindRegionMachineType takes a list of machine types of a region and tries to find the first entry whose name matches
+// with the given name. If no such entry is found then an error will be returned.
+func FindRegionMachineType(machineTypes []api.RegionMachineType, name string) (*api.RegionMachineType, error) {
+	for _, machineType := range machineTypes {
+		if machineType.Name == name {
+			return &machineType, nil
+		}
+	}
+	return nil, fmt.Errorf("no machine type with name %q found", name)
+}
+
+// FindUpdateDomainCount determines the update domain count for the given region. The count of the region specific
+// information takes precedence over the count in the `CountUpdateDomains` list.
+func FindUpdateDomainCount(cloudProfileConfig *api.CloudProfileConfig, region string) (int, error) {
+	if r, err := FindRegion(cloudProfileConfig.Regions, region); err == nil && r.CountUpdateDomains != nil {
+		return *r.CountUpdateDomains, nil
+	}
+	return FindDomainCountByRegion(cloudProfileConfig.CountUpdateDomains, region)
+}
+
+// FindFaultDomainCount determines the fault domain count for the given region. The count of the region specific
+// information takes precedence over the count in the `CountFaultDomains` list.
+func FindFaultDomainCount(cloudProfileConfig *api.CloudProfileConfig, region string) (int, error) {
+	if r, err := FindRegion(cloudProfileConfig.Regions, region); err == nil && r.CountFaultDomains != nil {
+		return *r.CountFaultDomains, nil
+	}
+	return FindDomainCountByRegion(cloudProfileConfig.CountFaultDomains, region)
+}
+
+// FindImageFromCloudProfile takes a list of machine images, and the desired image name, version and region as well as
+// the capabilities of the machine type which should run the image. It tries to find the first variant of the image with
+// the given name and version which is compatible with the machine type (see IsMachineImageVersionCompatible). A region
+// specific image reference of the version takes precedence over the image reference of the version. If no image
+// reference can be found then an error is returned.
+func FindImageFromCloudProfile(cloudProfileConfig *api.CloudProfileConfig, imageName, imageVersion, region string, machineType *api.MachineType) (*api.MachineImage, error) {
 	if cloudProfileConfig != nil {
 		for _, machineImage := range cloudProfileConfig.MachineImages {
 			if machineImage.Name != imageName {
 				continue
 			}
 			for _, version := range machineImage.Versions {
-				if imageVersion == version.Version {
-					return &api.MachineImage{
-						Name:    imageName,
-						Version: version.Version,
-						URN:     version.URN,
-						ID:      version.ID,
-					}, nil
+				if imageVersion != version.Version || !IsMachineImageVersionCompatible(version, machineType) {
+					continue
+				}
+
+				image := &api.MachineImage{
+					Name:             imageName,
+					Version:          version.Version,
+					URN:              version.URN,
+					ID:               version.ID,
+					Architecture:     version.Architecture,
+					HyperVGeneration: version.HyperVGeneration,
+				}
+				for _, mapping := range version.Regions {
+					if mapping.Name == region {
+						image = &api.MachineImage{
+							Name:             imageName,
+							Version:          version.Version,
+							ID:               mapping.ID,
+							Architecture:     version.Architecture,
+							HyperVGeneration: version.HyperVGeneration,
+						}
+						break
+					}
+				}
+
+				if image.URN != nil || image.ID != nil {
+					return image, nil
 				}
 			}
 		}
 	}
 
-	return nil, fmt.Errorf("could not find an image for name %q in version %q", imageName, imageVersion)
+	architecture := api.ArchitectureAMD64
+	if machineType != nil {
+		architecture = ArchitectureOrDefault(machineType.Architecture)
+	}
+	return nil, fmt.Errorf("could not find an image for name %q in version %q for region %q and architecture %q", imageName, imageVersion, region, architecture)
+}
+
+// IsMachineImageVersionCompatible checks whether the given machine image version can be used for machines of the given
+// machine type, i.e. whether the architectures match and whether the Hyper-V generation of the image version is
+// supported by the machine type. If no machine type is given, an amd64 machine type supporting all Hyper-V generations
+// is assumed.
+func IsMachineImageVersionCompatible(version api.MachineImageVersion, machineType *api.MachineType) bool {
+	return isCompatible(version.Architecture, version.HyperVGeneration, machineType)
+}
+
+func isCompatible(architecture *string, generation *api.HyperVGeneration, machineType *api.MachineType) bool {
+	if machineType == nil {
+		return ArchitectureOrDefault(architecture) == api.ArchitectureAMD64
+	}
+
+	if ArchitectureOrDefault(architecture) != ArchitectureOrDefault(machineType.Architecture) {
+		return false
+	}
+
+	if len(machineType.HyperVGenerations) == 0 {
+		return true
+	}
+	for _, supportedGeneration := range machineType.HyperVGenerations {
+		if supportedGeneration == HyperVGenerationOrDefault(generation) {
+			return true
+		}
+	}
+	return false
+}
+
+// ArchitectureOrDefault returns the given CPU architecture or amd64 if it is not set.
+func ArchitectureOrDefault(architecture *string) string {
+	if architecture == nil {
+		return api.ArchitectureAMD64
+	}
+	return *architecture
+}
+
+// HyperVGenerationOrDefault returns the given Hyper-V generation or V1 if it is not set.
+func HyperVGenerationOrDefault(generation *api.HyperVGeneration) api.HyperVGeneration {
+	if generation == nil {
+		return api.HyperVGenerationV1
+	}
+	return *generation
+}
+
+// PodNetworkModeOrDefault returns the pod network mode of the given InfrastructureConfig or Routes if it is not set.
+func PodNetworkModeOrDefault(infrastructureConfig *api.InfrastructureConfig) api.PodNetworkMode {
+	if infrastructureConfig == nil || infrastructureConfig.Networks.PodNetworkMode == nil {
+		return api.PodNetworkModeRoutes
+	}
+	return *infrastructureConfig.Networks.PodNetworkMode
+}
+
+// FindMachineType takes a list of machine types and tries to find the first entry
+// whose name matches with the given name. If no such entry is found then an error will be
+// returned.
+func FindMachineType(machineTypes []api.MachineType, name string) (*api.MachineType, error) {
+	for _, machineType := range machineTypes {
+		if machineType.Name == name {
+			return &machineType, nil
+		}
+	}
+	return nil, fmt.Errorf("no machine type with name %q found", name)
 }
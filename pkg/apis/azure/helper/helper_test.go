@@ -24,8 +24,12 @@ import (
 )
 
 var (
-	profileURN = "publisher:offer:sku:1.2.4"
-	profileID  = "/subscription/image/id"
+	profileURN     = "publisher:offer:sku:1.2.4"
+	profileID      = "/subscription/image/id"
+	profileARM64ID = "/subscription/image/arm64-id"
+	arm64          = api.ArchitectureARM64
+	generationV2   = api.HyperVGenerationV2
+	overlay        = api.PodNetworkModeOverlay
 )
 
 var _ = Describe("Helper", func() {
@@ -85,17 +89,33 @@ var _ = Describe("Helper", func() {
 	)
 
 	DescribeTable("#FindMachineImage",
-		func(machineImages []api.MachineImage, name, version string, expectedMachineImage *api.MachineImage, expectErr bool) {
-			machineImage, err := FindMachineImage(machineImages, name, version)
+		func(machineImages []api.MachineImage, name, version string, machineType *api.MachineType, expectedMachineImage *api.MachineImage, expectErr bool) {
+			machineImage, err := FindMachineImage(machineImages, name, version, machineType)
 			expectResults(machineImage, expectedMachineImage, err, expectErr)
 		},
 
-		Entry("list is nil", nil, "foo", "1.2.3", nil, true),
-		Entry("empty list", []api.MachineImage{}, "foo", "1.2.3", nil, true),
-		Entry("entry not found (no name)", []api.MachineImage{{Name: "bar", Version: "1.2.3", URN: &urn}}, "foo", "1.2.3", nil, true),
-		Entry("entry not found (no version)", []api.MachineImage{{Name: "bar", Version: "1.2.3", URN: &urn}}, "bar", "1.2.4", nil, true),
-		Entry("entry exists(urn)", []api.MachineImage{{Name: "bar", Version: "1.2.3", URN: &urn}}, "bar", "1.2.3", &api.MachineImage{Name: "bar", Version: "1.2.3", URN: &urn}, false),
-		Entry("entry exists(id)", []api.MachineImage{{Name: "bar", Version: "1.2.3", ID: &imageID}}, "bar", "1.2.3", &api.MachineImage{Name: "bar", Version: "1.2.3", ID: &imageID}, false),
+		Entry("list is nil", nil, "foo", "1.2.3", nil, nil, true),
+		Entry("empty list", []api.MachineImage{}, "foo", "1.2.3", nil, nil, true),
+		Entry("entry not found (no name)", []api.MachineImage{{Name: "bar", Version: "1.2.3", URN: &urn}}, "foo", "1.2.3", nil, nil, true),
+		Entry("entry not found (no version)", []api.MachineImage{{Name: "bar", Version: "1.2.3", URN: &urn}}, "bar", "1.2.4", nil, nil, true),
+		Entry("entry not found (no architecture)", []api.MachineImage{{Name: "bar", Version: "1.2.3", URN: &urn}}, "bar", "1.2.3", &api.MachineType{Architecture: &arm64}, nil, true),
+		Entry("entry not found (no Hyper-V generation)", []api.MachineImage{{Name: "bar", Version: "1.2.3", URN: &urn}}, "bar", "1.2.3", &api.MachineType{HyperVGenerations: []api.HyperVGeneration{api.HyperVGenerationV2}}, nil, true),
+		Entry("entry exists(urn)", []api.MachineImage{{Name: "bar", Version: "1.2.3", URN: &urn}}, "bar", "1.2.3", nil, &api.MachineImage{Name: "bar", Version: "1.2.3", URN: &urn}, false),
+		Entry("entry exists(id)", []api.MachineImage{{Name: "bar", Version: "1.2.3", ID: &imageID}}, "bar", "1.2.3", nil, &api.MachineImage{Name: "bar", Version: "1.2.3", ID: &imageID}, false),
+		Entry("entry exists(architecture)", []api.MachineImage{{Name: "bar", Version: "1.2.3", URN: &urn}, {Name: "bar", Version: "1.2.3", ID: &imageID, Architecture: &arm64}}, "bar", "1.2.3", &api.MachineType{Architecture: &arm64}, &api.MachineImage{Name: "bar", Version: "1.2.3", ID: &imageID, Architecture: &arm64}, false),
+		Entry("entry exists(Hyper-V generation)", []api.MachineImage{{Name: "bar", Version: "1.2.3", URN: &urn}, {Name: "bar", Version: "1.2.3", ID: &imageID, HyperVGeneration: &generationV2}}, "bar", "1.2.3", &api.MachineType{HyperVGenerations: []api.HyperVGeneration{api.HyperVGenerationV2}}, &api.MachineImage{Name: "bar", Version: "1.2.3", ID: &imageID, HyperVGeneration: &generationV2}, false),
+	)
+
+	DescribeTable("#FindMachineType",
+		func(machineTypes []api.MachineType, name string, expectedMachineType *api.MachineType, expectErr bool) {
+			machineType, err := FindMachineType(machineTypes, name)
+			expectResults(machineType, expectedMachineType, err, expectErr)
+		},
+
+		Entry("list is nil", nil, "foo", nil, true),
+		Entry("empty list", []api.MachineType{}, "foo", nil, true),
+		Entry("entry not found", []api.MachineType{{Name: "bar"}}, "foo", nil, true),
+		Entry("entry exists", []api.MachineType{{Name: "bar"}}, "bar", &api.MachineType{Name: "bar"}, false),
 	)
 
 	DescribeTable("#FindDomainCountByRegion",
@@ -110,11 +130,89 @@ var _ = Describe("Helper", func() {
 		Entry("entry exists", []api.DomainCount{{Region: "bar", Count: 1}}, "bar", 1, false),
 	)
 
+	DescribeTable("#FindRegion",
+		func(regions []api.Region, name string, expectedRegion *api.Region, expectErr bool) {
+			region, err := FindRegion(regions, name)
+			expectResults(region, expectedRegion, err, expectErr)
+		},
+
+		Entry("list is nil", nil, "foo", nil, true),
+		Entry("empty list", []api.Region{}, "foo", nil, true),
+		Entry("entry not found", []api.Region{{Name: "bar"}}, "foo", nil, true),
+		Entry("entry exists", []api.Region{{Name: "bar", Zones: []string{"1"}}}, "bar", &api.Region{Name: "bar", Zones: []string{"1"}}, false),
+	)
+
+	DescribeTable("#FindFaultDomainCount",
+		func(regions []api.Region, domainCounts []api.DomainCount, region string, expectedCount int, expectErr bool) {
+			count, err := FindFaultDomainCount(&api.CloudProfileConfig{Regions: regions, CountFaultDomains: domainCounts}, region)
+			expectResults(count, expectedCount, err, expectErr)
+		},
+
+		Entry("no counts", nil, nil, "foo", 0, true),
+		Entry("count from list", []api.Region{{Name: "foo"}}, []api.DomainCount{{Region: "foo", Count: 2}}, "foo", 2, false),
+		Entry("count from region", []api.Region{{Name: "foo", CountFaultDomains: intPtr(3)}}, []api.DomainCount{{Region: "foo", Count: 2}}, "foo", 3, false),
+		Entry("count of other region", []api.Region{{Name: "bar", CountFaultDomains: intPtr(3)}}, nil, "foo", 0, true),
+	)
+
+	DescribeTable("#FindUpdateDomainCount",
+		func(regions []api.Region, domainCounts []api.DomainCount, region string, expectedCount int, expectErr bool) {
+			count, err := FindUpdateDomainCount(&api.CloudProfileConfig{Regions: regions, CountUpdateDomains: domainCounts}, region)
+			expectResults(count, expectedCount, err, expectErr)
+		},
+
+		Entry("no counts", nil, nil, "foo", 0, true),
+		Entry("count from list", []api.Region{{Name: "foo"}}, []api.DomainCount{{Region: "foo", Count: 5}}, "foo", 5, false),
+		Entry("count from region", []api.Region{{Name: "foo", CountUpdateDomains: intPtr(20)}}, []api.DomainCount{{Region: "foo", Count: 5}}, "foo", 20, false),
+	)
+
 	DescribeTable("#FindImage",
-		func(profileImages []api.MachineImages, imageName, version string, expectedImage *api.MachineImage) {
+		func(profileImages []api.MachineImages, imageName, version, region string, expectedImage *api.MachineImage) {
 			cfg := &api.CloudProfileConfig{}
 			cfg.MachineImages = profileImages
-			image, err := FindImageFromCloudProfile(cfg, imageName, version)
+			image, err := FindImageFromCloudProfile(cfg, imageName, version, region, nil)
+
+			Expect(image).To(Equal(expectedImage))
+			if expectedImage != nil {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(HaveOccurred())
+			}
+		},
+
+		Entry("list is nil", nil, "ubuntu", "1", "westeurope", nil),
+
+		Entry("profile empty list", []api.MachineImages{}, "ubuntu", "1", "westeurope", nil),
+		Entry("profile entry not found (image does not exist)", makeProfileMachineImages("debian", "1", "3"), "ubuntu", "1", "westeurope", nil),
+		Entry("profile entry not found (version does not exist)", makeProfileMachineImages("ubuntu", "2", "4"), "ubuntu", "1", "westeurope", nil),
+		Entry("profile entry(urn)", makeProfileMachineImages("ubuntu", "1", "3"), "ubuntu", "1", "westeurope", &api.MachineImage{Name: "ubuntu", Version: "1", URN: &profileURN}),
+		Entry("profile entry(id)", makeProfileMachineImages("ubuntu", "1", "3"), "ubuntu", "3", "westeurope", &api.MachineImage{Name: "ubuntu", Version: "3", ID: &profileID}),
+
+		Entry("valid image reference, only urn", makeProfileMachineImageWithIDandURN("ubuntu", "1", &profileURN, nil), "ubuntu", "1", "westeurope", &api.MachineImage{Name: "ubuntu", Version: "1", URN: &profileURN}),
+		Entry("valid image reference, only id", makeProfileMachineImageWithIDandURN("ubuntu", "1", nil, &profileID), "ubuntu", "1", "westeurope", &api.MachineImage{Name: "ubuntu", Version: "1", ID: &profileID}),
+
+		Entry("no image reference", makeProfileMachineImageWithIDandURN("ubuntu", "1", nil, nil), "ubuntu", "1", "westeurope", nil),
+
+		Entry("region mapping takes precedence", makeProfileMachineImageWithRegions("ubuntu", "1", &profileURN, api.RegionImageMapping{Name: "westeurope", ID: &profileID}), "ubuntu", "1", "westeurope", &api.MachineImage{Name: "ubuntu", Version: "1", ID: &profileID}),
+		Entry("region mapping of other region", makeProfileMachineImageWithRegions("ubuntu", "1", &profileURN, api.RegionImageMapping{Name: "northeurope", ID: &profileID}), "ubuntu", "1", "westeurope", &api.MachineImage{Name: "ubuntu", Version: "1", URN: &profileURN}),
+		Entry("region mapping only, region found", makeProfileMachineImageWithRegions("ubuntu", "1", nil, api.RegionImageMapping{Name: "westeurope", ID: &profileID}), "ubuntu", "1", "westeurope", &api.MachineImage{Name: "ubuntu", Version: "1", ID: &profileID}),
+		Entry("region mapping only, region not found", makeProfileMachineImageWithRegions("ubuntu", "1", nil, api.RegionImageMapping{Name: "northeurope", ID: &profileID}), "ubuntu", "1", "westeurope", nil),
+	)
+
+	DescribeTable("#FindImageFromCloudProfile with machine type capabilities",
+		func(machineType *api.MachineType, expectedImage *api.MachineImage) {
+			cfg := &api.CloudProfileConfig{
+				MachineImages: []api.MachineImages{
+					{
+						Name: "ubuntu",
+						Versions: []api.MachineImageVersion{
+							{Version: "1", URN: &profileURN},
+							{Version: "1", ID: &profileID, HyperVGeneration: &generationV2},
+							{Version: "1", ID: &profileARM64ID, Architecture: &arm64, HyperVGeneration: &generationV2},
+						},
+					},
+				},
+			}
+			image, err := FindImageFromCloudProfile(cfg, "ubuntu", "1", "westeurope", machineType)
 
 			Expect(image).To(Equal(expectedImage))
 			if expectedImage != nil {
@@ -124,16 +222,21 @@ var _ = Describe("Helper", func() {
 			}
 		},
 
-		Entry("list is nil", nil, "ubuntu", "1", nil),
+		Entry("no machine type", nil, &api.MachineImage{Name: "ubuntu", Version: "1", URN: &profileURN}),
+		Entry("machine type without capabilities", &api.MachineType{Name: "foo"}, &api.MachineImage{Name: "ubuntu", Version: "1", URN: &profileURN}),
+		Entry("machine type supporting only generation 2", &api.MachineType{Name: "foo", HyperVGenerations: []api.HyperVGeneration{generationV2}}, &api.MachineImage{Name: "ubuntu", Version: "1", ID: &profileID, HyperVGeneration: &generationV2}),
+		Entry("arm64 machine type", &api.MachineType{Name: "foo", Architecture: &arm64}, &api.MachineImage{Name: "ubuntu", Version: "1", ID: &profileARM64ID, Architecture: &arm64, HyperVGeneration: &generationV2}),
+		Entry("arm64 machine type supporting only generation 1", &api.MachineType{Name: "foo", Architecture: &arm64, HyperVGenerations: []api.HyperVGeneration{api.HyperVGenerationV1}}, nil),
+	)
 
-		Entry("profile empty list", []api.MachineImages{}, "ubuntu", "1", nil),
-		Entry("profile entry not found (image does not exist)", makeProfileMachineImages("debian", "1", "3"), "ubuntu", "1", nil),
-		Entry("profile entry not found (version does not exist)", makeProfileMachineImages("ubuntu", "2", "4"), "ubuntu", "1", nil),
-		Entry("profile entry(urn)", makeProfileMachineImages("ubuntu", "1", "3"), "ubuntu", "1", &api.MachineImage{Name: "ubuntu", Version: "1", URN: &profileURN}),
-		Entry("profile entry(id)", makeProfileMachineImages("ubuntu", "1", "3"), "ubuntu", "3", &api.MachineImage{Name: "ubuntu", Version: "3", ID: &profileID}),
+	DescribeTable("#PodNetworkModeOrDefault",
+		func(infrastructureConfig *api.InfrastructureConfig, expectedMode api.PodNetworkMode) {
+			Expect(PodNetworkModeOrDefault(infrastructureConfig)).To(Equal(expectedMode))
+		},
 
-		Entry("valid image reference, only urn", makeProfileMachineImageWithIDandURN("ubuntu", "1", &profileURN, nil), "ubuntu", "1", &api.MachineImage{Name: "ubuntu", Version: "1", URN: &profileURN}),
-		Entry("valid image reference, only id", makeProfileMachineImageWithIDandURN("ubuntu", "1", nil, &profileID), "ubuntu", "1", &api.MachineImage{Name: "ubuntu", Version: "1", ID: &profileID}),
+		Entry("no infrastructure config", nil, api.PodNetworkModeRoutes),
+		Entry("no pod network mode", &api.InfrastructureConfig{}, api.PodNetworkModeRoutes),
+		Entry("overlay pod network mode", &api.InfrastructureConfig{Networks: api.NetworkConfig{PodNetworkMode: &overlay}}, api.PodNetworkModeOverlay),
 	)
 })
 
@@ -170,6 +273,21 @@ func makeProfileMachineImageWithIDandURN(name, version string, urn, id *string)
 	}
 }
 
+func makeProfileMachineImageWithRegions(name, version string, urn *string, regions ...api.RegionImageMapping) []api.MachineImages {
+	return []api.MachineImages{
+		{
+			Name: name,
+			Versions: []api.MachineImageVersion{
+				{
+					Version: version,
+					URN:     urn,
+					Regions: regions,
+				},
+			},
+		},
+	}
+}
+
 func expectResults(result, expected interface{}, err error, expectErr bool) {
 	if !expectErr {
 		Expect(result).To(Equal(expected))
@@ -179,3 +297,7 @@ func expectResults(result, expected interface{}, err error, expectErr bool) {
 		Expect(err).To(HaveOccurred())
 	}
 }
+
+func intPtr(i int) *int {
+	return &i
+}
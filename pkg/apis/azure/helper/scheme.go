@@ -61,6 +61,45 @@ func InfrastructureConfigFromInfrastructure(infra *extensionsv1alpha1.Infrastruc
 	return nil, fmt.Errorf("provider config is not set on the infrastructure resource")
 }
 
+// InfrastructureStatusFromControlPlane extracts the InfrastructureStatus from the InfrastructureProviderStatus section
+// of the given ControlPlane. It returns nil if the status has not been set.
+func InfrastructureStatusFromControlPlane(cp *extensionsv1alpha1.ControlPlane) (*api.InfrastructureStatus, error) {
+	if cp.Spec.InfrastructureProviderStatus == nil || cp.Spec.InfrastructureProviderStatus.Raw == nil {
+		return nil, nil
+	}
+	status := &api.InfrastructureStatus{}
+	if _, _, err := decoder.Decode(cp.Spec.InfrastructureProviderStatus.Raw, nil, status); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// InfrastructureStatusFromWorker extracts the InfrastructureStatus from the InfrastructureProviderStatus section of the
+// given Worker. It returns nil if the status has not been set.
+func InfrastructureStatusFromWorker(worker *extensionsv1alpha1.Worker) (*api.InfrastructureStatus, error) {
+	if worker.Spec.InfrastructureProviderStatus == nil || worker.Spec.InfrastructureProviderStatus.Raw == nil {
+		return nil, nil
+	}
+	status := &api.InfrastructureStatus{}
+	if _, _, err := decoder.Decode(worker.Spec.InfrastructureProviderStatus.Raw, nil, status); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// ControlPlaneStatusFromControlPlane extracts the ControlPlaneStatus from the ProviderStatus section of the given
+// ControlPlane. It returns nil if the status has not been set yet.
+func ControlPlaneStatusFromControlPlane(cp *extensionsv1alpha1.ControlPlane) (*api.ControlPlaneStatus, error) {
+	if cp.Status.ProviderStatus == nil || cp.Status.ProviderStatus.Raw == nil {
+		return nil, nil
+	}
+	status := &api.ControlPlaneStatus{}
+	if _, _, err := decoder.Decode(cp.Status.ProviderStatus.Raw, nil, status); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
 // CloudProfileConfigFromCluster decodes the provider specific cloud profile configuration for a cluster
 func CloudProfileConfigFromCluster(cluster *controller.Cluster) (*api.CloudProfileConfig, error) {
 	var cloudProfileConfig *api.CloudProfileConfig
@@ -72,3 +111,27 @@ func CloudProfileConfigFromCluster(cluster *controller.Cluster) (*api.CloudProfi
 	}
 	return cloudProfileConfig, nil
 }
+
+// InfrastructureConfigFromCluster decodes the provider specific infrastructure configuration of the Shoot of the given cluster.
+func InfrastructureConfigFromCluster(cluster *controller.Cluster) (*api.InfrastructureConfig, error) {
+	var infrastructureConfig *api.InfrastructureConfig
+	if cluster != nil && cluster.Shoot != nil && cluster.Shoot.Spec.Provider.InfrastructureConfig != nil && cluster.Shoot.Spec.Provider.InfrastructureConfig.Raw != nil {
+		infrastructureConfig = &api.InfrastructureConfig{}
+		if _, _, err := decoder.Decode(cluster.Shoot.Spec.Provider.InfrastructureConfig.Raw, nil, infrastructureConfig); err != nil {
+			return nil, errors.Wrapf(err, "could not decode infrastructureConfig of shoot '%s'", util.ObjectName(cluster.Shoot))
+		}
+	}
+	return infrastructureConfig, nil
+}
+
+// ControlPlaneConfigFromCluster decodes the provider specific control plane configuration of the Shoot of the given cluster.
+func ControlPlaneConfigFromCluster(cluster *controller.Cluster) (*api.ControlPlaneConfig, error) {
+	var controlPlaneConfig *api.ControlPlaneConfig
+	if cluster != nil && cluster.Shoot != nil && cluster.Shoot.Spec.Provider.ControlPlaneConfig != nil && cluster.Shoot.Spec.Provider.ControlPlaneConfig.Raw != nil {
+		controlPlaneConfig = &api.ControlPlaneConfig{}
+		if _, _, err := decoder.Decode(cluster.Shoot.Spec.Provider.ControlPlaneConfig.Raw, nil, controlPlaneConfig); err != nil {
+			return nil, errors.Wrapf(err, "could not decode controlPlaneConfig of shoot '%s'", util.ObjectName(cluster.Shoot))
+		}
+	}
+	return controlPlaneConfig, nil
+}
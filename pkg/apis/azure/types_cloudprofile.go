@@ -31,6 +31,38 @@ type CloudProfileConfig struct {
 	// MachineImages is the list of machine images that are understood by the controller. It maps
 	// logical names and versions to provider-specific identifiers.
 	MachineImages []MachineImages
+	// MachineTypes is a list of machine types with provider-specific capability information.
+	MachineTypes []MachineType
+	// Regions is a list of regions with provider-specific information, e.g. the availability zones, the domain counts
+	// and the availability of machine types.
+	Regions []Region
+}
+
+// Region contains provider-specific information about a region.
+type Region struct {
+	// Name is the name of the region.
+	Name string
+	// Zones is the list of availability zones of the region. If the list is empty, the region does not support zoned
+	// clusters.
+	Zones []string
+	// CountUpdateDomains is the update domain count of the region. It takes precedence over the update domain count of
+	// the region in `countUpdateDomains`.
+	CountUpdateDomains *int
+	// CountFaultDomains is the fault domain count of the region. It takes precedence over the fault domain count of
+	// the region in `countFaultDomains`.
+	CountFaultDomains *int
+	// MachineTypes is the list of machine types which are available in the region. If the list is empty, all machine
+	// types are considered to be available in all zones of the region.
+	MachineTypes []RegionMachineType
+}
+
+// RegionMachineType contains information about the availability of a machine type in a region.
+type RegionMachineType struct {
+	// Name is the name of the machine type.
+	Name string
+	// Zones is the list of zones of the region in which the machine type is available. If the list is empty, the
+	// machine type is available in all zones of the region.
+	Zones []string
 }
 
 // DomainCount defines the region and the count for this domain count value.
@@ -57,4 +89,51 @@ type MachineImageVersion struct {
 	URN *string
 	// ID is the image id.
 	ID *string
+	// Regions is a list of region specific image references. In the listed regions they take precedence over
+	// the image reference of the version.
+	Regions []RegionImageMapping
+	// Architecture is the CPU architecture of the image version. Defaults to amd64.
+	// A version can be listed multiple times with different architectures and Hyper-V generations, the variant which
+	// matches the machine type of a worker pool is used.
+	Architecture *string
+	// HyperVGeneration is the Hyper-V generation of the image version. Defaults to V1.
+	HyperVGeneration *HyperVGeneration
+}
+
+// RegionImageMapping contains the image reference of a machine image version for a region.
+type RegionImageMapping struct {
+	// Name is the name of the region.
+	Name string
+	// ID is the VM image ID in the region.
+	ID *string
 }
+
+// MachineType contains provider-specific capability information about a machine type.
+type MachineType struct {
+	// Name is the name of the machine type.
+	Name string
+	// Architecture is the CPU architecture of the machine type. Defaults to amd64.
+	Architecture *string
+	// HyperVGenerations is the list of Hyper-V generations which are supported by the machine type. If the list is
+	// empty, images of all generations can be used.
+	HyperVGenerations []HyperVGeneration
+	// MaxDataDisks is the maximum number of data disks which can be attached to a machine of the machine type.
+	MaxDataDisks *int32
+}
+
+const (
+	// ArchitectureAMD64 is the amd64 CPU architecture.
+	ArchitectureAMD64 = "amd64"
+	// ArchitectureARM64 is the arm64 CPU architecture.
+	ArchitectureARM64 = "arm64"
+)
+
+// HyperVGeneration is the Hyper-V generation of a machine image or a machine type.
+type HyperVGeneration string
+
+const (
+	// HyperVGenerationV1 is the Hyper-V generation 1.
+	HyperVGenerationV1 HyperVGeneration = "V1"
+	// HyperVGenerationV2 is the Hyper-V generation 2.
+	HyperVGenerationV2 HyperVGeneration = "V2"
+)
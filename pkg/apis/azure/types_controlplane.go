@@ -15,6 +15,8 @@
 package azure
 
 import (
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -27,10 +29,273 @@ type ControlPlaneConfig struct {
 	// CloudControllerManager contains configuration settings for the cloud-controller-manager.
 	// +optional
 	CloudControllerManager *CloudControllerManagerConfig
+	// LoadBalancer contains configuration settings for the load balancers of the services of type LoadBalancer.
+	LoadBalancer *LoadBalancerConfig
+	// Storage contains configuration settings for the storage classes of the cluster.
+	Storage *Storage
+	// KMS contains the settings for the encryption of secrets in etcd with a key of an Azure Key Vault.
+	KMS *KMS
+	// KubeAPIServerExposure contains the settings for the exposure of the kube-apiserver. Settings which are not set
+	// fall back to the defaults of the extension.
+	KubeAPIServerExposure *KubeAPIServerExposure
+	// ScheduledEventsHandler contains the settings of the handler of the Azure Scheduled Events, which drains the nodes
+	// before they are affected by a maintenance or an eviction.
+	ScheduledEventsHandler *ScheduledEventsHandler
 }
 
 // CloudControllerManagerConfig contains configuration settings for the cloud-controller-manager.
 type CloudControllerManagerConfig struct {
 	// FeatureGates contains information about enabled feature gates.
 	FeatureGates map[string]bool
+	// RateLimit contains the settings for the client side rate limiting of the requests to the Azure API.
+	RateLimit *CloudProviderRateLimit
+	// Backoff contains the settings for the retries of failed requests to the Azure API.
+	Backoff *CloudProviderBackoff
 }
+
+// CloudProviderRateLimit contains the settings for the client side rate limiting of the requests to the Azure API.
+// Settings which are not set fall back to the defaults of the extension.
+type CloudProviderRateLimit struct {
+	// QPS is the number of read requests per second.
+	QPS *float64
+	// Bucket is the maximum number of read requests in a burst.
+	Bucket *int32
+	// QPSWrite is the number of write requests per second.
+	QPSWrite *float64
+	// BucketWrite is the maximum number of write requests in a burst.
+	BucketWrite *int32
+}
+
+// CloudProviderBackoff contains the settings for the retries of failed requests to the Azure API. Settings which are
+// not set fall back to the defaults of the extension.
+type CloudProviderBackoff struct {
+	// Retries is the number of retries of a failed request.
+	Retries *int32
+	// Exponent is the factor by which the duration between two retries is multiplied.
+	Exponent *float64
+	// DurationSeconds is the initial duration between two retries in seconds.
+	DurationSeconds *int32
+	// Jitter is the factor of the duration between two retries which is randomly added to it.
+	Jitter *float64
+}
+
+// LoadBalancerConfig contains configuration settings for the load balancers of the services of type LoadBalancer.
+type LoadBalancerConfig struct {
+	// OutboundRule contains configuration for an outbound rule of the standard load balancer which provides the
+	// outbound connectivity of the worker nodes. It is only supported for zoned clusters.
+	OutboundRule *OutboundRule
+	// DisableOutboundSNAT indicates whether the load balancing rules of the standard load balancer do not provide
+	// outbound connectivity via source network address translation. It is always true if an outbound rule is configured.
+	DisableOutboundSNAT *bool
+	// ExcludedWorkerPools is a list of names of worker pools whose nodes are excluded from the load balancers.
+	ExcludedWorkerPools []string
+	// PreConfiguredBackendPoolType is the type of the load balancers whose backend pools are preconfigured, i.e. the
+	// cloud-controller-manager does not add or remove the nodes of the cluster to or from their backend pools.
+	PreConfiguredBackendPoolType *PreConfiguredBackendPoolType
+}
+
+// OutboundRule contains configuration for an outbound rule of the standard load balancer.
+type OutboundRule struct {
+	// AllocatedOutboundPorts is the number of outbound ports which are allocated per node. It must be a multiple of 8
+	// between 0 and 64000. If it is 0 or not set, Azure allocates the ports automatically based on the number of nodes.
+	AllocatedOutboundPorts *int32
+	// IdleTimeoutInMinutes is the idle timeout of outbound connections in minutes. It must be between 4 and 120.
+	// Defaults to 4.
+	IdleTimeoutInMinutes *int32
+}
+
+// PreConfiguredBackendPoolType is a type of load balancers whose backend pools are preconfigured.
+type PreConfiguredBackendPoolType string
+
+const (
+	// PreConfiguredBackendPoolTypeInternal preconfigures the backend pools of internal load balancers.
+	PreConfiguredBackendPoolTypeInternal PreConfiguredBackendPoolType = "Internal"
+	// PreConfiguredBackendPoolTypeExternal preconfigures the backend pools of external load balancers.
+	PreConfiguredBackendPoolTypeExternal PreConfiguredBackendPoolType = "External"
+	// PreConfiguredBackendPoolTypeAll preconfigures the backend pools of all load balancers.
+	PreConfiguredBackendPoolTypeAll PreConfiguredBackendPoolType = "All"
+)
+
+// Storage contains configuration settings for the storage classes of the cluster.
+type Storage struct {
+	// ManagedStorageClasses indicates whether the storage classes provided by Gardener are deployed. Defaults to true.
+	ManagedStorageClasses *bool
+	// DefaultStorageClass is the name of the storage class which is marked as the default storage class of the
+	// cluster. Defaults to the "default" storage class provided by Gardener.
+	DefaultStorageClass *string
+	// StorageClasses is a list of additional storage classes for Azure managed disks.
+	StorageClasses []StorageClass
+	// VolumeSnapshotClass contains the settings of the default volume snapshot class. The volume snapshot class is
+	// only deployed for clusters which use the CSI drivers.
+	VolumeSnapshotClass *VolumeSnapshotClass
+}
+
+// StorageClass contains the settings of a storage class for Azure managed disks.
+type StorageClass struct {
+	// Name is the name of the storage class.
+	Name string
+	// SkuName is the SKU of the managed disks, e.g. Premium_LRS or StandardSSD_ZRS.
+	SkuName string
+	// CachingMode is the host caching mode of the managed disks. It must be one of None, ReadOnly or ReadWrite.
+	CachingMode *string
+	// VolumeBindingMode indicates when the volumes are provisioned and bound. Defaults to WaitForFirstConsumer for
+	// zoned clusters and for clusters which use the CSI drivers, to Immediate otherwise.
+	VolumeBindingMode *storagev1.VolumeBindingMode
+	// ReclaimPolicy is the reclaim policy of the provisioned volumes. Defaults to Delete.
+	ReclaimPolicy *corev1.PersistentVolumeReclaimPolicy
+	// DiskEncryptionSetID is the resource id of the disk encryption set which is used to encrypt the managed disks.
+	// Defaults to the disk encryption set of the InfrastructureConfig.
+	DiskEncryptionSetID *string
+	// Zones is a list of zones of the region to which the provisioning of the volumes is restricted. It is only
+	// supported for zoned clusters.
+	Zones []string
+}
+
+// VolumeSnapshotClass contains the settings of the default volume snapshot class for Azure managed disks.
+type VolumeSnapshotClass struct {
+	// Incremental indicates whether incremental snapshots are taken, which only store the changes since the last
+	// snapshot. Defaults to true.
+	Incremental *bool
+	// ResourceGroup is the name of the resource group in which the snapshots are stored. Defaults to the resource group
+	// of the cluster.
+	ResourceGroup *string
+}
+
+// KMS contains the settings for the encryption of secrets in etcd with a key of an Azure Key Vault. The Azure KMS plugin
+// runs as sidecar of the kube-apiserver and encrypts the data encryption keys with the key encryption key of the
+// Key Vault.
+type KMS struct {
+	// KeyVaultName is the name of the Azure Key Vault which contains the key encryption key.
+	KeyVaultName string
+	// KeyName is the name of the key encryption key.
+	KeyName string
+	// KeyVersion is the version of the key encryption key which is used to encrypt new data.
+	KeyVersion string
+	// PreviousKeyVersions are the versions of the key encryption key which were used before the key was rotated. They
+	// are still used to decrypt existing data and can be removed once all secrets have been re-encrypted.
+	PreviousKeyVersions []string
+}
+
+// KubeAPIServerExposure contains the settings for the exposure of the kube-apiserver by the load balancer of its service.
+// Whether it is exposed by an internal load balancer is configured by the seed.
+type KubeAPIServerExposure struct {
+	// PrivateLinkService contains the settings for the publication of the internal load balancer through a Private
+	// Link Service.
+	PrivateLinkService *PrivateLinkService
+	// TCPIdleTimeoutInMinutes is the TCP idle timeout of the load balancer in minutes.
+	TCPIdleTimeoutInMinutes *int32
+	// DNSLabel is the DNS label of the static public IP of the kube-apiserver. It is only used if the seed reserves
+	// static public IPs for the kube-apiservers.
+	DNSLabel *string
+}
+
+// PrivateLinkService contains the settings for the publication of the internal load balancer of the kube-apiserver
+// through a Private Link Service, so that it can be reached by private endpoints in other virtual networks.
+type PrivateLinkService struct {
+	// Enabled specifies whether the internal load balancer is published through a Private Link Service.
+	Enabled *bool
+	// AllowedSubscriptions are the subscriptions which can create private endpoints for the Private Link Service.
+	// "*" allows all subscriptions.
+	AllowedSubscriptions []string
+	// AutoApprovedSubscriptions are the subscriptions whose private endpoints are approved automatically.
+	AutoApprovedSubscriptions []string
+}
+
+// ScheduledEventsHandler contains the settings of the handler of the Azure Scheduled Events. The handler runs on every
+// node, watches the scheduled events of its virtual machine in the Azure Instance Metadata Service, cordons and drains
+// the node before the event and acknowledges the event afterwards.
+type ScheduledEventsHandler struct {
+	// Enabled indicates whether the handler is deployed to the nodes of the cluster.
+	Enabled bool
+	// EventTypes are the types of the scheduled events before which the nodes are drained. Defaults to Reboot,
+	// Redeploy, Preempt and Terminate.
+	EventTypes []ScheduledEventType
+	// DrainTimeout is the maximum duration of the drain of a node. The event is acknowledged once the node has been
+	// drained or the timeout has expired, at the latest when the event is due. Defaults to 5 minutes.
+	DrainTimeout *metav1.Duration
+}
+
+// ScheduledEventType is a type of an Azure scheduled event.
+type ScheduledEventType string
+
+const (
+	// ScheduledEventTypeFreeze means that the virtual machine is paused for a few seconds.
+	ScheduledEventTypeFreeze ScheduledEventType = "Freeze"
+	// ScheduledEventTypeReboot means that the virtual machine is rebooted.
+	ScheduledEventTypeReboot ScheduledEventType = "Reboot"
+	// ScheduledEventTypeRedeploy means that the virtual machine is moved to another host and loses its temporary disk.
+	ScheduledEventTypeRedeploy ScheduledEventType = "Redeploy"
+	// ScheduledEventTypePreempt means that the spot virtual machine is evicted.
+	ScheduledEventTypePreempt ScheduledEventType = "Preempt"
+	// ScheduledEventTypeTerminate means that the virtual machine is deleted.
+	ScheduledEventTypeTerminate ScheduledEventType = "Terminate"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ControlPlaneStatus contains information about the resources of the control plane.
+type ControlPlaneStatus struct {
+	metav1.TypeMeta
+
+	// KubeAPIServerExposure is the exposure of the kube-apiserver which has been fixed when the shoot was created.
+	KubeAPIServerExposure *KubeAPIServerExposureStatus
+	// PublicIP is the static public IP of the kube-apiserver.
+	PublicIP *PublicIPStatus
+	// LoadBalancerMigration is the state of the migration of the load balancers of the shoot from the basic to the
+	// standard SKU.
+	LoadBalancerMigration *LoadBalancerMigrationStatus
+}
+
+// KubeAPIServerExposureStatus contains information about the exposure of the kube-apiserver.
+type KubeAPIServerExposureStatus struct {
+	// Internal specifies whether the kube-apiserver is exposed by an internal load balancer in the virtual network of
+	// the seed instead of a public one.
+	Internal bool
+}
+
+// PublicIPStatus contains information about the static public IP of the kube-apiserver.
+type PublicIPStatus struct {
+	// Name is the name of the public IP.
+	Name string
+	// ResourceGroup is the name of the resource group of the public IP.
+	ResourceGroup string
+	// IPAddress is the address of the public IP.
+	IPAddress string
+	// FQDN is the fully qualified domain name of the DNS label of the public IP.
+	FQDN *string
+}
+
+// LoadBalancerMigrationStatus contains information about the migration of the load balancers of the shoot from the
+// basic to the standard SKU.
+type LoadBalancerMigrationStatus struct {
+	// State is the last step of the migration which has been completed.
+	State LoadBalancerMigrationState
+	// LastUpdateTime is the time when the state has been updated.
+	LastUpdateTime metav1.Time
+	// IgnoredManagedResources are the names of the ManagedResources of the shoot which are ignored by the
+	// gardener-resource-manager while the load balancers are migrated.
+	IgnoredManagedResources []string
+}
+
+// LoadBalancerMigrationState is a step of the migration of the load balancers of the shoot from the basic to the
+// standard SKU.
+type LoadBalancerMigrationState string
+
+const (
+	// LoadBalancerMigrationStateManagedResourcesIgnored means that the ManagedResources of the shoot are ignored by the
+	// gardener-resource-manager so that it does not recreate the services of type LoadBalancer.
+	LoadBalancerMigrationStateManagedResourcesIgnored LoadBalancerMigrationState = "ManagedResourcesIgnored"
+	// LoadBalancerMigrationStateServicesBackedUp means that the services of type LoadBalancer have been backed up.
+	LoadBalancerMigrationStateServicesBackedUp LoadBalancerMigrationState = "ServicesBackedUp"
+	// LoadBalancerMigrationStateServicesDeleted means that the services of type LoadBalancer have been deleted.
+	LoadBalancerMigrationStateServicesDeleted LoadBalancerMigrationState = "ServicesDeleted"
+	// LoadBalancerMigrationStateLoadBalancersDeleted means that the Azure load balancers have been deleted.
+	LoadBalancerMigrationStateLoadBalancersDeleted LoadBalancerMigrationState = "LoadBalancersDeleted"
+	// LoadBalancerMigrationStateSKUSwitched means that the cloud-controller-manager has been rolled out with the
+	// standard SKU.
+	LoadBalancerMigrationStateSKUSwitched LoadBalancerMigrationState = "SKUSwitched"
+	// LoadBalancerMigrationStateServicesRestored means that the services of type LoadBalancer have been restored.
+	LoadBalancerMigrationStateServicesRestored LoadBalancerMigrationState = "ServicesRestored"
+	// LoadBalancerMigrationStateSucceeded means that the migration has been completed.
+	LoadBalancerMigrationStateSucceeded LoadBalancerMigrationState = "Succeeded"
+)
@@ -42,4 +42,8 @@ type MachineImage struct {
 	URN *string
 	// ID is the VM image ID
 	ID *string
+	// Architecture is the CPU architecture of the image.
+	Architecture *string
+	// HyperVGeneration is the Hyper-V generation of the image.
+	HyperVGeneration *HyperVGeneration
 }
@@ -15,6 +15,8 @@
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -28,6 +30,23 @@ type ControlPlaneConfig struct {
 	// CloudControllerManager contains configuration settings for the cloud-controller-manager.
 	// +optional
 	CloudControllerManager *CloudControllerManagerConfig `json:"cloudControllerManager,omitempty"`
+	// LoadBalancer contains configuration settings for the load balancers of the services of type LoadBalancer.
+	// +optional
+	LoadBalancer *LoadBalancerConfig `json:"loadBalancer,omitempty"`
+	// Storage contains configuration settings for the storage classes of the cluster.
+	// +optional
+	Storage *Storage `json:"storage,omitempty"`
+	// KMS contains the settings for the encryption of secrets in etcd with a key of an Azure Key Vault.
+	// +optional
+	KMS *KMS `json:"kms,omitempty"`
+	// KubeAPIServerExposure contains the settings for the exposure of the kube-apiserver. Settings which are not set
+	// fall back to the defaults of the extension.
+	// +optional
+	KubeAPIServerExposure *KubeAPIServerExposure `json:"kubeAPIServerExposure,omitempty"`
+	// ScheduledEventsHandler contains the settings of the handler of the Azure Scheduled Events, which drains the nodes
+	// before they are affected by a maintenance or an eviction.
+	// +optional
+	ScheduledEventsHandler *ScheduledEventsHandler `json:"scheduledEventsHandler,omitempty"`
 }
 
 // CloudControllerManagerConfig contains configuration settings for the cloud-controller-manager.
@@ -35,4 +54,296 @@ type CloudControllerManagerConfig struct {
 	// FeatureGates contains information about enabled feature gates.
 	// +optional
 	FeatureGates map[string]bool `json:"featureGates,omitempty"`
+	// RateLimit contains the settings for the client side rate limiting of the requests to the Azure API.
+	// +optional
+	RateLimit *CloudProviderRateLimit `json:"rateLimit,omitempty"`
+	// Backoff contains the settings for the retries of failed requests to the Azure API.
+	// +optional
+	Backoff *CloudProviderBackoff `json:"backoff,omitempty"`
+}
+
+// CloudProviderRateLimit contains the settings for the client side rate limiting of the requests to the Azure API.
+// Settings which are not set fall back to the defaults of the extension.
+type CloudProviderRateLimit struct {
+	// QPS is the number of read requests per second.
+	// +optional
+	QPS *float64 `json:"qps,omitempty"`
+	// Bucket is the maximum number of read requests in a burst.
+	// +optional
+	Bucket *int32 `json:"bucket,omitempty"`
+	// QPSWrite is the number of write requests per second.
+	// +optional
+	QPSWrite *float64 `json:"qpsWrite,omitempty"`
+	// BucketWrite is the maximum number of write requests in a burst.
+	// +optional
+	BucketWrite *int32 `json:"bucketWrite,omitempty"`
+}
+
+// CloudProviderBackoff contains the settings for the retries of failed requests to the Azure API. Settings which are
+// not set fall back to the defaults of the extension.
+type CloudProviderBackoff struct {
+	// Retries is the number of retries of a failed request.
+	// +optional
+	Retries *int32 `json:"retries,omitempty"`
+	// Exponent is the factor by which the duration between two retries is multiplied.
+	// +optional
+	Exponent *float64 `json:"exponent,omitempty"`
+	// DurationSeconds is the initial duration between two retries in seconds.
+	// +optional
+	DurationSeconds *int32 `json:"durationSeconds,omitempty"`
+	// Jitter is the factor of the duration between two retries which is randomly added to it.
+	// +optional
+	Jitter *float64 `json:"jitter,omitempty"`
+}
+
+// LoadBalancerConfig contains configuration settings for the load balancers of the services of type LoadBalancer.
+type LoadBalancerConfig struct {
+	// OutboundRule contains configuration for an outbound rule of the standard load balancer which provides the
+	// outbound connectivity of the worker nodes. It is only supported for zoned clusters.
+	// +optional
+	OutboundRule *OutboundRule `json:"outboundRule,omitempty"`
+	// DisableOutboundSNAT indicates whether the load balancing rules of the standard load balancer do not provide
+	// outbound connectivity via source network address translation. It is always true if an outbound rule is configured.
+	// +optional
+	DisableOutboundSNAT *bool `json:"disableOutboundSNAT,omitempty"`
+	// ExcludedWorkerPools is a list of names of worker pools whose nodes are excluded from the load balancers.
+	// +optional
+	ExcludedWorkerPools []string `json:"excludedWorkerPools,omitempty"`
+	// PreConfiguredBackendPoolType is the type of the load balancers whose backend pools are preconfigured, i.e. the
+	// cloud-controller-manager does not add or remove the nodes of the cluster to or from their backend pools.
+	// +optional
+	PreConfiguredBackendPoolType *PreConfiguredBackendPoolType `json:"preConfiguredBackendPoolType,omitempty"`
+}
+
+// OutboundRule contains configuration for an outbound rule of the standard load balancer.
+type OutboundRule struct {
+	// AllocatedOutboundPorts is the number of outbound ports which are allocated per node. It must be a multiple of 8
+	// between 0 and 64000. If it is 0 or not set, Azure allocates the ports automatically based on the number of nodes.
+	// +optional
+	AllocatedOutboundPorts *int32 `json:"allocatedOutboundPorts,omitempty"`
+	// IdleTimeoutInMinutes is the idle timeout of outbound connections in minutes. It must be between 4 and 120.
+	// Defaults to 4.
+	// +optional
+	IdleTimeoutInMinutes *int32 `json:"idleTimeoutInMinutes,omitempty"`
+}
+
+// PreConfiguredBackendPoolType is a type of load balancers whose backend pools are preconfigured.
+type PreConfiguredBackendPoolType string
+
+const (
+	// PreConfiguredBackendPoolTypeInternal preconfigures the backend pools of internal load balancers.
+	PreConfiguredBackendPoolTypeInternal PreConfiguredBackendPoolType = "Internal"
+	// PreConfiguredBackendPoolTypeExternal preconfigures the backend pools of external load balancers.
+	PreConfiguredBackendPoolTypeExternal PreConfiguredBackendPoolType = "External"
+	// PreConfiguredBackendPoolTypeAll preconfigures the backend pools of all load balancers.
+	PreConfiguredBackendPoolTypeAll PreConfiguredBackendPoolType = "All"
+)
+
+// Storage contains configuration settings for the storage classes of the cluster.
+type Storage struct {
+	// ManagedStorageClasses indicates whether the storage classes provided by Gardener are deployed. Defaults to true.
+	// +optional
+	ManagedStorageClasses *bool `json:"managedStorageClasses,omitempty"`
+	// DefaultStorageClass is the name of the storage class which is marked as the default storage class of the
+	// cluster. Defaults to the "default" storage class provided by Gardener.
+	// +optional
+	DefaultStorageClass *string `json:"defaultStorageClass,omitempty"`
+	// StorageClasses is a list of additional storage classes for Azure managed disks.
+	// +optional
+	StorageClasses []StorageClass `json:"storageClasses,omitempty"`
+	// VolumeSnapshotClass contains the settings of the default volume snapshot class. The volume snapshot class is
+	// only deployed for clusters which use the CSI drivers.
+	// +optional
+	VolumeSnapshotClass *VolumeSnapshotClass `json:"volumeSnapshotClass,omitempty"`
+}
+
+// StorageClass contains the settings of a storage class for Azure managed disks.
+type StorageClass struct {
+	// Name is the name of the storage class.
+	Name string `json:"name"`
+	// SkuName is the SKU of the managed disks, e.g. Premium_LRS or StandardSSD_ZRS.
+	SkuName string `json:"skuName"`
+	// CachingMode is the host caching mode of the managed disks. It must be one of None, ReadOnly or ReadWrite.
+	// +optional
+	CachingMode *string `json:"cachingMode,omitempty"`
+	// VolumeBindingMode indicates when the volumes are provisioned and bound. Defaults to WaitForFirstConsumer for
+	// zoned clusters and for clusters which use the CSI drivers, to Immediate otherwise.
+	// +optional
+	VolumeBindingMode *storagev1.VolumeBindingMode `json:"volumeBindingMode,omitempty"`
+	// ReclaimPolicy is the reclaim policy of the provisioned volumes. Defaults to Delete.
+	// +optional
+	ReclaimPolicy *corev1.PersistentVolumeReclaimPolicy `json:"reclaimPolicy,omitempty"`
+	// DiskEncryptionSetID is the resource id of the disk encryption set which is used to encrypt the managed disks.
+	// Defaults to the disk encryption set of the InfrastructureConfig.
+	// +optional
+	DiskEncryptionSetID *string `json:"diskEncryptionSetID,omitempty"`
+	// Zones is a list of zones of the region to which the provisioning of the volumes is restricted. It is only
+	// supported for zoned clusters.
+	// +optional
+	Zones []string `json:"zones,omitempty"`
 }
+
+// VolumeSnapshotClass contains the settings of the default volume snapshot class for Azure managed disks.
+type VolumeSnapshotClass struct {
+	// Incremental indicates whether incremental snapshots are taken, which only store the changes since the last
+	// snapshot. Defaults to true.
+	// +optional
+	Incremental *bool `json:"incremental,omitempty"`
+	// ResourceGroup is the name of the resource group in which the snapshots are stored. Defaults to the resource group
+	// of the cluster.
+	// +optional
+	ResourceGroup *string `json:"resourceGroup,omitempty"`
+}
+
+// KMS contains the settings for the encryption of secrets in etcd with a key of an Azure Key Vault. The Azure KMS plugin
+// runs as sidecar of the kube-apiserver and encrypts the data encryption keys with the key encryption key of the
+// Key Vault.
+type KMS struct {
+	// KeyVaultName is the name of the Azure Key Vault which contains the key encryption key.
+	KeyVaultName string `json:"keyVaultName"`
+	// KeyName is the name of the key encryption key.
+	KeyName string `json:"keyName"`
+	// KeyVersion is the version of the key encryption key which is used to encrypt new data.
+	KeyVersion string `json:"keyVersion"`
+	// PreviousKeyVersions are the versions of the key encryption key which were used before the key was rotated. They
+	// are still used to decrypt existing data and can be removed once all secrets have been re-encrypted.
+	// +optional
+	PreviousKeyVersions []string `json:"previousKeyVersions,omitempty"`
+}
+
+// KubeAPIServerExposure contains the settings for the exposure of the kube-apiserver by the load balancer of its service.
+// Whether it is exposed by an internal load balancer is configured by the seed.
+type KubeAPIServerExposure struct {
+	// PrivateLinkService contains the settings for the publication of the internal load balancer through a Private
+	// Link Service.
+	// +optional
+	PrivateLinkService *PrivateLinkService `json:"privateLinkService,omitempty"`
+	// TCPIdleTimeoutInMinutes is the TCP idle timeout of the load balancer in minutes.
+	// +optional
+	TCPIdleTimeoutInMinutes *int32 `json:"tcpIdleTimeoutInMinutes,omitempty"`
+	// DNSLabel is the DNS label of the static public IP of the kube-apiserver. It is only used if the seed reserves
+	// static public IPs for the kube-apiservers.
+	// +optional
+	DNSLabel *string `json:"dnsLabel,omitempty"`
+}
+
+// PrivateLinkService contains the settings for the publication of the internal load balancer of the kube-apiserver
+// through a Private Link Service, so that it can be reached by private endpoints in other virtual networks.
+type PrivateLinkService struct {
+	// Enabled specifies whether the internal load balancer is published through a Private Link Service.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// AllowedSubscriptions are the subscriptions which can create private endpoints for the Private Link Service.
+	// "*" allows all subscriptions.
+	// +optional
+	AllowedSubscriptions []string `json:"allowedSubscriptions,omitempty"`
+	// AutoApprovedSubscriptions are the subscriptions whose private endpoints are approved automatically.
+	// +optional
+	AutoApprovedSubscriptions []string `json:"autoApprovedSubscriptions,omitempty"`
+}
+
+// ScheduledEventsHandler contains the settings of the handler of the Azure Scheduled Events. The handler runs on every
+// node, watches the scheduled events of its virtual machine in the Azure Instance Metadata Service, cordons and drains
+// the node before the event and acknowledges the event afterwards.
+type ScheduledEventsHandler struct {
+	// Enabled indicates whether the handler is deployed to the nodes of the cluster.
+	Enabled bool `json:"enabled"`
+	// EventTypes are the types of the scheduled events before which the nodes are drained. Defaults to Reboot,
+	// Redeploy, Preempt and Terminate.
+	// +optional
+	EventTypes []ScheduledEventType `json:"eventTypes,omitempty"`
+	// DrainTimeout is the maximum duration of the drain of a node. The event is acknowledged once the node has been
+	// drained or the timeout has expired, at the latest when the event is due. Defaults to 5 minutes.
+	// +optional
+	DrainTimeout *metav1.Duration `json:"drainTimeout,omitempty"`
+}
+
+// ScheduledEventType is a type of an Azure scheduled event.
+type ScheduledEventType string
+
+const (
+	// ScheduledEventTypeFreeze means that the virtual machine is paused for a few seconds.
+	ScheduledEventTypeFreeze ScheduledEventType = "Freeze"
+	// ScheduledEventTypeReboot means that the virtual machine is rebooted.
+	ScheduledEventTypeReboot ScheduledEventType = "Reboot"
+	// ScheduledEventTypeRedeploy means that the virtual machine is moved to another host and loses its temporary disk.
+	ScheduledEventTypeRedeploy ScheduledEventType = "Redeploy"
+	// ScheduledEventTypePreempt means that the spot virtual machine is evicted.
+	ScheduledEventTypePreempt ScheduledEventType = "Preempt"
+	// ScheduledEventTypeTerminate means that the virtual machine is deleted.
+	ScheduledEventTypeTerminate ScheduledEventType = "Terminate"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ControlPlaneStatus contains information about the resources of the control plane.
+type ControlPlaneStatus struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// KubeAPIServerExposure is the exposure of the kube-apiserver which has been fixed when the shoot was created.
+	// +optional
+	KubeAPIServerExposure *KubeAPIServerExposureStatus `json:"kubeAPIServerExposure,omitempty"`
+	// PublicIP is the static public IP of the kube-apiserver.
+	// +optional
+	PublicIP *PublicIPStatus `json:"publicIP,omitempty"`
+	// LoadBalancerMigration is the state of the migration of the load balancers of the shoot from the basic to the
+	// standard SKU.
+	// +optional
+	LoadBalancerMigration *LoadBalancerMigrationStatus `json:"loadBalancerMigration,omitempty"`
+}
+
+// KubeAPIServerExposureStatus contains information about the exposure of the kube-apiserver.
+type KubeAPIServerExposureStatus struct {
+	// Internal specifies whether the kube-apiserver is exposed by an internal load balancer in the virtual network of
+	// the seed instead of a public one.
+	Internal bool `json:"internal"`
+}
+
+// PublicIPStatus contains information about the static public IP of the kube-apiserver.
+type PublicIPStatus struct {
+	// Name is the name of the public IP.
+	Name string `json:"name"`
+	// ResourceGroup is the name of the resource group of the public IP.
+	ResourceGroup string `json:"resourceGroup"`
+	// IPAddress is the address of the public IP.
+	IPAddress string `json:"ipAddress"`
+	// FQDN is the fully qualified domain name of the DNS label of the public IP.
+	// +optional
+	FQDN *string `json:"fqdn,omitempty"`
+}
+
+// LoadBalancerMigrationStatus contains information about the migration of the load balancers of the shoot from the
+// basic to the standard SKU.
+type LoadBalancerMigrationStatus struct {
+	// State is the last step of the migration which has been completed.
+	State LoadBalancerMigrationState `json:"state"`
+	// LastUpdateTime is the time when the state has been updated.
+	LastUpdateTime metav1.Time `json:"lastUpdateTime"`
+	// IgnoredManagedResources are the names of the ManagedResources of the shoot which are ignored by the
+	// gardener-resource-manager while the load balancers are migrated.
+	// +optional
+	IgnoredManagedResources []string `json:"ignoredManagedResources,omitempty"`
+}
+
+// LoadBalancerMigrationState is a step of the migration of the load balancers of the shoot from the basic to the
+// standard SKU.
+type LoadBalancerMigrationState string
+
+const (
+	// LoadBalancerMigrationStateManagedResourcesIgnored means that the ManagedResources of the shoot are ignored by the
+	// gardener-resource-manager so that it does not recreate the services of type LoadBalancer.
+	LoadBalancerMigrationStateManagedResourcesIgnored LoadBalancerMigrationState = "ManagedResourcesIgnored"
+	// LoadBalancerMigrationStateServicesBackedUp means that the services of type LoadBalancer have been backed up.
+	LoadBalancerMigrationStateServicesBackedUp LoadBalancerMigrationState = "ServicesBackedUp"
+	// LoadBalancerMigrationStateServicesDeleted means that the services of type LoadBalancer have been deleted.
+	LoadBalancerMigrationStateServicesDeleted LoadBalancerMigrationState = "ServicesDeleted"
+	// LoadBalancerMigrationStateLoadBalancersDeleted means that the Azure load balancers have been deleted.
+	LoadBalancerMigrationStateLoadBalancersDeleted LoadBalancerMigrationState = "LoadBalancersDeleted"
+	// LoadBalancerMigrationStateSKUSwitched means that the cloud-controller-manager has been rolled out with the
+	// standard SKU.
+	LoadBalancerMigrationStateSKUSwitched LoadBalancerMigrationState = "SKUSwitched"
+	// LoadBalancerMigrationStateServicesRestored means that the services of type LoadBalancer have been restored.
+	LoadBalancerMigrationStateServicesRestored LoadBalancerMigrationState = "ServicesRestored"
+	// LoadBalancerMigrationStateSucceeded means that the migration has been completed.
+	LoadBalancerMigrationStateSucceeded LoadBalancerMigrationState = "Succeeded"
+)
@@ -35,6 +35,21 @@ type InfrastructureConfig struct {
 	// Zoned indicates whether the cluster uses availability zones.
 	// +optional
 	Zoned bool `json:"zoned,omitempty"`
+	// DiskEncryption contains configuration for the encryption of the disks which are provisioned via the storage
+	// classes of the cluster.
+	// +optional
+	DiskEncryption *DiskEncryption `json:"diskEncryption,omitempty"`
+	// ContainerRegistries is a list of Azure Container Registries from which the worker nodes pull images. The nodes
+	// authenticate with the managed identity of the Identity configuration or, if none is configured, with a managed
+	// identity which is created for the cluster. The identity is assigned the AcrPull role on each registry.
+	// +optional
+	ContainerRegistries []ContainerRegistry `json:"containerRegistries,omitempty"`
+}
+
+// ContainerRegistry contains the settings of an Azure Container Registry from which the worker nodes pull images.
+type ContainerRegistry struct {
+	// ID is the Azure resource id of the registry. The registry can be in another subscription than the cluster.
+	ID string `json:"id"`
 }
 
 // ResourceGroup is azure resource group
@@ -55,6 +70,9 @@ type NetworkConfig struct {
 	// ServiceEndpoints is a list of Azure ServiceEndpoints which should be associated with the worker subnet.
 	// +optional
 	ServiceEndpoints []string `json:"serviceEndpoints,omitempty"`
+	// PodNetworkMode is the mode of the pod network, either Routes or Overlay. Defaults to Routes.
+	// +optional
+	PodNetworkMode *PodNetworkMode `json:"podNetworkMode,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -155,12 +173,33 @@ type VNetStatus struct {
 	ResourceGroup *string `json:"resourceGroup,omitempty"`
 }
 
+// PodNetworkMode is the mode of the pod network of a cluster.
+type PodNetworkMode string
+
+const (
+	// PodNetworkModeRoutes routes the pod traffic via the routes of the route table of the worker subnet which are
+	// maintained by the cloud-controller-manager. The number of nodes is limited by the maximum number of routes
+	// of a route table.
+	PodNetworkModeRoutes PodNetworkMode = "Routes"
+	// PodNetworkModeOverlay encapsulates the pod traffic in a VXLAN overlay network between the nodes. No routes are
+	// required, hence the number of nodes is not limited by the route table.
+	PodNetworkModeOverlay PodNetworkMode = "Overlay"
+)
+
 // NatGatewayConfig contains configuration for the nat gateway and the attached resources.
 type NatGatewayConfig struct {
 	// Enabled is an indicator if NAT gateway should be deployed.
 	Enabled bool `json:"enabled"`
 }
 
+// DiskEncryption contains configuration for the encryption of the disks which are provisioned via the storage classes.
+type DiskEncryption struct {
+	// DiskEncryptionSetID is the Azure resource id of a disk encryption set which is used to encrypt
+	// the managed disks with customer-managed keys.
+	// +optional
+	DiskEncryptionSetID *string `json:"diskEncryptionSetID,omitempty"`
+}
+
 // IdentityConfig contains configuration for the managed identity.
 type IdentityConfig struct {
 	// Name is the name of the identity.
@@ -46,4 +46,10 @@ type MachineImage struct {
 	// ID is the VM image ID
 	// +optional
 	ID *string `json:"id,omitempty"`
+	// Architecture is the CPU architecture of the image.
+	// +optional
+	Architecture *string `json:"architecture,omitempty"`
+	// HyperVGeneration is the Hyper-V generation of the image.
+	// +optional
+	HyperVGeneration *HyperVGeneration `json:"hyperVGeneration,omitempty"`
 }
@@ -24,6 +24,9 @@ import (
 	unsafe "unsafe"
 
 	azure "github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
@@ -65,6 +68,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*CloudProviderBackoff)(nil), (*azure.CloudProviderBackoff)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_CloudProviderBackoff_To_azure_CloudProviderBackoff(a.(*CloudProviderBackoff), b.(*azure.CloudProviderBackoff), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*azure.CloudProviderBackoff)(nil), (*CloudProviderBackoff)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_azure_CloudProviderBackoff_To_v1alpha1_CloudProviderBackoff(a.(*azure.CloudProviderBackoff), b.(*CloudProviderBackoff), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CloudProviderRateLimit)(nil), (*azure.CloudProviderRateLimit)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_CloudProviderRateLimit_To_azure_CloudProviderRateLimit(a.(*CloudProviderRateLimit), b.(*azure.CloudProviderRateLimit), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*azure.CloudProviderRateLimit)(nil), (*CloudProviderRateLimit)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_azure_CloudProviderRateLimit_To_v1alpha1_CloudProviderRateLimit(a.(*azure.CloudProviderRateLimit), b.(*CloudProviderRateLimit), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ContainerRegistry)(nil), (*azure.ContainerRegistry)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ContainerRegistry_To_azure_ContainerRegistry(a.(*ContainerRegistry), b.(*azure.ContainerRegistry), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*azure.ContainerRegistry)(nil), (*ContainerRegistry)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_azure_ContainerRegistry_To_v1alpha1_ContainerRegistry(a.(*azure.ContainerRegistry), b.(*ContainerRegistry), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ControlPlaneConfig)(nil), (*azure.ControlPlaneConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_ControlPlaneConfig_To_azure_ControlPlaneConfig(a.(*ControlPlaneConfig), b.(*azure.ControlPlaneConfig), scope)
 	}); err != nil {
@@ -75,6 +108,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ControlPlaneStatus)(nil), (*azure.ControlPlaneStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ControlPlaneStatus_To_azure_ControlPlaneStatus(a.(*ControlPlaneStatus), b.(*azure.ControlPlaneStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*azure.ControlPlaneStatus)(nil), (*ControlPlaneStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_azure_ControlPlaneStatus_To_v1alpha1_ControlPlaneStatus(a.(*azure.ControlPlaneStatus), b.(*ControlPlaneStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DiskEncryption)(nil), (*azure.DiskEncryption)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_DiskEncryption_To_azure_DiskEncryption(a.(*DiskEncryption), b.(*azure.DiskEncryption), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*azure.DiskEncryption)(nil), (*DiskEncryption)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_azure_DiskEncryption_To_v1alpha1_DiskEncryption(a.(*azure.DiskEncryption), b.(*DiskEncryption), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*DomainCount)(nil), (*azure.DomainCount)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_DomainCount_To_azure_DomainCount(a.(*DomainCount), b.(*azure.DomainCount), scope)
 	}); err != nil {
@@ -125,6 +178,56 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*KMS)(nil), (*azure.KMS)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_KMS_To_azure_KMS(a.(*KMS), b.(*azure.KMS), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*azure.KMS)(nil), (*KMS)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_azure_KMS_To_v1alpha1_KMS(a.(*azure.KMS), b.(*KMS), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*KubeAPIServerExposure)(nil), (*azure.KubeAPIServerExposure)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_KubeAPIServerExposure_To_azure_KubeAPIServerExposure(a.(*KubeAPIServerExposure), b.(*azure.KubeAPIServerExposure), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*azure.KubeAPIServerExposure)(nil), (*KubeAPIServerExposure)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_azure_KubeAPIServerExposure_To_v1alpha1_KubeAPIServerExposure(a.(*azure.KubeAPIServerExposure), b.(*KubeAPIServerExposure), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*KubeAPIServerExposureStatus)(nil), (*azure.KubeAPIServerExposureStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_KubeAPIServerExposureStatus_To_azure_KubeAPIServerExposureStatus(a.(*KubeAPIServerExposureStatus), b.(*azure.KubeAPIServerExposureStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*azure.KubeAPIServerExposureStatus)(nil), (*KubeAPIServerExposureStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_azure_KubeAPIServerExposureStatus_To_v1alpha1_KubeAPIServerExposureStatus(a.(*azure.KubeAPIServerExposureStatus), b.(*KubeAPIServerExposureStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*LoadBalancerConfig)(nil), (*azure.LoadBalancerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_LoadBalancerConfig_To_azure_LoadBalancerConfig(a.(*LoadBalancerConfig), b.(*azure.LoadBalancerConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*azure.LoadBalancerConfig)(nil), (*LoadBalancerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_azure_LoadBalancerConfig_To_v1alpha1_LoadBalancerConfig(a.(*azure.LoadBalancerConfig), b.(*LoadBalancerConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*LoadBalancerMigrationStatus)(nil), (*azure.LoadBalancerMigrationStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_LoadBalancerMigrationStatus_To_azure_LoadBalancerMigrationStatus(a.(*LoadBalancerMigrationStatus), b.(*azure.LoadBalancerMigrationStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*azure.LoadBalancerMigrationStatus)(nil), (*LoadBalancerMigrationStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_azure_LoadBalancerMigrationStatus_To_v1alpha1_LoadBalancerMigrationStatus(a.(*azure.LoadBalancerMigrationStatus), b.(*LoadBalancerMigrationStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*MachineImage)(nil), (*azure.MachineImage)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_MachineImage_To_azure_MachineImage(a.(*MachineImage), b.(*azure.MachineImage), scope)
 	}); err != nil {
@@ -155,6 +258,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*MachineType)(nil), (*azure.MachineType)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_MachineType_To_azure_MachineType(a.(*MachineType), b.(*azure.MachineType), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*azure.MachineType)(nil), (*MachineType)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_azure_MachineType_To_v1alpha1_MachineType(a.(*azure.MachineType), b.(*MachineType), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*NatGatewayConfig)(nil), (*azure.NatGatewayConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_NatGatewayConfig_To_azure_NatGatewayConfig(a.(*NatGatewayConfig), b.(*azure.NatGatewayConfig), scope)
 	}); err != nil {
@@ -185,6 +298,66 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*OutboundRule)(nil), (*azure.OutboundRule)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_OutboundRule_To_azure_OutboundRule(a.(*OutboundRule), b.(*azure.OutboundRule), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*azure.OutboundRule)(nil), (*OutboundRule)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_azure_OutboundRule_To_v1alpha1_OutboundRule(a.(*azure.OutboundRule), b.(*OutboundRule), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PrivateLinkService)(nil), (*azure.PrivateLinkService)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_PrivateLinkService_To_azure_PrivateLinkService(a.(*PrivateLinkService), b.(*azure.PrivateLinkService), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*azure.PrivateLinkService)(nil), (*PrivateLinkService)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_azure_PrivateLinkService_To_v1alpha1_PrivateLinkService(a.(*azure.PrivateLinkService), b.(*PrivateLinkService), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PublicIPStatus)(nil), (*azure.PublicIPStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_PublicIPStatus_To_azure_PublicIPStatus(a.(*PublicIPStatus), b.(*azure.PublicIPStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*azure.PublicIPStatus)(nil), (*PublicIPStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_azure_PublicIPStatus_To_v1alpha1_PublicIPStatus(a.(*azure.PublicIPStatus), b.(*PublicIPStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*Region)(nil), (*azure.Region)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_Region_To_azure_Region(a.(*Region), b.(*azure.Region), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*azure.Region)(nil), (*Region)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_azure_Region_To_v1alpha1_Region(a.(*azure.Region), b.(*Region), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*RegionImageMapping)(nil), (*azure.RegionImageMapping)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_RegionImageMapping_To_azure_RegionImageMapping(a.(*RegionImageMapping), b.(*azure.RegionImageMapping), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*azure.RegionImageMapping)(nil), (*RegionImageMapping)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_azure_RegionImageMapping_To_v1alpha1_RegionImageMapping(a.(*azure.RegionImageMapping), b.(*RegionImageMapping), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*RegionMachineType)(nil), (*azure.RegionMachineType)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_RegionMachineType_To_azure_RegionMachineType(a.(*RegionMachineType), b.(*azure.RegionMachineType), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*azure.RegionMachineType)(nil), (*RegionMachineType)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_azure_RegionMachineType_To_v1alpha1_RegionMachineType(a.(*azure.RegionMachineType), b.(*RegionMachineType), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ResourceGroup)(nil), (*azure.ResourceGroup)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_ResourceGroup_To_azure_ResourceGroup(a.(*ResourceGroup), b.(*azure.ResourceGroup), scope)
 	}); err != nil {
@@ -205,6 +378,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ScheduledEventsHandler)(nil), (*azure.ScheduledEventsHandler)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ScheduledEventsHandler_To_azure_ScheduledEventsHandler(a.(*ScheduledEventsHandler), b.(*azure.ScheduledEventsHandler), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*azure.ScheduledEventsHandler)(nil), (*ScheduledEventsHandler)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_azure_ScheduledEventsHandler_To_v1alpha1_ScheduledEventsHandler(a.(*azure.ScheduledEventsHandler), b.(*ScheduledEventsHandler), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*SecurityGroup)(nil), (*azure.SecurityGroup)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_SecurityGroup_To_azure_SecurityGroup(a.(*SecurityGroup), b.(*azure.SecurityGroup), scope)
 	}); err != nil {
@@ -215,6 +398,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*Storage)(nil), (*azure.Storage)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_Storage_To_azure_Storage(a.(*Storage), b.(*azure.Storage), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*azure.Storage)(nil), (*Storage)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_azure_Storage_To_v1alpha1_Storage(a.(*azure.Storage), b.(*Storage), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*StorageClass)(nil), (*azure.StorageClass)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_StorageClass_To_azure_StorageClass(a.(*StorageClass), b.(*azure.StorageClass), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*azure.StorageClass)(nil), (*StorageClass)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_azure_StorageClass_To_v1alpha1_StorageClass(a.(*azure.StorageClass), b.(*StorageClass), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*Subnet)(nil), (*azure.Subnet)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_Subnet_To_azure_Subnet(a.(*Subnet), b.(*azure.Subnet), scope)
 	}); err != nil {
@@ -245,6 +448,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*VolumeSnapshotClass)(nil), (*azure.VolumeSnapshotClass)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_VolumeSnapshotClass_To_azure_VolumeSnapshotClass(a.(*VolumeSnapshotClass), b.(*azure.VolumeSnapshotClass), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*azure.VolumeSnapshotClass)(nil), (*VolumeSnapshotClass)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_azure_VolumeSnapshotClass_To_v1alpha1_VolumeSnapshotClass(a.(*azure.VolumeSnapshotClass), b.(*VolumeSnapshotClass), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*WorkerStatus)(nil), (*azure.WorkerStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_WorkerStatus_To_azure_WorkerStatus(a.(*WorkerStatus), b.(*azure.WorkerStatus), scope)
 	}); err != nil {
@@ -284,6 +497,8 @@ func Convert_azure_AvailabilitySet_To_v1alpha1_AvailabilitySet(in *azure.Availab
 
 func autoConvert_v1alpha1_CloudControllerManagerConfig_To_azure_CloudControllerManagerConfig(in *CloudControllerManagerConfig, out *azure.CloudControllerManagerConfig, s conversion.Scope) error {
 	out.FeatureGates = *(*map[string]bool)(unsafe.Pointer(&in.FeatureGates))
+	out.RateLimit = (*azure.CloudProviderRateLimit)(unsafe.Pointer(in.RateLimit))
+	out.Backoff = (*azure.CloudProviderBackoff)(unsafe.Pointer(in.Backoff))
 	return nil
 }
 
@@ -294,6 +509,8 @@ func Convert_v1alpha1_CloudControllerManagerConfig_To_azure_CloudControllerManag
 
 func autoConvert_azure_CloudControllerManagerConfig_To_v1alpha1_CloudControllerManagerConfig(in *azure.CloudControllerManagerConfig, out *CloudControllerManagerConfig, s conversion.Scope) error {
 	out.FeatureGates = *(*map[string]bool)(unsafe.Pointer(&in.FeatureGates))
+	out.RateLimit = (*CloudProviderRateLimit)(unsafe.Pointer(in.RateLimit))
+	out.Backoff = (*CloudProviderBackoff)(unsafe.Pointer(in.Backoff))
 	return nil
 }
 
@@ -306,6 +523,8 @@ func autoConvert_v1alpha1_CloudProfileConfig_To_azure_CloudProfileConfig(in *Clo
 	out.CountUpdateDomains = *(*[]azure.DomainCount)(unsafe.Pointer(&in.CountUpdateDomains))
 	out.CountFaultDomains = *(*[]azure.DomainCount)(unsafe.Pointer(&in.CountFaultDomains))
 	out.MachineImages = *(*[]azure.MachineImages)(unsafe.Pointer(&in.MachineImages))
+	out.MachineTypes = *(*[]azure.MachineType)(unsafe.Pointer(&in.MachineTypes))
+	out.Regions = *(*[]azure.Region)(unsafe.Pointer(&in.Regions))
 	return nil
 }
 
@@ -318,6 +537,8 @@ func autoConvert_azure_CloudProfileConfig_To_v1alpha1_CloudProfileConfig(in *azu
 	out.CountUpdateDomains = *(*[]DomainCount)(unsafe.Pointer(&in.CountUpdateDomains))
 	out.CountFaultDomains = *(*[]DomainCount)(unsafe.Pointer(&in.CountFaultDomains))
 	out.MachineImages = *(*[]MachineImages)(unsafe.Pointer(&in.MachineImages))
+	out.MachineTypes = *(*[]MachineType)(unsafe.Pointer(&in.MachineTypes))
+	out.Regions = *(*[]Region)(unsafe.Pointer(&in.Regions))
 	return nil
 }
 
@@ -326,8 +547,85 @@ func Convert_azure_CloudProfileConfig_To_v1alpha1_CloudProfileConfig(in *azure.C
 	return autoConvert_azure_CloudProfileConfig_To_v1alpha1_CloudProfileConfig(in, out, s)
 }
 
+func autoConvert_v1alpha1_CloudProviderBackoff_To_azure_CloudProviderBackoff(in *CloudProviderBackoff, out *azure.CloudProviderBackoff, s conversion.Scope) error {
+	out.Retries = (*int32)(unsafe.Pointer(in.Retries))
+	out.Exponent = (*float64)(unsafe.Pointer(in.Exponent))
+	out.DurationSeconds = (*int32)(unsafe.Pointer(in.DurationSeconds))
+	out.Jitter = (*float64)(unsafe.Pointer(in.Jitter))
+	return nil
+}
+
+// Convert_v1alpha1_CloudProviderBackoff_To_azure_CloudProviderBackoff is an autogenerated conversion function.
+func Convert_v1alpha1_CloudProviderBackoff_To_azure_CloudProviderBackoff(in *CloudProviderBackoff, out *azure.CloudProviderBackoff, s conversion.Scope) error {
+	return autoConvert_v1alpha1_CloudProviderBackoff_To_azure_CloudProviderBackoff(in, out, s)
+}
+
+func autoConvert_azure_CloudProviderBackoff_To_v1alpha1_CloudProviderBackoff(in *azure.CloudProviderBackoff, out *CloudProviderBackoff, s conversion.Scope) error {
+	out.Retries = (*int32)(unsafe.Pointer(in.Retries))
+	out.Exponent = (*float64)(unsafe.Pointer(in.Exponent))
+	out.DurationSeconds = (*int32)(unsafe.Pointer(in.DurationSeconds))
+	out.Jitter = (*float64)(unsafe.Pointer(in.Jitter))
+	return nil
+}
+
+// Convert_azure_CloudProviderBackoff_To_v1alpha1_CloudProviderBackoff is an autogenerated conversion function.
+func Convert_azure_CloudProviderBackoff_To_v1alpha1_CloudProviderBackoff(in *azure.CloudProviderBackoff, out *CloudProviderBackoff, s conversion.Scope) error {
+	return autoConvert_azure_CloudProviderBackoff_To_v1alpha1_CloudProviderBackoff(in, out, s)
+}
+
+func autoConvert_v1alpha1_CloudProviderRateLimit_To_azure_CloudProviderRateLimit(in *CloudProviderRateLimit, out *azure.CloudProviderRateLimit, s conversion.Scope) error {
+	out.QPS = (*float64)(unsafe.Pointer(in.QPS))
+	out.Bucket = (*int32)(unsafe.Pointer(in.Bucket))
+	out.QPSWrite = (*float64)(unsafe.Pointer(in.QPSWrite))
+	out.BucketWrite = (*int32)(unsafe.Pointer(in.BucketWrite))
+	return nil
+}
+
+// Convert_v1alpha1_CloudProviderRateLimit_To_azure_CloudProviderRateLimit is an autogenerated conversion function.
+func Convert_v1alpha1_CloudProviderRateLimit_To_azure_CloudProviderRateLimit(in *CloudProviderRateLimit, out *azure.CloudProviderRateLimit, s conversion.Scope) error {
+	return autoConvert_v1alpha1_CloudProviderRateLimit_To_azure_CloudProviderRateLimit(in, out, s)
+}
+
+func autoConvert_azure_CloudProviderRateLimit_To_v1alpha1_CloudProviderRateLimit(in *azure.CloudProviderRateLimit, out *CloudProviderRateLimit, s conversion.Scope) error {
+	out.QPS = (*float64)(unsafe.Pointer(in.QPS))
+	out.Bucket = (*int32)(unsafe.Pointer(in.Bucket))
+	out.QPSWrite = (*float64)(unsafe.Pointer(in.QPSWrite))
+	out.BucketWrite = (*int32)(unsafe.Pointer(in.BucketWrite))
+	return nil
+}
+
+// Convert_azure_CloudProviderRateLimit_To_v1alpha1_CloudProviderRateLimit is an autogenerated conversion function.
+func Convert_azure_CloudProviderRateLimit_To_v1alpha1_CloudProviderRateLimit(in *azure.CloudProviderRateLimit, out *CloudProviderRateLimit, s conversion.Scope) error {
+	return autoConvert_azure_CloudProviderRateLimit_To_v1alpha1_CloudProviderRateLimit(in, out, s)
+}
+
+func autoConvert_v1alpha1_ContainerRegistry_To_azure_ContainerRegistry(in *ContainerRegistry, out *azure.ContainerRegistry, s conversion.Scope) error {
+	out.ID = in.ID
+	return nil
+}
+
+// Convert_v1alpha1_ContainerRegistry_To_azure_ContainerRegistry is an autogenerated conversion function.
+func Convert_v1alpha1_ContainerRegistry_To_azure_ContainerRegistry(in *ContainerRegistry, out *azure.ContainerRegistry, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ContainerRegistry_To_azure_ContainerRegistry(in, out, s)
+}
+
+func autoConvert_azure_ContainerRegistry_To_v1alpha1_ContainerRegistry(in *azure.ContainerRegistry, out *ContainerRegistry, s conversion.Scope) error {
+	out.ID = in.ID
+	return nil
+}
+
+// Convert_azure_ContainerRegistry_To_v1alpha1_ContainerRegistry is an autogenerated conversion function.
+func Convert_azure_ContainerRegistry_To_v1alpha1_ContainerRegistry(in *azure.ContainerRegistry, out *ContainerRegistry, s conversion.Scope) error {
+	return autoConvert_azure_ContainerRegistry_To_v1alpha1_ContainerRegistry(in, out, s)
+}
+
 func autoConvert_v1alpha1_ControlPlaneConfig_To_azure_ControlPlaneConfig(in *ControlPlaneConfig, out *azure.ControlPlaneConfig, s conversion.Scope) error {
 	out.CloudControllerManager = (*azure.CloudControllerManagerConfig)(unsafe.Pointer(in.CloudControllerManager))
+	out.LoadBalancer = (*azure.LoadBalancerConfig)(unsafe.Pointer(in.LoadBalancer))
+	out.Storage = (*azure.Storage)(unsafe.Pointer(in.Storage))
+	out.KMS = (*azure.KMS)(unsafe.Pointer(in.KMS))
+	out.KubeAPIServerExposure = (*azure.KubeAPIServerExposure)(unsafe.Pointer(in.KubeAPIServerExposure))
+	out.ScheduledEventsHandler = (*azure.ScheduledEventsHandler)(unsafe.Pointer(in.ScheduledEventsHandler))
 	return nil
 }
 
@@ -338,6 +636,11 @@ func Convert_v1alpha1_ControlPlaneConfig_To_azure_ControlPlaneConfig(in *Control
 
 func autoConvert_azure_ControlPlaneConfig_To_v1alpha1_ControlPlaneConfig(in *azure.ControlPlaneConfig, out *ControlPlaneConfig, s conversion.Scope) error {
 	out.CloudControllerManager = (*CloudControllerManagerConfig)(unsafe.Pointer(in.CloudControllerManager))
+	out.LoadBalancer = (*LoadBalancerConfig)(unsafe.Pointer(in.LoadBalancer))
+	out.Storage = (*Storage)(unsafe.Pointer(in.Storage))
+	out.KMS = (*KMS)(unsafe.Pointer(in.KMS))
+	out.KubeAPIServerExposure = (*KubeAPIServerExposure)(unsafe.Pointer(in.KubeAPIServerExposure))
+	out.ScheduledEventsHandler = (*ScheduledEventsHandler)(unsafe.Pointer(in.ScheduledEventsHandler))
 	return nil
 }
 
@@ -346,6 +649,50 @@ func Convert_azure_ControlPlaneConfig_To_v1alpha1_ControlPlaneConfig(in *azure.C
 	return autoConvert_azure_ControlPlaneConfig_To_v1alpha1_ControlPlaneConfig(in, out, s)
 }
 
+func autoConvert_v1alpha1_ControlPlaneStatus_To_azure_ControlPlaneStatus(in *ControlPlaneStatus, out *azure.ControlPlaneStatus, s conversion.Scope) error {
+	out.KubeAPIServerExposure = (*azure.KubeAPIServerExposureStatus)(unsafe.Pointer(in.KubeAPIServerExposure))
+	out.PublicIP = (*azure.PublicIPStatus)(unsafe.Pointer(in.PublicIP))
+	out.LoadBalancerMigration = (*azure.LoadBalancerMigrationStatus)(unsafe.Pointer(in.LoadBalancerMigration))
+	return nil
+}
+
+// Convert_v1alpha1_ControlPlaneStatus_To_azure_ControlPlaneStatus is an autogenerated conversion function.
+func Convert_v1alpha1_ControlPlaneStatus_To_azure_ControlPlaneStatus(in *ControlPlaneStatus, out *azure.ControlPlaneStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ControlPlaneStatus_To_azure_ControlPlaneStatus(in, out, s)
+}
+
+func autoConvert_azure_ControlPlaneStatus_To_v1alpha1_ControlPlaneStatus(in *azure.ControlPlaneStatus, out *ControlPlaneStatus, s conversion.Scope) error {
+	out.KubeAPIServerExposure = (*KubeAPIServerExposureStatus)(unsafe.Pointer(in.KubeAPIServerExposure))
+	out.PublicIP = (*PublicIPStatus)(unsafe.Pointer(in.PublicIP))
+	out.LoadBalancerMigration = (*LoadBalancerMigrationStatus)(unsafe.Pointer(in.LoadBalancerMigration))
+	return nil
+}
+
+// Convert_azure_ControlPlaneStatus_To_v1alpha1_ControlPlaneStatus is an autogenerated conversion function.
+func Convert_azure_ControlPlaneStatus_To_v1alpha1_ControlPlaneStatus(in *azure.ControlPlaneStatus, out *ControlPlaneStatus, s conversion.Scope) error {
+	return autoConvert_azure_ControlPlaneStatus_To_v1alpha1_ControlPlaneStatus(in, out, s)
+}
+
+func autoConvert_v1alpha1_DiskEncryption_To_azure_DiskEncryption(in *DiskEncryption, out *azure.DiskEncryption, s conversion.Scope) error {
+	out.DiskEncryptionSetID = (*string)(unsafe.Pointer(in.DiskEncryptionSetID))
+	return nil
+}
+
+// Convert_v1alpha1_DiskEncryption_To_azure_DiskEncryption is an autogenerated conversion function.
+func Convert_v1alpha1_DiskEncryption_To_azure_DiskEncryption(in *DiskEncryption, out *azure.DiskEncryption, s conversion.Scope) error {
+	return autoConvert_v1alpha1_DiskEncryption_To_azure_DiskEncryption(in, out, s)
+}
+
+func autoConvert_azure_DiskEncryption_To_v1alpha1_DiskEncryption(in *azure.DiskEncryption, out *DiskEncryption, s conversion.Scope) error {
+	out.DiskEncryptionSetID = (*string)(unsafe.Pointer(in.DiskEncryptionSetID))
+	return nil
+}
+
+// Convert_azure_DiskEncryption_To_v1alpha1_DiskEncryption is an autogenerated conversion function.
+func Convert_azure_DiskEncryption_To_v1alpha1_DiskEncryption(in *azure.DiskEncryption, out *DiskEncryption, s conversion.Scope) error {
+	return autoConvert_azure_DiskEncryption_To_v1alpha1_DiskEncryption(in, out, s)
+}
+
 func autoConvert_v1alpha1_DomainCount_To_azure_DomainCount(in *DomainCount, out *azure.DomainCount, s conversion.Scope) error {
 	out.Region = in.Region
 	out.Count = in.Count
@@ -423,6 +770,8 @@ func autoConvert_v1alpha1_InfrastructureConfig_To_azure_InfrastructureConfig(in
 	}
 	out.Identity = (*azure.IdentityConfig)(unsafe.Pointer(in.Identity))
 	out.Zoned = in.Zoned
+	out.DiskEncryption = (*azure.DiskEncryption)(unsafe.Pointer(in.DiskEncryption))
+	out.ContainerRegistries = *(*[]azure.ContainerRegistry)(unsafe.Pointer(&in.ContainerRegistries))
 	return nil
 }
 
@@ -438,6 +787,8 @@ func autoConvert_azure_InfrastructureConfig_To_v1alpha1_InfrastructureConfig(in
 	}
 	out.Identity = (*IdentityConfig)(unsafe.Pointer(in.Identity))
 	out.Zoned = in.Zoned
+	out.DiskEncryption = (*DiskEncryption)(unsafe.Pointer(in.DiskEncryption))
+	out.ContainerRegistries = *(*[]ContainerRegistry)(unsafe.Pointer(&in.ContainerRegistries))
 	return nil
 }
 
@@ -486,11 +837,133 @@ func Convert_azure_InfrastructureStatus_To_v1alpha1_InfrastructureStatus(in *azu
 	return autoConvert_azure_InfrastructureStatus_To_v1alpha1_InfrastructureStatus(in, out, s)
 }
 
+func autoConvert_v1alpha1_KMS_To_azure_KMS(in *KMS, out *azure.KMS, s conversion.Scope) error {
+	out.KeyVaultName = in.KeyVaultName
+	out.KeyName = in.KeyName
+	out.KeyVersion = in.KeyVersion
+	out.PreviousKeyVersions = *(*[]string)(unsafe.Pointer(&in.PreviousKeyVersions))
+	return nil
+}
+
+// Convert_v1alpha1_KMS_To_azure_KMS is an autogenerated conversion function.
+func Convert_v1alpha1_KMS_To_azure_KMS(in *KMS, out *azure.KMS, s conversion.Scope) error {
+	return autoConvert_v1alpha1_KMS_To_azure_KMS(in, out, s)
+}
+
+func autoConvert_azure_KMS_To_v1alpha1_KMS(in *azure.KMS, out *KMS, s conversion.Scope) error {
+	out.KeyVaultName = in.KeyVaultName
+	out.KeyName = in.KeyName
+	out.KeyVersion = in.KeyVersion
+	out.PreviousKeyVersions = *(*[]string)(unsafe.Pointer(&in.PreviousKeyVersions))
+	return nil
+}
+
+// Convert_azure_KMS_To_v1alpha1_KMS is an autogenerated conversion function.
+func Convert_azure_KMS_To_v1alpha1_KMS(in *azure.KMS, out *KMS, s conversion.Scope) error {
+	return autoConvert_azure_KMS_To_v1alpha1_KMS(in, out, s)
+}
+
+func autoConvert_v1alpha1_KubeAPIServerExposure_To_azure_KubeAPIServerExposure(in *KubeAPIServerExposure, out *azure.KubeAPIServerExposure, s conversion.Scope) error {
+	out.PrivateLinkService = (*azure.PrivateLinkService)(unsafe.Pointer(in.PrivateLinkService))
+	out.TCPIdleTimeoutInMinutes = (*int32)(unsafe.Pointer(in.TCPIdleTimeoutInMinutes))
+	out.DNSLabel = (*string)(unsafe.Pointer(in.DNSLabel))
+	return nil
+}
+
+// Convert_v1alpha1_KubeAPIServerExposure_To_azure_KubeAPIServerExposure is an autogenerated conversion function.
+func Convert_v1alpha1_KubeAPIServerExposure_To_azure_KubeAPIServerExposure(in *KubeAPIServerExposure, out *azure.KubeAPIServerExposure, s conversion.Scope) error {
+	return autoConvert_v1alpha1_KubeAPIServerExposure_To_azure_KubeAPIServerExposure(in, out, s)
+}
+
+func autoConvert_azure_KubeAPIServerExposure_To_v1alpha1_KubeAPIServerExposure(in *azure.KubeAPIServerExposure, out *KubeAPIServerExposure, s conversion.Scope) error {
+	out.PrivateLinkService = (*PrivateLinkService)(unsafe.Pointer(in.PrivateLinkService))
+	out.TCPIdleTimeoutInMinutes = (*int32)(unsafe.Pointer(in.TCPIdleTimeoutInMinutes))
+	out.DNSLabel = (*string)(unsafe.Pointer(in.DNSLabel))
+	return nil
+}
+
+// Convert_azure_KubeAPIServerExposure_To_v1alpha1_KubeAPIServerExposure is an autogenerated conversion function.
+func Convert_azure_KubeAPIServerExposure_To_v1alpha1_KubeAPIServerExposure(in *azure.KubeAPIServerExposure, out *KubeAPIServerExposure, s conversion.Scope) error {
+	return autoConvert_azure_KubeAPIServerExposure_To_v1alpha1_KubeAPIServerExposure(in, out, s)
+}
+
+func autoConvert_v1alpha1_KubeAPIServerExposureStatus_To_azure_KubeAPIServerExposureStatus(in *KubeAPIServerExposureStatus, out *azure.KubeAPIServerExposureStatus, s conversion.Scope) error {
+	out.Internal = in.Internal
+	return nil
+}
+
+// Convert_v1alpha1_KubeAPIServerExposureStatus_To_azure_KubeAPIServerExposureStatus is an autogenerated conversion function.
+func Convert_v1alpha1_KubeAPIServerExposureStatus_To_azure_KubeAPIServerExposureStatus(in *KubeAPIServerExposureStatus, out *azure.KubeAPIServerExposureStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_KubeAPIServerExposureStatus_To_azure_KubeAPIServerExposureStatus(in, out, s)
+}
+
+func autoConvert_azure_KubeAPIServerExposureStatus_To_v1alpha1_KubeAPIServerExposureStatus(in *azure.KubeAPIServerExposureStatus, out *KubeAPIServerExposureStatus, s conversion.Scope) error {
+	out.Internal = in.Internal
+	return nil
+}
+
+// Convert_azure_KubeAPIServerExposureStatus_To_v1alpha1_KubeAPIServerExposureStatus is an autogenerated conversion function.
+func Convert_azure_KubeAPIServerExposureStatus_To_v1alpha1_KubeAPIServerExposureStatus(in *azure.KubeAPIServerExposureStatus, out *KubeAPIServerExposureStatus, s conversion.Scope) error {
+	return autoConvert_azure_KubeAPIServerExposureStatus_To_v1alpha1_KubeAPIServerExposureStatus(in, out, s)
+}
+
+func autoConvert_v1alpha1_LoadBalancerConfig_To_azure_LoadBalancerConfig(in *LoadBalancerConfig, out *azure.LoadBalancerConfig, s conversion.Scope) error {
+	out.OutboundRule = (*azure.OutboundRule)(unsafe.Pointer(in.OutboundRule))
+	out.DisableOutboundSNAT = (*bool)(unsafe.Pointer(in.DisableOutboundSNAT))
+	out.ExcludedWorkerPools = *(*[]string)(unsafe.Pointer(&in.ExcludedWorkerPools))
+	out.PreConfiguredBackendPoolType = (*azure.PreConfiguredBackendPoolType)(unsafe.Pointer(in.PreConfiguredBackendPoolType))
+	return nil
+}
+
+// Convert_v1alpha1_LoadBalancerConfig_To_azure_LoadBalancerConfig is an autogenerated conversion function.
+func Convert_v1alpha1_LoadBalancerConfig_To_azure_LoadBalancerConfig(in *LoadBalancerConfig, out *azure.LoadBalancerConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_LoadBalancerConfig_To_azure_LoadBalancerConfig(in, out, s)
+}
+
+func autoConvert_azure_LoadBalancerConfig_To_v1alpha1_LoadBalancerConfig(in *azure.LoadBalancerConfig, out *LoadBalancerConfig, s conversion.Scope) error {
+	out.OutboundRule = (*OutboundRule)(unsafe.Pointer(in.OutboundRule))
+	out.DisableOutboundSNAT = (*bool)(unsafe.Pointer(in.DisableOutboundSNAT))
+	out.ExcludedWorkerPools = *(*[]string)(unsafe.Pointer(&in.ExcludedWorkerPools))
+	out.PreConfiguredBackendPoolType = (*PreConfiguredBackendPoolType)(unsafe.Pointer(in.PreConfiguredBackendPoolType))
+	return nil
+}
+
+// Convert_azure_LoadBalancerConfig_To_v1alpha1_LoadBalancerConfig is an autogenerated conversion function.
+func Convert_azure_LoadBalancerConfig_To_v1alpha1_LoadBalancerConfig(in *azure.LoadBalancerConfig, out *LoadBalancerConfig, s conversion.Scope) error {
+	return autoConvert_azure_LoadBalancerConfig_To_v1alpha1_LoadBalancerConfig(in, out, s)
+}
+
+func autoConvert_v1alpha1_LoadBalancerMigrationStatus_To_azure_LoadBalancerMigrationStatus(in *LoadBalancerMigrationStatus, out *azure.LoadBalancerMigrationStatus, s conversion.Scope) error {
+	out.State = azure.LoadBalancerMigrationState(in.State)
+	out.LastUpdateTime = in.LastUpdateTime
+	out.IgnoredManagedResources = *(*[]string)(unsafe.Pointer(&in.IgnoredManagedResources))
+	return nil
+}
+
+// Convert_v1alpha1_LoadBalancerMigrationStatus_To_azure_LoadBalancerMigrationStatus is an autogenerated conversion function.
+func Convert_v1alpha1_LoadBalancerMigrationStatus_To_azure_LoadBalancerMigrationStatus(in *LoadBalancerMigrationStatus, out *azure.LoadBalancerMigrationStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_LoadBalancerMigrationStatus_To_azure_LoadBalancerMigrationStatus(in, out, s)
+}
+
+func autoConvert_azure_LoadBalancerMigrationStatus_To_v1alpha1_LoadBalancerMigrationStatus(in *azure.LoadBalancerMigrationStatus, out *LoadBalancerMigrationStatus, s conversion.Scope) error {
+	out.State = LoadBalancerMigrationState(in.State)
+	out.LastUpdateTime = in.LastUpdateTime
+	out.IgnoredManagedResources = *(*[]string)(unsafe.Pointer(&in.IgnoredManagedResources))
+	return nil
+}
+
+// Convert_azure_LoadBalancerMigrationStatus_To_v1alpha1_LoadBalancerMigrationStatus is an autogenerated conversion function.
+func Convert_azure_LoadBalancerMigrationStatus_To_v1alpha1_LoadBalancerMigrationStatus(in *azure.LoadBalancerMigrationStatus, out *LoadBalancerMigrationStatus, s conversion.Scope) error {
+	return autoConvert_azure_LoadBalancerMigrationStatus_To_v1alpha1_LoadBalancerMigrationStatus(in, out, s)
+}
+
 func autoConvert_v1alpha1_MachineImage_To_azure_MachineImage(in *MachineImage, out *azure.MachineImage, s conversion.Scope) error {
 	out.Name = in.Name
 	out.Version = in.Version
 	out.URN = (*string)(unsafe.Pointer(in.URN))
 	out.ID = (*string)(unsafe.Pointer(in.ID))
+	out.Architecture = (*string)(unsafe.Pointer(in.Architecture))
+	out.HyperVGeneration = (*azure.HyperVGeneration)(unsafe.Pointer(in.HyperVGeneration))
 	return nil
 }
 
@@ -504,6 +977,8 @@ func autoConvert_azure_MachineImage_To_v1alpha1_MachineImage(in *azure.MachineIm
 	out.Version = in.Version
 	out.URN = (*string)(unsafe.Pointer(in.URN))
 	out.ID = (*string)(unsafe.Pointer(in.ID))
+	out.Architecture = (*string)(unsafe.Pointer(in.Architecture))
+	out.HyperVGeneration = (*HyperVGeneration)(unsafe.Pointer(in.HyperVGeneration))
 	return nil
 }
 
@@ -516,6 +991,9 @@ func autoConvert_v1alpha1_MachineImageVersion_To_azure_MachineImageVersion(in *M
 	out.Version = in.Version
 	out.URN = (*string)(unsafe.Pointer(in.URN))
 	out.ID = (*string)(unsafe.Pointer(in.ID))
+	out.Regions = *(*[]azure.RegionImageMapping)(unsafe.Pointer(&in.Regions))
+	out.Architecture = (*string)(unsafe.Pointer(in.Architecture))
+	out.HyperVGeneration = (*azure.HyperVGeneration)(unsafe.Pointer(in.HyperVGeneration))
 	return nil
 }
 
@@ -528,6 +1006,9 @@ func autoConvert_azure_MachineImageVersion_To_v1alpha1_MachineImageVersion(in *a
 	out.Version = in.Version
 	out.URN = (*string)(unsafe.Pointer(in.URN))
 	out.ID = (*string)(unsafe.Pointer(in.ID))
+	out.Regions = *(*[]RegionImageMapping)(unsafe.Pointer(&in.Regions))
+	out.Architecture = (*string)(unsafe.Pointer(in.Architecture))
+	out.HyperVGeneration = (*HyperVGeneration)(unsafe.Pointer(in.HyperVGeneration))
 	return nil
 }
 
@@ -558,6 +1039,32 @@ func Convert_azure_MachineImages_To_v1alpha1_MachineImages(in *azure.MachineImag
 	return autoConvert_azure_MachineImages_To_v1alpha1_MachineImages(in, out, s)
 }
 
+func autoConvert_v1alpha1_MachineType_To_azure_MachineType(in *MachineType, out *azure.MachineType, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Architecture = (*string)(unsafe.Pointer(in.Architecture))
+	out.HyperVGenerations = *(*[]azure.HyperVGeneration)(unsafe.Pointer(&in.HyperVGenerations))
+	out.MaxDataDisks = (*int32)(unsafe.Pointer(in.MaxDataDisks))
+	return nil
+}
+
+// Convert_v1alpha1_MachineType_To_azure_MachineType is an autogenerated conversion function.
+func Convert_v1alpha1_MachineType_To_azure_MachineType(in *MachineType, out *azure.MachineType, s conversion.Scope) error {
+	return autoConvert_v1alpha1_MachineType_To_azure_MachineType(in, out, s)
+}
+
+func autoConvert_azure_MachineType_To_v1alpha1_MachineType(in *azure.MachineType, out *MachineType, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Architecture = (*string)(unsafe.Pointer(in.Architecture))
+	out.HyperVGenerations = *(*[]HyperVGeneration)(unsafe.Pointer(&in.HyperVGenerations))
+	out.MaxDataDisks = (*int32)(unsafe.Pointer(in.MaxDataDisks))
+	return nil
+}
+
+// Convert_azure_MachineType_To_v1alpha1_MachineType is an autogenerated conversion function.
+func Convert_azure_MachineType_To_v1alpha1_MachineType(in *azure.MachineType, out *MachineType, s conversion.Scope) error {
+	return autoConvert_azure_MachineType_To_v1alpha1_MachineType(in, out, s)
+}
+
 func autoConvert_v1alpha1_NatGatewayConfig_To_azure_NatGatewayConfig(in *NatGatewayConfig, out *azure.NatGatewayConfig, s conversion.Scope) error {
 	out.Enabled = in.Enabled
 	return nil
@@ -585,6 +1092,7 @@ func autoConvert_v1alpha1_NetworkConfig_To_azure_NetworkConfig(in *NetworkConfig
 	out.Workers = in.Workers
 	out.NatGateway = (*azure.NatGatewayConfig)(unsafe.Pointer(in.NatGateway))
 	out.ServiceEndpoints = *(*[]string)(unsafe.Pointer(&in.ServiceEndpoints))
+	out.PodNetworkMode = (*azure.PodNetworkMode)(unsafe.Pointer(in.PodNetworkMode))
 	return nil
 }
 
@@ -600,6 +1108,7 @@ func autoConvert_azure_NetworkConfig_To_v1alpha1_NetworkConfig(in *azure.Network
 	out.Workers = in.Workers
 	out.NatGateway = (*NatGatewayConfig)(unsafe.Pointer(in.NatGateway))
 	out.ServiceEndpoints = *(*[]string)(unsafe.Pointer(&in.ServiceEndpoints))
+	out.PodNetworkMode = (*PodNetworkMode)(unsafe.Pointer(in.PodNetworkMode))
 	return nil
 }
 
@@ -634,6 +1143,150 @@ func Convert_azure_NetworkStatus_To_v1alpha1_NetworkStatus(in *azure.NetworkStat
 	return autoConvert_azure_NetworkStatus_To_v1alpha1_NetworkStatus(in, out, s)
 }
 
+func autoConvert_v1alpha1_OutboundRule_To_azure_OutboundRule(in *OutboundRule, out *azure.OutboundRule, s conversion.Scope) error {
+	out.AllocatedOutboundPorts = (*int32)(unsafe.Pointer(in.AllocatedOutboundPorts))
+	out.IdleTimeoutInMinutes = (*int32)(unsafe.Pointer(in.IdleTimeoutInMinutes))
+	return nil
+}
+
+// Convert_v1alpha1_OutboundRule_To_azure_OutboundRule is an autogenerated conversion function.
+func Convert_v1alpha1_OutboundRule_To_azure_OutboundRule(in *OutboundRule, out *azure.OutboundRule, s conversion.Scope) error {
+	return autoConvert_v1alpha1_OutboundRule_To_azure_OutboundRule(in, out, s)
+}
+
+func autoConvert_azure_OutboundRule_To_v1alpha1_OutboundRule(in *azure.OutboundRule, out *OutboundRule, s conversion.Scope) error {
+	out.AllocatedOutboundPorts = (*int32)(unsafe.Pointer(in.AllocatedOutboundPorts))
+	out.IdleTimeoutInMinutes = (*int32)(unsafe.Pointer(in.IdleTimeoutInMinutes))
+	return nil
+}
+
+// Convert_azure_OutboundRule_To_v1alpha1_OutboundRule is an autogenerated conversion function.
+func Convert_azure_OutboundRule_To_v1alpha1_OutboundRule(in *azure.OutboundRule, out *OutboundRule, s conversion.Scope) error {
+	return autoConvert_azure_OutboundRule_To_v1alpha1_OutboundRule(in, out, s)
+}
+
+func autoConvert_v1alpha1_PrivateLinkService_To_azure_PrivateLinkService(in *PrivateLinkService, out *azure.PrivateLinkService, s conversion.Scope) error {
+	out.Enabled = (*bool)(unsafe.Pointer(in.Enabled))
+	out.AllowedSubscriptions = *(*[]string)(unsafe.Pointer(&in.AllowedSubscriptions))
+	out.AutoApprovedSubscriptions = *(*[]string)(unsafe.Pointer(&in.AutoApprovedSubscriptions))
+	return nil
+}
+
+// Convert_v1alpha1_PrivateLinkService_To_azure_PrivateLinkService is an autogenerated conversion function.
+func Convert_v1alpha1_PrivateLinkService_To_azure_PrivateLinkService(in *PrivateLinkService, out *azure.PrivateLinkService, s conversion.Scope) error {
+	return autoConvert_v1alpha1_PrivateLinkService_To_azure_PrivateLinkService(in, out, s)
+}
+
+func autoConvert_azure_PrivateLinkService_To_v1alpha1_PrivateLinkService(in *azure.PrivateLinkService, out *PrivateLinkService, s conversion.Scope) error {
+	out.Enabled = (*bool)(unsafe.Pointer(in.Enabled))
+	out.AllowedSubscriptions = *(*[]string)(unsafe.Pointer(&in.AllowedSubscriptions))
+	out.AutoApprovedSubscriptions = *(*[]string)(unsafe.Pointer(&in.AutoApprovedSubscriptions))
+	return nil
+}
+
+// Convert_azure_PrivateLinkService_To_v1alpha1_PrivateLinkService is an autogenerated conversion function.
+func Convert_azure_PrivateLinkService_To_v1alpha1_PrivateLinkService(in *azure.PrivateLinkService, out *PrivateLinkService, s conversion.Scope) error {
+	return autoConvert_azure_PrivateLinkService_To_v1alpha1_PrivateLinkService(in, out, s)
+}
+
+func autoConvert_v1alpha1_PublicIPStatus_To_azure_PublicIPStatus(in *PublicIPStatus, out *azure.PublicIPStatus, s conversion.Scope) error {
+	out.Name = in.Name
+	out.ResourceGroup = in.ResourceGroup
+	out.IPAddress = in.IPAddress
+	out.FQDN = (*string)(unsafe.Pointer(in.FQDN))
+	return nil
+}
+
+// Convert_v1alpha1_PublicIPStatus_To_azure_PublicIPStatus is an autogenerated conversion function.
+func Convert_v1alpha1_PublicIPStatus_To_azure_PublicIPStatus(in *PublicIPStatus, out *azure.PublicIPStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_PublicIPStatus_To_azure_PublicIPStatus(in, out, s)
+}
+
+func autoConvert_azure_PublicIPStatus_To_v1alpha1_PublicIPStatus(in *azure.PublicIPStatus, out *PublicIPStatus, s conversion.Scope) error {
+	out.Name = in.Name
+	out.ResourceGroup = in.ResourceGroup
+	out.IPAddress = in.IPAddress
+	out.FQDN = (*string)(unsafe.Pointer(in.FQDN))
+	return nil
+}
+
+// Convert_azure_PublicIPStatus_To_v1alpha1_PublicIPStatus is an autogenerated conversion function.
+func Convert_azure_PublicIPStatus_To_v1alpha1_PublicIPStatus(in *azure.PublicIPStatus, out *PublicIPStatus, s conversion.Scope) error {
+	return autoConvert_azure_PublicIPStatus_To_v1alpha1_PublicIPStatus(in, out, s)
+}
+
+func autoConvert_v1alpha1_Region_To_azure_Region(in *Region, out *azure.Region, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Zones = *(*[]string)(unsafe.Pointer(&in.Zones))
+	out.CountUpdateDomains = (*int)(unsafe.Pointer(in.CountUpdateDomains))
+	out.CountFaultDomains = (*int)(unsafe.Pointer(in.CountFaultDomains))
+	out.MachineTypes = *(*[]azure.RegionMachineType)(unsafe.Pointer(&in.MachineTypes))
+	return nil
+}
+
+// Convert_v1alpha1_Region_To_azure_Region is an autogenerated conversion function.
+func Convert_v1alpha1_Region_To_azure_Region(in *Region, out *azure.Region, s conversion.Scope) error {
+	return autoConvert_v1alpha1_Region_To_azure_Region(in, out, s)
+}
+
+func autoConvert_azure_Region_To_v1alpha1_Region(in *azure.Region, out *Region, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Zones = *(*[]string)(unsafe.Pointer(&in.Zones))
+	out.CountUpdateDomains = (*int)(unsafe.Pointer(in.CountUpdateDomains))
+	out.CountFaultDomains = (*int)(unsafe.Pointer(in.CountFaultDomains))
+	out.MachineTypes = *(*[]RegionMachineType)(unsafe.Pointer(&in.MachineTypes))
+	return nil
+}
+
+// Convert_azure_Region_To_v1alpha1_Region is an autogenerated conversion function.
+func Convert_azure_Region_To_v1alpha1_Region(in *azure.Region, out *Region, s conversion.Scope) error {
+	return autoConvert_azure_Region_To_v1alpha1_Region(in, out, s)
+}
+
+func autoConvert_v1alpha1_RegionImageMapping_To_azure_RegionImageMapping(in *RegionImageMapping, out *azure.RegionImageMapping, s conversion.Scope) error {
+	out.Name = in.Name
+	out.ID = (*string)(unsafe.Pointer(in.ID))
+	return nil
+}
+
+// Convert_v1alpha1_RegionImageMapping_To_azure_RegionImageMapping is an autogenerated conversion function.
+func Convert_v1alpha1_RegionImageMapping_To_azure_RegionImageMapping(in *RegionImageMapping, out *azure.RegionImageMapping, s conversion.Scope) error {
+	return autoConvert_v1alpha1_RegionImageMapping_To_azure_RegionImageMapping(in, out, s)
+}
+
+func autoConvert_azure_RegionImageMapping_To_v1alpha1_RegionImageMapping(in *azure.RegionImageMapping, out *RegionImageMapping, s conversion.Scope) error {
+	out.Name = in.Name
+	out.ID = (*string)(unsafe.Pointer(in.ID))
+	return nil
+}
+
+// Convert_azure_RegionImageMapping_To_v1alpha1_RegionImageMapping is an autogenerated conversion function.
+func Convert_azure_RegionImageMapping_To_v1alpha1_RegionImageMapping(in *azure.RegionImageMapping, out *RegionImageMapping, s conversion.Scope) error {
+	return autoConvert_azure_RegionImageMapping_To_v1alpha1_RegionImageMapping(in, out, s)
+}
+
+func autoConvert_v1alpha1_RegionMachineType_To_azure_RegionMachineType(in *RegionMachineType, out *azure.RegionMachineType, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Zones = *(*[]string)(unsafe.Pointer(&in.Zones))
+	return nil
+}
+
+// Convert_v1alpha1_RegionMachineType_To_azure_RegionMachineType is an autogenerated conversion function.
+func Convert_v1alpha1_RegionMachineType_To_azure_RegionMachineType(in *RegionMachineType, out *azure.RegionMachineType, s conversion.Scope) error {
+	return autoConvert_v1alpha1_RegionMachineType_To_azure_RegionMachineType(in, out, s)
+}
+
+func autoConvert_azure_RegionMachineType_To_v1alpha1_RegionMachineType(in *azure.RegionMachineType, out *RegionMachineType, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Zones = *(*[]string)(unsafe.Pointer(&in.Zones))
+	return nil
+}
+
+// Convert_azure_RegionMachineType_To_v1alpha1_RegionMachineType is an autogenerated conversion function.
+func Convert_azure_RegionMachineType_To_v1alpha1_RegionMachineType(in *azure.RegionMachineType, out *RegionMachineType, s conversion.Scope) error {
+	return autoConvert_azure_RegionMachineType_To_v1alpha1_RegionMachineType(in, out, s)
+}
+
 func autoConvert_v1alpha1_ResourceGroup_To_azure_ResourceGroup(in *ResourceGroup, out *azure.ResourceGroup, s conversion.Scope) error {
 	out.Name = in.Name
 	return nil
@@ -676,6 +1329,30 @@ func Convert_azure_RouteTable_To_v1alpha1_RouteTable(in *azure.RouteTable, out *
 	return autoConvert_azure_RouteTable_To_v1alpha1_RouteTable(in, out, s)
 }
 
+func autoConvert_v1alpha1_ScheduledEventsHandler_To_azure_ScheduledEventsHandler(in *ScheduledEventsHandler, out *azure.ScheduledEventsHandler, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.EventTypes = *(*[]azure.ScheduledEventType)(unsafe.Pointer(&in.EventTypes))
+	out.DrainTimeout = (*v1.Duration)(unsafe.Pointer(in.DrainTimeout))
+	return nil
+}
+
+// Convert_v1alpha1_ScheduledEventsHandler_To_azure_ScheduledEventsHandler is an autogenerated conversion function.
+func Convert_v1alpha1_ScheduledEventsHandler_To_azure_ScheduledEventsHandler(in *ScheduledEventsHandler, out *azure.ScheduledEventsHandler, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ScheduledEventsHandler_To_azure_ScheduledEventsHandler(in, out, s)
+}
+
+func autoConvert_azure_ScheduledEventsHandler_To_v1alpha1_ScheduledEventsHandler(in *azure.ScheduledEventsHandler, out *ScheduledEventsHandler, s conversion.Scope) error {
+	out.Enabled = in.Enabled
+	out.EventTypes = *(*[]ScheduledEventType)(unsafe.Pointer(&in.EventTypes))
+	out.DrainTimeout = (*v1.Duration)(unsafe.Pointer(in.DrainTimeout))
+	return nil
+}
+
+// Convert_azure_ScheduledEventsHandler_To_v1alpha1_ScheduledEventsHandler is an autogenerated conversion function.
+func Convert_azure_ScheduledEventsHandler_To_v1alpha1_ScheduledEventsHandler(in *azure.ScheduledEventsHandler, out *ScheduledEventsHandler, s conversion.Scope) error {
+	return autoConvert_azure_ScheduledEventsHandler_To_v1alpha1_ScheduledEventsHandler(in, out, s)
+}
+
 func autoConvert_v1alpha1_SecurityGroup_To_azure_SecurityGroup(in *SecurityGroup, out *azure.SecurityGroup, s conversion.Scope) error {
 	out.Purpose = azure.Purpose(in.Purpose)
 	out.Name = in.Name
@@ -698,6 +1375,64 @@ func Convert_azure_SecurityGroup_To_v1alpha1_SecurityGroup(in *azure.SecurityGro
 	return autoConvert_azure_SecurityGroup_To_v1alpha1_SecurityGroup(in, out, s)
 }
 
+func autoConvert_v1alpha1_Storage_To_azure_Storage(in *Storage, out *azure.Storage, s conversion.Scope) error {
+	out.ManagedStorageClasses = (*bool)(unsafe.Pointer(in.ManagedStorageClasses))
+	out.DefaultStorageClass = (*string)(unsafe.Pointer(in.DefaultStorageClass))
+	out.StorageClasses = *(*[]azure.StorageClass)(unsafe.Pointer(&in.StorageClasses))
+	out.VolumeSnapshotClass = (*azure.VolumeSnapshotClass)(unsafe.Pointer(in.VolumeSnapshotClass))
+	return nil
+}
+
+// Convert_v1alpha1_Storage_To_azure_Storage is an autogenerated conversion function.
+func Convert_v1alpha1_Storage_To_azure_Storage(in *Storage, out *azure.Storage, s conversion.Scope) error {
+	return autoConvert_v1alpha1_Storage_To_azure_Storage(in, out, s)
+}
+
+func autoConvert_azure_Storage_To_v1alpha1_Storage(in *azure.Storage, out *Storage, s conversion.Scope) error {
+	out.ManagedStorageClasses = (*bool)(unsafe.Pointer(in.ManagedStorageClasses))
+	out.DefaultStorageClass = (*string)(unsafe.Pointer(in.DefaultStorageClass))
+	out.StorageClasses = *(*[]StorageClass)(unsafe.Pointer(&in.StorageClasses))
+	out.VolumeSnapshotClass = (*VolumeSnapshotClass)(unsafe.Pointer(in.VolumeSnapshotClass))
+	return nil
+}
+
+// Convert_azure_Storage_To_v1alpha1_Storage is an autogenerated conversion function.
+func Convert_azure_Storage_To_v1alpha1_Storage(in *azure.Storage, out *Storage, s conversion.Scope) error {
+	return autoConvert_azure_Storage_To_v1alpha1_Storage(in, out, s)
+}
+
+func autoConvert_v1alpha1_StorageClass_To_azure_StorageClass(in *StorageClass, out *azure.StorageClass, s conversion.Scope) error {
+	out.Name = in.Name
+	out.SkuName = in.SkuName
+	out.CachingMode = (*string)(unsafe.Pointer(in.CachingMode))
+	out.VolumeBindingMode = (*storagev1.VolumeBindingMode)(unsafe.Pointer(in.VolumeBindingMode))
+	out.ReclaimPolicy = (*corev1.PersistentVolumeReclaimPolicy)(unsafe.Pointer(in.ReclaimPolicy))
+	out.DiskEncryptionSetID = (*string)(unsafe.Pointer(in.DiskEncryptionSetID))
+	out.Zones = *(*[]string)(unsafe.Pointer(&in.Zones))
+	return nil
+}
+
+// Convert_v1alpha1_StorageClass_To_azure_StorageClass is an autogenerated conversion function.
+func Convert_v1alpha1_StorageClass_To_azure_StorageClass(in *StorageClass, out *azure.StorageClass, s conversion.Scope) error {
+	return autoConvert_v1alpha1_StorageClass_To_azure_StorageClass(in, out, s)
+}
+
+func autoConvert_azure_StorageClass_To_v1alpha1_StorageClass(in *azure.StorageClass, out *StorageClass, s conversion.Scope) error {
+	out.Name = in.Name
+	out.SkuName = in.SkuName
+	out.CachingMode = (*string)(unsafe.Pointer(in.CachingMode))
+	out.VolumeBindingMode = (*storagev1.VolumeBindingMode)(unsafe.Pointer(in.VolumeBindingMode))
+	out.ReclaimPolicy = (*corev1.PersistentVolumeReclaimPolicy)(unsafe.Pointer(in.ReclaimPolicy))
+	out.DiskEncryptionSetID = (*string)(unsafe.Pointer(in.DiskEncryptionSetID))
+	out.Zones = *(*[]string)(unsafe.Pointer(&in.Zones))
+	return nil
+}
+
+// Convert_azure_StorageClass_To_v1alpha1_StorageClass is an autogenerated conversion function.
+func Convert_azure_StorageClass_To_v1alpha1_StorageClass(in *azure.StorageClass, out *StorageClass, s conversion.Scope) error {
+	return autoConvert_azure_StorageClass_To_v1alpha1_StorageClass(in, out, s)
+}
+
 func autoConvert_v1alpha1_Subnet_To_azure_Subnet(in *Subnet, out *azure.Subnet, s conversion.Scope) error {
 	out.Name = in.Name
 	out.Purpose = azure.Purpose(in.Purpose)
@@ -766,6 +1501,28 @@ func Convert_azure_VNetStatus_To_v1alpha1_VNetStatus(in *azure.VNetStatus, out *
 	return autoConvert_azure_VNetStatus_To_v1alpha1_VNetStatus(in, out, s)
 }
 
+func autoConvert_v1alpha1_VolumeSnapshotClass_To_azure_VolumeSnapshotClass(in *VolumeSnapshotClass, out *azure.VolumeSnapshotClass, s conversion.Scope) error {
+	out.Incremental = (*bool)(unsafe.Pointer(in.Incremental))
+	out.ResourceGroup = (*string)(unsafe.Pointer(in.ResourceGroup))
+	return nil
+}
+
+// Convert_v1alpha1_VolumeSnapshotClass_To_azure_VolumeSnapshotClass is an autogenerated conversion function.
+func Convert_v1alpha1_VolumeSnapshotClass_To_azure_VolumeSnapshotClass(in *VolumeSnapshotClass, out *azure.VolumeSnapshotClass, s conversion.Scope) error {
+	return autoConvert_v1alpha1_VolumeSnapshotClass_To_azure_VolumeSnapshotClass(in, out, s)
+}
+
+func autoConvert_azure_VolumeSnapshotClass_To_v1alpha1_VolumeSnapshotClass(in *azure.VolumeSnapshotClass, out *VolumeSnapshotClass, s conversion.Scope) error {
+	out.Incremental = (*bool)(unsafe.Pointer(in.Incremental))
+	out.ResourceGroup = (*string)(unsafe.Pointer(in.ResourceGroup))
+	return nil
+}
+
+// Convert_azure_VolumeSnapshotClass_To_v1alpha1_VolumeSnapshotClass is an autogenerated conversion function.
+func Convert_azure_VolumeSnapshotClass_To_v1alpha1_VolumeSnapshotClass(in *azure.VolumeSnapshotClass, out *VolumeSnapshotClass, s conversion.Scope) error {
+	return autoConvert_azure_VolumeSnapshotClass_To_v1alpha1_VolumeSnapshotClass(in, out, s)
+}
+
 func autoConvert_v1alpha1_WorkerStatus_To_azure_WorkerStatus(in *WorkerStatus, out *azure.WorkerStatus, s conversion.Scope) error {
 	out.MachineImages = *(*[]azure.MachineImage)(unsafe.Pointer(&in.MachineImages))
 	return nil
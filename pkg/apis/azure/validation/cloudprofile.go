@@ -19,10 +19,17 @@ import (
 	"strings"
 
 	apisazure "github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure"
+	"github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure/helper"
 
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
+var (
+	supportedArchitectures     = sets.NewString(apisazure.ArchitectureAMD64, apisazure.ArchitectureARM64)
+	supportedHyperVGenerations = sets.NewString(string(apisazure.HyperVGenerationV1), string(apisazure.HyperVGenerationV2))
+)
+
 // ValidateCloudProfileConfig validates a CloudProfileConfig object.
 func ValidateCloudProfileConfig(cloudProfile *apisazure.CloudProfileConfig) field.ErrorList {
 	allErrs := field.ErrorList{}
@@ -44,13 +51,31 @@ func ValidateCloudProfileConfig(cloudProfile *apisazure.CloudProfileConfig) fiel
 		if len(machineImage.Versions) == 0 {
 			allErrs = append(allErrs, field.Required(idxPath.Child("versions"), fmt.Sprintf("must provide at least one version for machine image %q", machineImage.Name)))
 		}
+		// A version can be listed multiple times, but only once per architecture and Hyper-V generation.
+		variants := sets.NewString()
 		for j, version := range machineImage.Versions {
 			jdxPath := idxPath.Child("versions").Index(j)
 
 			if len(version.Version) == 0 {
 				allErrs = append(allErrs, field.Required(jdxPath.Child("version"), "must provide a version"))
 			}
-			if (version.URN == nil && version.ID == nil) || (version.URN != nil && version.ID != nil) {
+			if version.Architecture != nil && !supportedArchitectures.Has(*version.Architecture) {
+				allErrs = append(allErrs, field.NotSupported(jdxPath.Child("architecture"), *version.Architecture, supportedArchitectures.List()))
+			}
+			generation := apisazure.HyperVGenerationV1
+			if version.HyperVGeneration != nil {
+				generation = *version.HyperVGeneration
+				if !supportedHyperVGenerations.Has(string(generation)) {
+					allErrs = append(allErrs, field.NotSupported(jdxPath.Child("hyperVGeneration"), generation, supportedHyperVGenerations.List()))
+				}
+			}
+			variant := fmt.Sprintf("%s/%s/%s", version.Version, helper.ArchitectureOrDefault(version.Architecture), generation)
+			if variants.Has(variant) {
+				allErrs = append(allErrs, field.Duplicate(jdxPath, variant))
+			}
+			variants.Insert(variant)
+			// An image reference of the version is not required if it is only offered in the regions which are listed.
+			if references := countImageReferences(version.URN, version.ID); references > 1 || (references == 0 && len(version.Regions) == 0) {
 				allErrs = append(allErrs, field.Required(jdxPath, "must provide either urn or id"))
 			}
 			if version.URN != nil {
@@ -63,6 +88,123 @@ func ValidateCloudProfileConfig(cloudProfile *apisazure.CloudProfileConfig) fiel
 			if version.ID != nil && len(*version.ID) == 0 {
 				allErrs = append(allErrs, field.Required(jdxPath.Child("id"), "id cannot be empty when defined"))
 			}
+
+			regions := sets.NewString()
+			for k, mapping := range version.Regions {
+				kdxPath := jdxPath.Child("regions").Index(k)
+
+				if len(mapping.Name) == 0 {
+					allErrs = append(allErrs, field.Required(kdxPath.Child("name"), "must provide a region name"))
+				} else if regions.Has(mapping.Name) {
+					allErrs = append(allErrs, field.Duplicate(kdxPath.Child("name"), mapping.Name))
+				}
+				regions.Insert(mapping.Name)
+
+				if mapping.ID == nil || len(*mapping.ID) == 0 {
+					allErrs = append(allErrs, field.Required(kdxPath.Child("id"), "must provide an id"))
+				}
+			}
+		}
+	}
+
+	allErrs = append(allErrs, validateMachineTypes(cloudProfile.MachineTypes, field.NewPath("machineTypes"))...)
+	allErrs = append(allErrs, validateRegions(cloudProfile.Regions, field.NewPath("regions"))...)
+
+	return allErrs
+}
+
+func countImageReferences(references ...*string) int {
+	count := 0
+	for _, reference := range references {
+		if reference != nil {
+			count++
+		}
+	}
+	return count
+}
+
+func validateMachineTypes(machineTypes []apisazure.MachineType, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	names := sets.NewString()
+	for i, machineType := range machineTypes {
+		idxPath := fldPath.Index(i)
+
+		if len(machineType.Name) == 0 {
+			allErrs = append(allErrs, field.Required(idxPath.Child("name"), "must provide a name"))
+		} else if names.Has(machineType.Name) {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), machineType.Name))
+		}
+		names.Insert(machineType.Name)
+
+		if machineType.Architecture != nil && !supportedArchitectures.Has(*machineType.Architecture) {
+			allErrs = append(allErrs, field.NotSupported(idxPath.Child("architecture"), *machineType.Architecture, supportedArchitectures.List()))
+		}
+
+		generations := sets.NewString()
+		for j, generation := range machineType.HyperVGenerations {
+			if !supportedHyperVGenerations.Has(string(generation)) {
+				allErrs = append(allErrs, field.NotSupported(idxPath.Child("hyperVGenerations").Index(j), generation, supportedHyperVGenerations.List()))
+			} else if generations.Has(string(generation)) {
+				allErrs = append(allErrs, field.Duplicate(idxPath.Child("hyperVGenerations").Index(j), generation))
+			}
+			generations.Insert(string(generation))
+		}
+		if machineType.MaxDataDisks != nil && *machineType.MaxDataDisks < 0 {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("maxDataDisks"), *machineType.MaxDataDisks, "must not be negative"))
+		}
+	}
+
+	return allErrs
+}
+
+func validateRegions(regions []apisazure.Region, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	names := sets.NewString()
+	for i, region := range regions {
+		idxPath := fldPath.Index(i)
+
+		if len(region.Name) == 0 {
+			allErrs = append(allErrs, field.Required(idxPath.Child("name"), "must provide a name"))
+		} else if names.Has(region.Name) {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), region.Name))
+		}
+		names.Insert(region.Name)
+
+		zones := sets.NewString()
+		for j, zone := range region.Zones {
+			if len(zone) == 0 {
+				allErrs = append(allErrs, field.Required(idxPath.Child("zones").Index(j), "must provide a zone"))
+			} else if zones.Has(zone) {
+				allErrs = append(allErrs, field.Duplicate(idxPath.Child("zones").Index(j), zone))
+			}
+			zones.Insert(zone)
+		}
+
+		if region.CountUpdateDomains != nil && *region.CountUpdateDomains < 0 {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("countUpdateDomains"), *region.CountUpdateDomains, "count must not be negative"))
+		}
+		if region.CountFaultDomains != nil && *region.CountFaultDomains < 0 {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("countFaultDomains"), *region.CountFaultDomains, "count must not be negative"))
+		}
+
+		machineTypes := sets.NewString()
+		for j, machineType := range region.MachineTypes {
+			jdxPath := idxPath.Child("machineTypes").Index(j)
+
+			if len(machineType.Name) == 0 {
+				allErrs = append(allErrs, field.Required(jdxPath.Child("name"), "must provide a name"))
+			} else if machineTypes.Has(machineType.Name) {
+				allErrs = append(allErrs, field.Duplicate(jdxPath.Child("name"), machineType.Name))
+			}
+			machineTypes.Insert(machineType.Name)
+
+			for k, zone := range machineType.Zones {
+				if !zones.Has(zone) {
+					allErrs = append(allErrs, field.NotSupported(jdxPath.Child("zones").Index(k), zone, zones.List()))
+				}
+			}
 		}
 	}
 
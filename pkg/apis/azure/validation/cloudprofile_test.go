@@ -194,6 +194,234 @@ var _ = Describe("CloudProfileConfig validation", func() {
 					"Field": Equal("machineImages[0].versions[0]"),
 				}))))
 			})
+
+			It("should accept the same version for different architectures and Hyper-V generations", func() {
+				var (
+					architecture = "arm64"
+					generation   = apisazure.HyperVGenerationV2
+				)
+				cloudProfileConfig.MachineImages[0].Versions = append(cloudProfileConfig.MachineImages[0].Versions,
+					apisazure.MachineImageVersion{Version: "Version", URN: &urn, HyperVGeneration: &generation},
+					apisazure.MachineImageVersion{Version: "Version", URN: &urn, HyperVGeneration: &generation, Architecture: &architecture},
+				)
+
+				Expect(ValidateCloudProfileConfig(cloudProfileConfig)).To(BeEmpty())
+			})
+
+			It("should forbid unsupported and duplicate machine image version variants", func() {
+				var (
+					architecture = "ppc64le"
+					generation   = apisazure.HyperVGeneration("V3")
+					amd64        = "amd64"
+				)
+				cloudProfileConfig.MachineImages[0].Versions = append(cloudProfileConfig.MachineImages[0].Versions,
+					apisazure.MachineImageVersion{Version: "Other", URN: &urn, HyperVGeneration: &generation, Architecture: &architecture},
+					apisazure.MachineImageVersion{Version: "Version", URN: &urn, Architecture: &amd64},
+				)
+
+				errorList := ValidateCloudProfileConfig(cloudProfileConfig)
+
+				Expect(errorList).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeNotSupported),
+					"Field": Equal("machineImages[0].versions[1].architecture"),
+				})), PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeNotSupported),
+					"Field": Equal("machineImages[0].versions[1].hyperVGeneration"),
+				})), PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeDuplicate),
+					"Field": Equal("machineImages[0].versions[2]"),
+				}))))
+			})
+		})
+
+		Context("region image validation", func() {
+			DescribeTable("forbid invalid region image references",
+				func(version apisazure.MachineImageVersion, matcher gomegatypes.GomegaMatcher) {
+					version.Version = "1.2.3"
+					cloudProfileConfig.MachineImages = []apisazure.MachineImages{
+						{
+							Name:     "my-image",
+							Versions: []apisazure.MachineImageVersion{version},
+						},
+					}
+
+					errorList := ValidateCloudProfileConfig(cloudProfileConfig)
+
+					Expect(errorList).To(matcher)
+				},
+				Entry("region mappings only", apisazure.MachineImageVersion{Regions: []apisazure.RegionImageMapping{
+					{Name: "westeurope", ID: &id},
+					{Name: "northeurope", ID: &id},
+				}}, BeEmpty()),
+				Entry("urn and id", apisazure.MachineImageVersion{URN: &urn, ID: &id}, ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("machineImages[0].versions[0]")})))),
+				Entry("region mapping without name and id", apisazure.MachineImageVersion{URN: &urn, Regions: []apisazure.RegionImageMapping{{}}}, ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("machineImages[0].versions[0].regions[0].name"),
+				})), PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("machineImages[0].versions[0].regions[0].id"),
+				})))),
+				Entry("duplicate region mappings", apisazure.MachineImageVersion{Regions: []apisazure.RegionImageMapping{
+					{Name: "westeurope", ID: &id},
+					{Name: "westeurope", ID: &id},
+				}}, ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeDuplicate),
+					"Field": Equal("machineImages[0].versions[0].regions[1].name"),
+				})))),
+			)
+		})
+
+		Context("machine type validation", func() {
+			It("should forbid machine types without name and duplicate machine types", func() {
+				cloudProfileConfig.MachineTypes = []apisazure.MachineType{{}, {Name: "Standard_D2_v3"}, {Name: "Standard_D2_v3"}}
+
+				errorList := ValidateCloudProfileConfig(cloudProfileConfig)
+
+				Expect(errorList).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("machineTypes[0].name"),
+				})), PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeDuplicate),
+					"Field": Equal("machineTypes[2].name"),
+				}))))
+			})
+
+			It("should accept machine types with a supported architecture", func() {
+				architecture := "arm64"
+				cloudProfileConfig.MachineTypes = []apisazure.MachineType{
+					{Name: "Standard_D4ps_v5", Architecture: &architecture},
+				}
+
+				Expect(ValidateCloudProfileConfig(cloudProfileConfig)).To(BeEmpty())
+			})
+
+			It("should forbid machine types with an unsupported architecture", func() {
+				architecture := "ppc64le"
+				cloudProfileConfig.MachineTypes = []apisazure.MachineType{
+					{Name: "foo", Architecture: &architecture},
+				}
+
+				errorList := ValidateCloudProfileConfig(cloudProfileConfig)
+
+				Expect(errorList).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeNotSupported),
+					"Field": Equal("machineTypes[0].architecture"),
+				}))))
+			})
+
+			It("should accept machine types with Hyper-V generations and data disk limits", func() {
+				maxDataDisks := int32(8)
+				cloudProfileConfig.MachineTypes = []apisazure.MachineType{
+					{Name: "Standard_D4s_v5", HyperVGenerations: []apisazure.HyperVGeneration{apisazure.HyperVGenerationV1, apisazure.HyperVGenerationV2}, MaxDataDisks: &maxDataDisks},
+				}
+
+				Expect(ValidateCloudProfileConfig(cloudProfileConfig)).To(BeEmpty())
+			})
+
+			It("should forbid unsupported and duplicate Hyper-V generations and negative data disk limits", func() {
+				maxDataDisks := int32(-1)
+				cloudProfileConfig.MachineTypes = []apisazure.MachineType{
+					{Name: "foo", HyperVGenerations: []apisazure.HyperVGeneration{apisazure.HyperVGenerationV2, "V3", apisazure.HyperVGenerationV2}, MaxDataDisks: &maxDataDisks},
+				}
+
+				errorList := ValidateCloudProfileConfig(cloudProfileConfig)
+
+				Expect(errorList).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeNotSupported),
+					"Field": Equal("machineTypes[0].hyperVGenerations[1]"),
+				})), PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeDuplicate),
+					"Field": Equal("machineTypes[0].hyperVGenerations[2]"),
+				})), PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("machineTypes[0].maxDataDisks"),
+				}))))
+			})
+
+			It("should forbid duplicate machine types", func() {
+				cloudProfileConfig.MachineTypes = []apisazure.MachineType{{Name: "foo"}, {Name: "foo"}}
+
+				errorList := ValidateCloudProfileConfig(cloudProfileConfig)
+
+				Expect(errorList).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeDuplicate),
+					"Field": Equal("machineTypes[1].name"),
+				}))))
+			})
+		})
+
+		Context("region validation", func() {
+			It("should allow valid regions", func() {
+				countFaultDomains := 2
+				cloudProfileConfig.Regions = []apisazure.Region{
+					{
+						Name:              "westeurope",
+						Zones:             []string{"1", "2", "3"},
+						CountFaultDomains: &countFaultDomains,
+						MachineTypes:      []apisazure.RegionMachineType{{Name: "Standard_D2s_v3", Zones: []string{"1", "2"}}, {Name: "Standard_D4s_v3"}},
+					},
+					{
+						Name: "germanynorth",
+					},
+				}
+
+				Expect(ValidateCloudProfileConfig(cloudProfileConfig)).To(BeEmpty())
+			})
+
+			It("should forbid invalid regions", func() {
+				countUpdateDomains := -1
+				cloudProfileConfig.Regions = []apisazure.Region{
+					{
+						Name:               "westeurope",
+						Zones:              []string{"1", "1", ""},
+						CountUpdateDomains: &countUpdateDomains,
+						MachineTypes:       []apisazure.RegionMachineType{{Name: "Standard_D2s_v3", Zones: []string{"4"}}, {Name: "Standard_D2s_v3"}, {}},
+					},
+					{
+						Name: "westeurope",
+					},
+					{},
+				}
+
+				errorList := ValidateCloudProfileConfig(cloudProfileConfig)
+
+				Expect(errorList).To(ConsistOf(
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  Equal(field.ErrorTypeDuplicate),
+						"Field": Equal("regions[0].zones[1]"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  Equal(field.ErrorTypeRequired),
+						"Field": Equal("regions[0].zones[2]"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  Equal(field.ErrorTypeInvalid),
+						"Field": Equal("regions[0].countUpdateDomains"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  Equal(field.ErrorTypeNotSupported),
+						"Field": Equal("regions[0].machineTypes[0].zones[0]"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  Equal(field.ErrorTypeDuplicate),
+						"Field": Equal("regions[0].machineTypes[1].name"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  Equal(field.ErrorTypeRequired),
+						"Field": Equal("regions[0].machineTypes[2].name"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  Equal(field.ErrorTypeDuplicate),
+						"Field": Equal("regions[1].name"),
+					})),
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  Equal(field.ErrorTypeRequired),
+						"Field": Equal("regions[2].name"),
+					})),
+				))
+			})
 		})
 
 		Context("fault domain count validation", func() {
@@ -0,0 +1,522 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"fmt"
+	"regexp"
+
+	apisazure "github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure"
+	"github.com/gardener/gardener-extension-provider-azure/pkg/azure"
+
+	"github.com/gardener/gardener/pkg/apis/core"
+	versionutils "github.com/gardener/gardener/pkg/utils/version"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	// maxBackoffRetries is the maximum number of retries of a failed request to the Azure API.
+	maxBackoffRetries = 20
+	// maxBackoffExponent is the maximum factor by which the duration between two retries is multiplied.
+	maxBackoffExponent = 10
+	// maxBackoffDurationSeconds is the maximum initial duration between two retries in seconds.
+	maxBackoffDurationSeconds = 300
+
+	// maxAllocatedOutboundPorts is the maximum number of outbound ports which can be allocated per node.
+	maxAllocatedOutboundPorts = 64000
+	// minOutboundIdleTimeoutInMinutes and maxOutboundIdleTimeoutInMinutes are the bounds of the idle timeout of an
+	// outbound rule.
+	minOutboundIdleTimeoutInMinutes = 4
+	maxOutboundIdleTimeoutInMinutes = 120
+	// minInboundIdleTimeoutInMinutes and maxInboundIdleTimeoutInMinutes are the bounds of the TCP idle timeout of the
+	// load balancer of the kube-apiserver.
+	minInboundIdleTimeoutInMinutes = 4
+	maxInboundIdleTimeoutInMinutes = 30
+
+	// serviceNodeExclusionFeatureGate is the feature gate of the cloud-controller-manager which makes it respect the
+	// label that excludes nodes from the load balancers.
+	serviceNodeExclusionFeatureGate = "ServiceNodeExclusion"
+	// serviceNodeExclusionLabelKubernetesVersion is the first Kubernetes version which knows the label that excludes
+	// nodes from the load balancers.
+	serviceNodeExclusionLabelKubernetesVersion = "1.16"
+	// serviceNodeExclusionDefaultKubernetesVersion is the first Kubernetes version which enables the
+	// ServiceNodeExclusion feature gate by default.
+	serviceNodeExclusionDefaultKubernetesVersion = "1.19"
+)
+
+var supportedPreConfiguredBackendPoolTypes = sets.NewString(
+	string(apisazure.PreConfiguredBackendPoolTypeInternal),
+	string(apisazure.PreConfiguredBackendPoolTypeExternal),
+	string(apisazure.PreConfiguredBackendPoolTypeAll),
+)
+
+var (
+	keyVaultNameRegex = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9-]{1,22}[a-zA-Z0-9]$`)
+	keyNameRegex      = regexp.MustCompile(`^[a-zA-Z0-9-]{1,127}$`)
+	keyVersionRegex   = regexp.MustCompile(`^[a-fA-F0-9]{32}$`)
+	dnsLabelRegex     = regexp.MustCompile(`^[a-z][a-z0-9-]{1,61}[a-z0-9]$`)
+
+	supportedSkuNames = sets.NewString(
+		"Standard_LRS",
+		"StandardSSD_LRS",
+		"Premium_LRS",
+		"UltraSSD_LRS",
+		"StandardSSD_ZRS",
+		"Premium_ZRS",
+	)
+	// zoneRedundantSkuNames are the SKUs of zone redundant disks which can only be provisioned by the CSI driver.
+	zoneRedundantSkuNames = sets.NewString(
+		"StandardSSD_ZRS",
+		"Premium_ZRS",
+	)
+	supportedCachingModes = sets.NewString(
+		"None",
+		"ReadOnly",
+		"ReadWrite",
+	)
+	supportedVolumeBindingModes = sets.NewString(
+		string(storagev1.VolumeBindingImmediate),
+		string(storagev1.VolumeBindingWaitForFirstConsumer),
+	)
+	supportedReclaimPolicies = sets.NewString(
+		string(corev1.PersistentVolumeReclaimDelete),
+		string(corev1.PersistentVolumeReclaimRetain),
+	)
+	// managedStorageClassNames are the names of the storage classes provided by Gardener.
+	managedStorageClassNames = sets.NewString(
+		"default",
+		"managed-standard-hdd",
+		"managed-standard-ssd",
+		"managed-premium-ssd",
+		"files",
+	)
+	supportedScheduledEventTypes = sets.NewString(
+		string(apisazure.ScheduledEventTypeFreeze),
+		string(apisazure.ScheduledEventTypeReboot),
+		string(apisazure.ScheduledEventTypeRedeploy),
+		string(apisazure.ScheduledEventTypePreempt),
+		string(apisazure.ScheduledEventTypeTerminate),
+	)
+)
+
+// ValidateControlPlaneConfig validates a ControlPlaneConfig object against the infrastructure configuration, the
+// Kubernetes version and the worker pools of the Shoot.
+func ValidateControlPlaneConfig(controlPlaneConfig *apisazure.ControlPlaneConfig, infra *apisazure.InfrastructureConfig, kubernetesVersion string, workers []core.Worker, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if controlPlaneConfig.CloudControllerManager != nil {
+		allErrs = append(allErrs, validateCloudControllerManagerConfig(controlPlaneConfig.CloudControllerManager, fldPath.Child("cloudControllerManager"))...)
+	}
+
+	if controlPlaneConfig.LoadBalancer != nil {
+		var featureGates map[string]bool
+		if controlPlaneConfig.CloudControllerManager != nil {
+			featureGates = controlPlaneConfig.CloudControllerManager.FeatureGates
+		}
+		allErrs = append(allErrs, validateLoadBalancerConfig(controlPlaneConfig.LoadBalancer, infra, kubernetesVersion, featureGates, workers, fldPath.Child("loadBalancer"))...)
+	}
+
+	if controlPlaneConfig.Storage != nil {
+		allErrs = append(allErrs, validateStorage(controlPlaneConfig.Storage, infra, kubernetesVersion, fldPath.Child("storage"))...)
+	}
+
+	if controlPlaneConfig.KMS != nil {
+		allErrs = append(allErrs, validateKMS(controlPlaneConfig.KMS, fldPath.Child("kms"))...)
+	}
+
+	if controlPlaneConfig.KubeAPIServerExposure != nil {
+		allErrs = append(allErrs, validateKubeAPIServerExposure(controlPlaneConfig.KubeAPIServerExposure, fldPath.Child("kubeAPIServerExposure"))...)
+	}
+
+	if controlPlaneConfig.ScheduledEventsHandler != nil {
+		allErrs = append(allErrs, validateScheduledEventsHandler(controlPlaneConfig.ScheduledEventsHandler, fldPath.Child("scheduledEventsHandler"))...)
+	}
+
+	return allErrs
+}
+
+// ValidateControlPlaneConfigUpdate validates an update of a ControlPlaneConfig object. The encryption of the secrets
+// with a key of an Azure Key Vault cannot be disabled and the key cannot be replaced, because the secrets which are
+// encrypted with it could not be decrypted anymore.
+// The outbound rule can neither be added to nor removed from an existing cluster, because its load balancer is the
+// standard load balancer of the cloud-controller-manager which must be created before the first service of type
+// LoadBalancer and cannot be deleted while it is in use.
+func ValidateControlPlaneConfigUpdate(oldConfig, newConfig *apisazure.ControlPlaneConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if hasOutboundRule(oldConfig) != hasOutboundRule(newConfig) {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("loadBalancer", "outboundRule"), "an outbound rule can only be configured when the cluster is created"))
+	}
+
+	if oldConfig.KMS == nil {
+		return allErrs
+	}
+
+	kmsPath := fldPath.Child("kms")
+	if newConfig.KMS == nil {
+		return append(allErrs, field.Forbidden(kmsPath, "the encryption with a key of an Azure Key Vault cannot be disabled"))
+	}
+
+	allErrs = append(allErrs, apivalidation.ValidateImmutableField(newConfig.KMS.KeyVaultName, oldConfig.KMS.KeyVaultName, kmsPath.Child("keyVaultName"))...)
+	allErrs = append(allErrs, apivalidation.ValidateImmutableField(newConfig.KMS.KeyName, oldConfig.KMS.KeyName, kmsPath.Child("keyName"))...)
+
+	// The former key version is still needed to decrypt the secrets which have not been re-encrypted yet.
+	if newConfig.KMS.KeyVersion != oldConfig.KMS.KeyVersion && !sets.NewString(newConfig.KMS.PreviousKeyVersions...).Has(oldConfig.KMS.KeyVersion) {
+		allErrs = append(allErrs, field.Forbidden(kmsPath.Child("previousKeyVersions"), fmt.Sprintf("must contain the former key version %q when the key is rotated", oldConfig.KMS.KeyVersion)))
+	}
+
+	return allErrs
+}
+
+func hasOutboundRule(config *apisazure.ControlPlaneConfig) bool {
+	return config.LoadBalancer != nil && config.LoadBalancer.OutboundRule != nil
+}
+
+// validateCloudControllerManagerConfig validates the rate limit and backoff settings.
+func validateCloudControllerManagerConfig(config *apisazure.CloudControllerManagerConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if rateLimit := config.RateLimit; rateLimit != nil {
+		allErrs = append(allErrs, ValidateCloudProviderRateLimit(rateLimit.QPS, rateLimit.Bucket, rateLimit.QPSWrite, rateLimit.BucketWrite, fldPath.Child("rateLimit"))...)
+	}
+
+	if backoff := config.Backoff; backoff != nil {
+		allErrs = append(allErrs, ValidateCloudProviderBackoff(backoff.Retries, backoff.Exponent, backoff.DurationSeconds, backoff.Jitter, fldPath.Child("backoff"))...)
+	}
+
+	return allErrs
+}
+
+// ValidateCloudProviderRateLimit validates the client side rate limiting settings of the cloud provider. The cloud
+// provider replaces zero values with its own defaults, hence all values must be positive.
+func ValidateCloudProviderRateLimit(qps *float64, bucket *int32, qpsWrite *float64, bucketWrite *int32, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	allErrs = append(allErrs, validatePositiveQPS(qps, fldPath.Child("qps"))...)
+	allErrs = append(allErrs, validatePositiveBucket(bucket, fldPath.Child("bucket"))...)
+	allErrs = append(allErrs, validatePositiveQPS(qpsWrite, fldPath.Child("qpsWrite"))...)
+	allErrs = append(allErrs, validatePositiveBucket(bucketWrite, fldPath.Child("bucketWrite"))...)
+
+	return allErrs
+}
+
+// ValidateCloudProviderBackoff validates the backoff settings of the cloud provider. The cloud provider replaces zero
+// values with its own defaults, hence all values must be positive.
+func ValidateCloudProviderBackoff(retries *int32, exponent *float64, durationSeconds *int32, jitter *float64, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if retries != nil && (*retries < 1 || *retries > maxBackoffRetries) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("retries"), *retries, fmt.Sprintf("must be between 1 and %d", maxBackoffRetries)))
+	}
+	if exponent != nil && (*exponent < 1 || *exponent > maxBackoffExponent) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("exponent"), *exponent, fmt.Sprintf("must be between 1 and %d", maxBackoffExponent)))
+	}
+	if durationSeconds != nil && (*durationSeconds < 1 || *durationSeconds > maxBackoffDurationSeconds) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("durationSeconds"), *durationSeconds, fmt.Sprintf("must be between 1 and %d", maxBackoffDurationSeconds)))
+	}
+	if jitter != nil && (*jitter <= 0 || *jitter > 1) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("jitter"), *jitter, "must be greater than 0 and at most 1"))
+	}
+
+	return allErrs
+}
+
+func validatePositiveQPS(qps *float64, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if qps != nil && *qps <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath, *qps, "must be greater than 0"))
+	}
+	return allErrs
+}
+
+func validatePositiveBucket(bucket *int32, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if bucket != nil && *bucket < 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath, *bucket, "must be at least 1"))
+	}
+	return allErrs
+}
+
+func validateLoadBalancerConfig(config *apisazure.LoadBalancerConfig, infra *apisazure.InfrastructureConfig, kubernetesVersion string, featureGates map[string]bool, workers []core.Worker, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	// Outbound rules and the other settings of the standard load balancer are not available for the basic load balancer
+	// which is used by non zoned clusters.
+	if !infra.Zoned {
+		if config.OutboundRule != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("outboundRule"), "outbound rules are only supported for zoned clusters"))
+		}
+		if config.DisableOutboundSNAT != nil && *config.DisableOutboundSNAT {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("disableOutboundSNAT"), "disabling the outbound source network address translation is only supported for zoned clusters"))
+		}
+		if config.PreConfiguredBackendPoolType != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("preConfiguredBackendPoolType"), "preconfigured backend pools are only supported for zoned clusters"))
+		}
+	}
+
+	if config.OutboundRule != nil {
+		if infra.Networks.NatGateway != nil && infra.Networks.NatGateway.Enabled {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("outboundRule"), "an outbound rule cannot be used in combination with a NatGateway"))
+		}
+		allErrs = append(allErrs, validateOutboundRule(config.OutboundRule, fldPath.Child("outboundRule"))...)
+	}
+
+	if config.PreConfiguredBackendPoolType != nil && !supportedPreConfiguredBackendPoolTypes.Has(string(*config.PreConfiguredBackendPoolType)) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("preConfiguredBackendPoolType"), *config.PreConfiguredBackendPoolType, supportedPreConfiguredBackendPoolTypes.List()))
+	}
+
+	workerPoolNames := sets.NewString()
+	for _, worker := range workers {
+		workerPoolNames.Insert(worker.Name)
+	}
+	excludedWorkerPools := sets.NewString()
+	for i, name := range config.ExcludedWorkerPools {
+		idxPath := fldPath.Child("excludedWorkerPools").Index(i)
+		if !workerPoolNames.Has(name) {
+			allErrs = append(allErrs, field.NotFound(idxPath, name))
+		}
+		if excludedWorkerPools.Has(name) {
+			allErrs = append(allErrs, field.Duplicate(idxPath, name))
+		}
+		excludedWorkerPools.Insert(name)
+	}
+
+	if len(config.ExcludedWorkerPools) > 0 {
+		allErrs = append(allErrs, validateServiceNodeExclusion(kubernetesVersion, featureGates, fldPath.Child("excludedWorkerPools"))...)
+	}
+
+	return allErrs
+}
+
+// validateServiceNodeExclusion validates that the cloud-controller-manager respects the label which excludes nodes
+// from the load balancers. The label is only known as of Kubernetes 1.16 and the ServiceNodeExclusion feature gate is
+// only enabled by default as of Kubernetes 1.19.
+func validateServiceNodeExclusion(kubernetesVersion string, featureGates map[string]bool, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	labelSupported, err := versionutils.CompareVersions(kubernetesVersion, ">=", serviceNodeExclusionLabelKubernetesVersion)
+	if err != nil {
+		return append(allErrs, field.InternalError(fldPath, err))
+	}
+	if !labelSupported {
+		return append(allErrs, field.Forbidden(fldPath, fmt.Sprintf("excluding worker pools from the load balancers is only supported as of Kubernetes %s", serviceNodeExclusionLabelKubernetesVersion)))
+	}
+
+	gateEnabledByDefault, err := versionutils.CompareVersions(kubernetesVersion, ">=", serviceNodeExclusionDefaultKubernetesVersion)
+	if err != nil {
+		return append(allErrs, field.InternalError(fldPath, err))
+	}
+	if !gateEnabledByDefault && !featureGates[serviceNodeExclusionFeatureGate] {
+		allErrs = append(allErrs, field.Forbidden(fldPath, fmt.Sprintf("excluding worker pools from the load balancers requires the %s feature gate of the cloudControllerManager below Kubernetes %s", serviceNodeExclusionFeatureGate, serviceNodeExclusionDefaultKubernetesVersion)))
+	}
+
+	return allErrs
+}
+
+func validateOutboundRule(outboundRule *apisazure.OutboundRule, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if ports := outboundRule.AllocatedOutboundPorts; ports != nil && (*ports < 0 || *ports > maxAllocatedOutboundPorts || *ports%8 != 0) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("allocatedOutboundPorts"), *ports, fmt.Sprintf("must be a multiple of 8 between 0 and %d", maxAllocatedOutboundPorts)))
+	}
+
+	if timeout := outboundRule.IdleTimeoutInMinutes; timeout != nil && (*timeout < minOutboundIdleTimeoutInMinutes || *timeout > maxOutboundIdleTimeoutInMinutes) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("idleTimeoutInMinutes"), *timeout, fmt.Sprintf("must be between %d and %d", minOutboundIdleTimeoutInMinutes, maxOutboundIdleTimeoutInMinutes)))
+	}
+
+	return allErrs
+}
+
+func validateStorage(storage *apisazure.Storage, infra *apisazure.InfrastructureConfig, kubernetesVersion string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	useCSI, err := versionutils.CompareVersions(kubernetesVersion, ">=", azure.CSIMigrationKubernetesVersion)
+	if err != nil {
+		return append(allErrs, field.InternalError(fldPath, err))
+	}
+
+	managedStorageClasses := storage.ManagedStorageClasses == nil || *storage.ManagedStorageClasses
+	storageClassNames := sets.NewString()
+	if managedStorageClasses {
+		storageClassNames.Insert(managedStorageClassNames.UnsortedList()...)
+	}
+
+	for i, sc := range storage.StorageClasses {
+		idxPath := fldPath.Child("storageClasses").Index(i)
+
+		if len(sc.Name) == 0 {
+			allErrs = append(allErrs, field.Required(idxPath.Child("name"), "must provide a name"))
+		} else {
+			for _, msg := range apivalidation.NameIsDNSSubdomain(sc.Name, false) {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("name"), sc.Name, msg))
+			}
+			if managedStorageClasses && managedStorageClassNames.Has(sc.Name) {
+				allErrs = append(allErrs, field.Forbidden(idxPath.Child("name"), "name is already used by a storage class provided by Gardener"))
+			} else if storageClassNames.Has(sc.Name) {
+				allErrs = append(allErrs, field.Duplicate(idxPath.Child("name"), sc.Name))
+			}
+			storageClassNames.Insert(sc.Name)
+		}
+
+		if !supportedSkuNames.Has(sc.SkuName) {
+			allErrs = append(allErrs, field.NotSupported(idxPath.Child("skuName"), sc.SkuName, supportedSkuNames.List()))
+		} else if zoneRedundantSkuNames.Has(sc.SkuName) && !useCSI {
+			allErrs = append(allErrs, field.Forbidden(idxPath.Child("skuName"), fmt.Sprintf("zone redundant disks are only supported as of Kubernetes %s", azure.CSIMigrationKubernetesVersion)))
+		}
+
+		if sc.CachingMode != nil && !supportedCachingModes.Has(*sc.CachingMode) {
+			allErrs = append(allErrs, field.NotSupported(idxPath.Child("cachingMode"), *sc.CachingMode, supportedCachingModes.List()))
+		}
+
+		if mode := sc.VolumeBindingMode; mode != nil {
+			if !supportedVolumeBindingModes.Has(string(*mode)) {
+				allErrs = append(allErrs, field.NotSupported(idxPath.Child("volumeBindingMode"), *mode, supportedVolumeBindingModes.List()))
+			} else if infra.Zoned && *mode == storagev1.VolumeBindingImmediate {
+				// Volumes of zoned clusters must be provisioned in the zone of the node of the pod which uses them.
+				allErrs = append(allErrs, field.Forbidden(idxPath.Child("volumeBindingMode"), fmt.Sprintf("zoned clusters require the volume binding mode %s", storagev1.VolumeBindingWaitForFirstConsumer)))
+			}
+		}
+
+		if sc.ReclaimPolicy != nil && !supportedReclaimPolicies.Has(string(*sc.ReclaimPolicy)) {
+			allErrs = append(allErrs, field.NotSupported(idxPath.Child("reclaimPolicy"), *sc.ReclaimPolicy, supportedReclaimPolicies.List()))
+		}
+
+		if len(sc.Zones) > 0 && !infra.Zoned {
+			allErrs = append(allErrs, field.Forbidden(idxPath.Child("zones"), "zones are only supported for zoned clusters"))
+		}
+		zones := sets.NewString()
+		for j, zone := range sc.Zones {
+			if zones.Has(zone) {
+				allErrs = append(allErrs, field.Duplicate(idxPath.Child("zones").Index(j), zone))
+			}
+			zones.Insert(zone)
+		}
+	}
+
+	if storage.DefaultStorageClass != nil && !storageClassNames.Has(*storage.DefaultStorageClass) {
+		allErrs = append(allErrs, field.NotFound(fldPath.Child("defaultStorageClass"), *storage.DefaultStorageClass))
+	}
+
+	if volumeSnapshotClass := storage.VolumeSnapshotClass; volumeSnapshotClass != nil {
+		volumeSnapshotClassPath := fldPath.Child("volumeSnapshotClass")
+		if !useCSI {
+			allErrs = append(allErrs, field.Forbidden(volumeSnapshotClassPath, fmt.Sprintf("volume snapshots are only supported as of Kubernetes %s", azure.CSIMigrationKubernetesVersion)))
+		}
+		if volumeSnapshotClass.ResourceGroup != nil && len(*volumeSnapshotClass.ResourceGroup) == 0 {
+			allErrs = append(allErrs, field.Invalid(volumeSnapshotClassPath.Child("resourceGroup"), *volumeSnapshotClass.ResourceGroup, "resource group name must not be empty"))
+		}
+	}
+
+	return allErrs
+}
+
+func validateKMS(kms *apisazure.KMS, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(kms.KeyVaultName) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("keyVaultName"), "must provide the name of the key vault"))
+	} else if !keyVaultNameRegex.MatchString(kms.KeyVaultName) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("keyVaultName"), kms.KeyVaultName, fmt.Sprintf("does not match expected regex %s", keyVaultNameRegex)))
+	}
+
+	if len(kms.KeyName) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("keyName"), "must provide the name of the key"))
+	} else if !keyNameRegex.MatchString(kms.KeyName) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("keyName"), kms.KeyName, fmt.Sprintf("does not match expected regex %s", keyNameRegex)))
+	}
+
+	keyVersions := sets.NewString()
+	if len(kms.KeyVersion) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("keyVersion"), "must provide the version of the key"))
+	} else if !keyVersionRegex.MatchString(kms.KeyVersion) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("keyVersion"), kms.KeyVersion, fmt.Sprintf("does not match expected regex %s", keyVersionRegex)))
+	}
+	keyVersions.Insert(kms.KeyVersion)
+
+	for i, keyVersion := range kms.PreviousKeyVersions {
+		idxPath := fldPath.Child("previousKeyVersions").Index(i)
+		if !keyVersionRegex.MatchString(keyVersion) {
+			allErrs = append(allErrs, field.Invalid(idxPath, keyVersion, fmt.Sprintf("does not match expected regex %s", keyVersionRegex)))
+		}
+		if keyVersions.Has(keyVersion) {
+			allErrs = append(allErrs, field.Duplicate(idxPath, keyVersion))
+		}
+		keyVersions.Insert(keyVersion)
+	}
+
+	return allErrs
+}
+
+func validateKubeAPIServerExposure(exposure *apisazure.KubeAPIServerExposure, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if timeout := exposure.TCPIdleTimeoutInMinutes; timeout != nil && (*timeout < minInboundIdleTimeoutInMinutes || *timeout > maxInboundIdleTimeoutInMinutes) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("tcpIdleTimeoutInMinutes"), *timeout, fmt.Sprintf("must be between %d and %d", minInboundIdleTimeoutInMinutes, maxInboundIdleTimeoutInMinutes)))
+	}
+
+	if dnsLabel := exposure.DNSLabel; dnsLabel != nil && !dnsLabelRegex.MatchString(*dnsLabel) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("dnsLabel"), *dnsLabel, fmt.Sprintf("does not match expected regex %s", dnsLabelRegex)))
+	}
+
+	if pls := exposure.PrivateLinkService; pls != nil {
+		plsPath := fldPath.Child("privateLinkService")
+		allErrs = append(allErrs, validateSubscriptions(pls.AllowedSubscriptions, plsPath.Child("allowedSubscriptions"))...)
+		allErrs = append(allErrs, validateSubscriptions(pls.AutoApprovedSubscriptions, plsPath.Child("autoApprovedSubscriptions"))...)
+	}
+
+	return allErrs
+}
+
+func validateScheduledEventsHandler(handler *apisazure.ScheduledEventsHandler, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	seen := sets.NewString()
+	for i, eventType := range handler.EventTypes {
+		idxPath := fldPath.Child("eventTypes").Index(i)
+		if !supportedScheduledEventTypes.Has(string(eventType)) {
+			allErrs = append(allErrs, field.NotSupported(idxPath, eventType, supportedScheduledEventTypes.List()))
+		} else if seen.Has(string(eventType)) {
+			allErrs = append(allErrs, field.Duplicate(idxPath, eventType))
+		}
+		seen.Insert(string(eventType))
+	}
+
+	if handler.DrainTimeout != nil && handler.DrainTimeout.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("drainTimeout"), handler.DrainTimeout.Duration.String(), "must be positive"))
+	}
+
+	return allErrs
+}
+
+func validateSubscriptions(subscriptions []string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	seen := sets.NewString()
+	for i, subscription := range subscriptions {
+		idxPath := fldPath.Index(i)
+		if len(subscription) == 0 {
+			allErrs = append(allErrs, field.Required(idxPath, "must provide a subscription"))
+		} else if seen.Has(subscription) {
+			allErrs = append(allErrs, field.Duplicate(idxPath, subscription))
+		}
+		seen.Insert(subscription)
+	}
+
+	return allErrs
+}
@@ -0,0 +1,695 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation_test
+
+import (
+	"time"
+
+	apisazure "github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure"
+	. "github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure/validation"
+
+	"github.com/gardener/gardener/pkg/apis/core"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/pointer"
+)
+
+var _ = Describe("ControlPlaneConfig validation", func() {
+	var (
+		fldPath           = field.NewPath("config")
+		kubernetesVersion = "1.19.2"
+
+		controlPlaneConfig *apisazure.ControlPlaneConfig
+		infra              *apisazure.InfrastructureConfig
+		workers            []core.Worker
+	)
+
+	BeforeEach(func() {
+		kubernetesVersion = "1.19.2"
+		controlPlaneConfig = &apisazure.ControlPlaneConfig{
+			LoadBalancer: &apisazure.LoadBalancerConfig{},
+		}
+		infra = &apisazure.InfrastructureConfig{
+			Zoned: true,
+		}
+		workers = []core.Worker{
+			{Name: "worker1"},
+			{Name: "worker2"},
+		}
+	})
+
+	Describe("#ValidateControlPlaneConfig", func() {
+		It("should allow an empty control plane config", func() {
+			Expect(ValidateControlPlaneConfig(&apisazure.ControlPlaneConfig{}, infra, kubernetesVersion, workers, fldPath)).To(BeEmpty())
+		})
+
+		It("should allow valid rate limit and backoff settings", func() {
+			controlPlaneConfig.CloudControllerManager = &apisazure.CloudControllerManagerConfig{
+				RateLimit: &apisazure.CloudProviderRateLimit{
+					QPS:         pointer.Float64Ptr(0.5),
+					Bucket:      pointer.Int32Ptr(1),
+					QPSWrite:    pointer.Float64Ptr(100),
+					BucketWrite: pointer.Int32Ptr(200),
+				},
+				Backoff: &apisazure.CloudProviderBackoff{
+					Retries:         pointer.Int32Ptr(20),
+					Exponent:        pointer.Float64Ptr(1),
+					DurationSeconds: pointer.Int32Ptr(300),
+					Jitter:          pointer.Float64Ptr(1),
+				},
+			}
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig, infra, kubernetesVersion, workers, fldPath)).To(BeEmpty())
+		})
+
+		It("should forbid non positive rate limit settings", func() {
+			controlPlaneConfig.CloudControllerManager = &apisazure.CloudControllerManagerConfig{
+				RateLimit: &apisazure.CloudProviderRateLimit{
+					QPS:         pointer.Float64Ptr(0),
+					Bucket:      pointer.Int32Ptr(0),
+					QPSWrite:    pointer.Float64Ptr(-1),
+					BucketWrite: pointer.Int32Ptr(-1),
+				},
+			}
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig, infra, kubernetesVersion, workers, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("config.cloudControllerManager.rateLimit.qps"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("config.cloudControllerManager.rateLimit.bucket"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("config.cloudControllerManager.rateLimit.qpsWrite"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("config.cloudControllerManager.rateLimit.bucketWrite"),
+				})),
+			))
+		})
+
+		It("should forbid backoff settings out of range", func() {
+			controlPlaneConfig.CloudControllerManager = &apisazure.CloudControllerManagerConfig{
+				Backoff: &apisazure.CloudProviderBackoff{
+					Retries:         pointer.Int32Ptr(0),
+					Exponent:        pointer.Float64Ptr(0.5),
+					DurationSeconds: pointer.Int32Ptr(301),
+					Jitter:          pointer.Float64Ptr(0),
+				},
+			}
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig, infra, kubernetesVersion, workers, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("config.cloudControllerManager.backoff.retries"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("config.cloudControllerManager.backoff.exponent"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("config.cloudControllerManager.backoff.durationSeconds"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("config.cloudControllerManager.backoff.jitter"),
+				})),
+			))
+		})
+
+		It("should allow a valid load balancer config", func() {
+			backendPoolType := apisazure.PreConfiguredBackendPoolTypeInternal
+			controlPlaneConfig.LoadBalancer = &apisazure.LoadBalancerConfig{
+				OutboundRule: &apisazure.OutboundRule{
+					AllocatedOutboundPorts: pointer.Int32Ptr(1024),
+					IdleTimeoutInMinutes:   pointer.Int32Ptr(30),
+				},
+				DisableOutboundSNAT:          pointer.BoolPtr(true),
+				ExcludedWorkerPools:          []string{"worker2"},
+				PreConfiguredBackendPoolType: &backendPoolType,
+			}
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig, infra, kubernetesVersion, workers, fldPath)).To(BeEmpty())
+		})
+
+		It("should forbid the settings of the standard load balancer for non zoned clusters", func() {
+			backendPoolType := apisazure.PreConfiguredBackendPoolTypeAll
+			infra.Zoned = false
+			controlPlaneConfig.LoadBalancer = &apisazure.LoadBalancerConfig{
+				OutboundRule:                 &apisazure.OutboundRule{},
+				DisableOutboundSNAT:          pointer.BoolPtr(true),
+				PreConfiguredBackendPoolType: &backendPoolType,
+			}
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig, infra, kubernetesVersion, workers, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeForbidden),
+					"Field": Equal("config.loadBalancer.outboundRule"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeForbidden),
+					"Field": Equal("config.loadBalancer.disableOutboundSNAT"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeForbidden),
+					"Field": Equal("config.loadBalancer.preConfiguredBackendPoolType"),
+				})),
+			))
+		})
+
+		It("should allow to exclude worker pools of non zoned clusters", func() {
+			infra.Zoned = false
+			controlPlaneConfig.LoadBalancer.ExcludedWorkerPools = []string{"worker1"}
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig, infra, kubernetesVersion, workers, fldPath)).To(BeEmpty())
+		})
+
+		It("should forbid an outbound rule in combination with a NatGateway", func() {
+			infra.Networks.NatGateway = &apisazure.NatGatewayConfig{Enabled: true}
+			controlPlaneConfig.LoadBalancer.OutboundRule = &apisazure.OutboundRule{}
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig, infra, kubernetesVersion, workers, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeForbidden),
+					"Field": Equal("config.loadBalancer.outboundRule"),
+				})),
+			))
+		})
+
+		It("should forbid invalid outbound rule settings", func() {
+			controlPlaneConfig.LoadBalancer.OutboundRule = &apisazure.OutboundRule{
+				AllocatedOutboundPorts: pointer.Int32Ptr(1001),
+				IdleTimeoutInMinutes:   pointer.Int32Ptr(3),
+			}
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig, infra, kubernetesVersion, workers, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("config.loadBalancer.outboundRule.allocatedOutboundPorts"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("config.loadBalancer.outboundRule.idleTimeoutInMinutes"),
+				})),
+			))
+		})
+
+		It("should forbid too many allocated outbound ports and a too long idle timeout", func() {
+			controlPlaneConfig.LoadBalancer.OutboundRule = &apisazure.OutboundRule{
+				AllocatedOutboundPorts: pointer.Int32Ptr(64008),
+				IdleTimeoutInMinutes:   pointer.Int32Ptr(121),
+			}
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig, infra, kubernetesVersion, workers, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("config.loadBalancer.outboundRule.allocatedOutboundPorts"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("config.loadBalancer.outboundRule.idleTimeoutInMinutes"),
+				})),
+			))
+		})
+
+		It("should forbid an unsupported preconfigured backend pool type", func() {
+			backendPoolType := apisazure.PreConfiguredBackendPoolType("foo")
+			controlPlaneConfig.LoadBalancer.PreConfiguredBackendPoolType = &backendPoolType
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig, infra, kubernetesVersion, workers, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeNotSupported),
+					"Field": Equal("config.loadBalancer.preConfiguredBackendPoolType"),
+				})),
+			))
+		})
+
+		It("should forbid to exclude unknown or duplicate worker pools", func() {
+			controlPlaneConfig.LoadBalancer.ExcludedWorkerPools = []string{"worker1", "worker3", "worker1"}
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig, infra, kubernetesVersion, workers, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeNotFound),
+					"Field": Equal("config.loadBalancer.excludedWorkerPools[1]"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeDuplicate),
+					"Field": Equal("config.loadBalancer.excludedWorkerPools[2]"),
+				})),
+			))
+		})
+
+		It("should allow to exclude worker pools below Kubernetes 1.19 if the ServiceNodeExclusion feature gate is enabled", func() {
+			kubernetesVersion = "1.18.10"
+			controlPlaneConfig.CloudControllerManager = &apisazure.CloudControllerManagerConfig{
+				FeatureGates: map[string]bool{"ServiceNodeExclusion": true},
+			}
+			controlPlaneConfig.LoadBalancer.ExcludedWorkerPools = []string{"worker1"}
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig, infra, kubernetesVersion, workers, fldPath)).To(BeEmpty())
+		})
+
+		It("should forbid to exclude worker pools below Kubernetes 1.19 if the ServiceNodeExclusion feature gate is not enabled", func() {
+			kubernetesVersion = "1.18.10"
+			controlPlaneConfig.LoadBalancer.ExcludedWorkerPools = []string{"worker1"}
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig, infra, kubernetesVersion, workers, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(field.ErrorTypeForbidden),
+					"Field":  Equal("config.loadBalancer.excludedWorkerPools"),
+					"Detail": ContainSubstring("ServiceNodeExclusion"),
+				})),
+			))
+		})
+
+		It("should forbid to exclude worker pools below Kubernetes 1.16", func() {
+			kubernetesVersion = "1.15.12"
+			controlPlaneConfig.CloudControllerManager = &apisazure.CloudControllerManagerConfig{
+				FeatureGates: map[string]bool{"ServiceNodeExclusion": true},
+			}
+			controlPlaneConfig.LoadBalancer.ExcludedWorkerPools = []string{"worker1"}
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig, infra, kubernetesVersion, workers, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeForbidden),
+					"Field": Equal("config.loadBalancer.excludedWorkerPools"),
+				})),
+			))
+		})
+
+		It("should allow valid storage classes", func() {
+			var (
+				volumeBindingMode = storagev1.VolumeBindingWaitForFirstConsumer
+				reclaimPolicy     = corev1.PersistentVolumeReclaimRetain
+			)
+			controlPlaneConfig.Storage = &apisazure.Storage{
+				ManagedStorageClasses: pointer.BoolPtr(false),
+				DefaultStorageClass:   pointer.StringPtr("default"),
+				StorageClasses: []apisazure.StorageClass{
+					{
+						Name:              "default",
+						SkuName:           "Premium_LRS",
+						CachingMode:       pointer.StringPtr("ReadOnly"),
+						VolumeBindingMode: &volumeBindingMode,
+						ReclaimPolicy:     &reclaimPolicy,
+						Zones:             []string{"1", "2"},
+					},
+					{
+						Name:    "premium-zrs",
+						SkuName: "Premium_ZRS",
+					},
+				},
+			}
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig, infra, kubernetesVersion, workers, fldPath)).To(BeEmpty())
+		})
+
+		It("should forbid invalid storage classes", func() {
+			var (
+				volumeBindingMode = storagev1.VolumeBindingMode("foo")
+				reclaimPolicy     = corev1.PersistentVolumeReclaimRecycle
+			)
+			controlPlaneConfig.Storage = &apisazure.Storage{
+				DefaultStorageClass: pointer.StringPtr("foo"),
+				StorageClasses: []apisazure.StorageClass{
+					{
+						Name:    "managed-premium-ssd",
+						SkuName: "Premium_LRS",
+					},
+					{
+						Name:              "Invalid_Name",
+						SkuName:           "foo",
+						CachingMode:       pointer.StringPtr("foo"),
+						VolumeBindingMode: &volumeBindingMode,
+						ReclaimPolicy:     &reclaimPolicy,
+						Zones:             []string{"1", "1"},
+					},
+					{
+						SkuName: "Premium_LRS",
+					},
+				},
+			}
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig, infra, kubernetesVersion, workers, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeForbidden),
+					"Field": Equal("config.storage.storageClasses[0].name"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("config.storage.storageClasses[1].name"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeNotSupported),
+					"Field": Equal("config.storage.storageClasses[1].skuName"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeNotSupported),
+					"Field": Equal("config.storage.storageClasses[1].cachingMode"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeNotSupported),
+					"Field": Equal("config.storage.storageClasses[1].volumeBindingMode"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeNotSupported),
+					"Field": Equal("config.storage.storageClasses[1].reclaimPolicy"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeDuplicate),
+					"Field": Equal("config.storage.storageClasses[1].zones[1]"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("config.storage.storageClasses[2].name"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeNotFound),
+					"Field": Equal("config.storage.defaultStorageClass"),
+				})),
+			))
+		})
+
+		It("should forbid zone redundant disks before the CSI migration", func() {
+			kubernetesVersion = "1.18.10"
+			controlPlaneConfig.Storage = &apisazure.Storage{
+				StorageClasses: []apisazure.StorageClass{
+					{
+						Name:    "premium-zrs",
+						SkuName: "Premium_ZRS",
+					},
+				},
+			}
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig, infra, kubernetesVersion, workers, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeForbidden),
+					"Field": Equal("config.storage.storageClasses[0].skuName"),
+				})),
+			))
+		})
+
+		It("should forbid the immediate volume binding mode and zones according to the zoned setting", func() {
+			volumeBindingMode := storagev1.VolumeBindingImmediate
+			controlPlaneConfig.Storage = &apisazure.Storage{
+				StorageClasses: []apisazure.StorageClass{
+					{
+						Name:              "premium",
+						SkuName:           "Premium_LRS",
+						VolumeBindingMode: &volumeBindingMode,
+						Zones:             []string{"1"},
+					},
+				},
+			}
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig, infra, kubernetesVersion, workers, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeForbidden),
+					"Field": Equal("config.storage.storageClasses[0].volumeBindingMode"),
+				})),
+			))
+
+			infra.Zoned = false
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig, infra, kubernetesVersion, workers, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeForbidden),
+					"Field": Equal("config.storage.storageClasses[0].zones"),
+				})),
+			))
+		})
+
+		It("should allow a volume snapshot class for clusters which use the CSI drivers", func() {
+			controlPlaneConfig.Storage = &apisazure.Storage{
+				VolumeSnapshotClass: &apisazure.VolumeSnapshotClass{
+					Incremental:   pointer.BoolPtr(false),
+					ResourceGroup: pointer.StringPtr("snapshots"),
+				},
+			}
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig, infra, kubernetesVersion, workers, fldPath)).To(BeEmpty())
+		})
+
+		It("should forbid an invalid volume snapshot class", func() {
+			kubernetesVersion = "1.18.10"
+			controlPlaneConfig.Storage = &apisazure.Storage{
+				VolumeSnapshotClass: &apisazure.VolumeSnapshotClass{
+					ResourceGroup: pointer.StringPtr(""),
+				},
+			}
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig, infra, kubernetesVersion, workers, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeForbidden),
+					"Field": Equal("config.storage.volumeSnapshotClass"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("config.storage.volumeSnapshotClass.resourceGroup"),
+				})),
+			))
+		})
+
+		It("should allow a valid kms config", func() {
+			controlPlaneConfig.KMS = &apisazure.KMS{
+				KeyVaultName:        "my-vault",
+				KeyName:             "my-key",
+				KeyVersion:          "0123456789abcdef0123456789abcdef",
+				PreviousKeyVersions: []string{"fedcba9876543210fedcba9876543210"},
+			}
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig, infra, kubernetesVersion, workers, fldPath)).To(BeEmpty())
+		})
+
+		It("should forbid an invalid kms config", func() {
+			controlPlaneConfig.KMS = &apisazure.KMS{
+				KeyVaultName:        "1vault",
+				KeyVersion:          "0123456789abcdef0123456789abcdef",
+				PreviousKeyVersions: []string{"foo", "0123456789abcdef0123456789abcdef"},
+			}
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig, infra, kubernetesVersion, workers, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("config.kms.keyVaultName"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("config.kms.keyName"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("config.kms.previousKeyVersions[0]"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeDuplicate),
+					"Field": Equal("config.kms.previousKeyVersions[1]"),
+				})),
+			))
+		})
+		It("should allow a valid kube-apiserver exposure", func() {
+			controlPlaneConfig.KubeAPIServerExposure = &apisazure.KubeAPIServerExposure{
+				PrivateLinkService: &apisazure.PrivateLinkService{
+					Enabled:                   pointer.BoolPtr(true),
+					AllowedSubscriptions:      []string{"*"},
+					AutoApprovedSubscriptions: []string{"00000000-0000-0000-0000-000000000000"},
+				},
+				TCPIdleTimeoutInMinutes: pointer.Int32Ptr(30),
+			}
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig, infra, kubernetesVersion, workers, fldPath)).To(BeEmpty())
+		})
+
+		It("should allow a valid DNS label of the static public IP", func() {
+			controlPlaneConfig.KubeAPIServerExposure = &apisazure.KubeAPIServerExposure{
+				DNSLabel: pointer.StringPtr("my-api-1"),
+			}
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig, infra, kubernetesVersion, workers, fldPath)).To(BeEmpty())
+		})
+
+		It("should forbid an invalid DNS label", func() {
+			controlPlaneConfig.KubeAPIServerExposure = &apisazure.KubeAPIServerExposure{
+				DNSLabel: pointer.StringPtr("My_API"),
+			}
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig, infra, kubernetesVersion, workers, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("config.kubeAPIServerExposure.dnsLabel"),
+				})),
+			))
+		})
+
+		It("should forbid an invalid kube-apiserver exposure", func() {
+			controlPlaneConfig.KubeAPIServerExposure = &apisazure.KubeAPIServerExposure{
+				PrivateLinkService: &apisazure.PrivateLinkService{
+					Enabled:                   pointer.BoolPtr(true),
+					AllowedSubscriptions:      []string{"sub", "sub"},
+					AutoApprovedSubscriptions: []string{""},
+				},
+				TCPIdleTimeoutInMinutes: pointer.Int32Ptr(3),
+			}
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig, infra, kubernetesVersion, workers, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("config.kubeAPIServerExposure.tcpIdleTimeoutInMinutes"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeDuplicate),
+					"Field": Equal("config.kubeAPIServerExposure.privateLinkService.allowedSubscriptions[1]"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("config.kubeAPIServerExposure.privateLinkService.autoApprovedSubscriptions[0]"),
+				})),
+			))
+		})
+
+		It("should allow a valid scheduled events handler", func() {
+			controlPlaneConfig.ScheduledEventsHandler = &apisazure.ScheduledEventsHandler{
+				Enabled:      true,
+				EventTypes:   []apisazure.ScheduledEventType{apisazure.ScheduledEventTypePreempt, apisazure.ScheduledEventTypeReboot},
+				DrainTimeout: &metav1.Duration{Duration: 2 * time.Minute},
+			}
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig, infra, kubernetesVersion, workers, fldPath)).To(BeEmpty())
+		})
+
+		It("should forbid an invalid scheduled events handler", func() {
+			controlPlaneConfig.ScheduledEventsHandler = &apisazure.ScheduledEventsHandler{
+				Enabled:      true,
+				EventTypes:   []apisazure.ScheduledEventType{"Shutdown", apisazure.ScheduledEventTypeReboot, apisazure.ScheduledEventTypeReboot},
+				DrainTimeout: &metav1.Duration{},
+			}
+
+			Expect(ValidateControlPlaneConfig(controlPlaneConfig, infra, kubernetesVersion, workers, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeNotSupported),
+					"Field": Equal("config.scheduledEventsHandler.eventTypes[0]"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeDuplicate),
+					"Field": Equal("config.scheduledEventsHandler.eventTypes[2]"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("config.scheduledEventsHandler.drainTimeout"),
+				})),
+			))
+		})
+	})
+
+	Describe("#ValidateControlPlaneConfigUpdate", func() {
+		var oldControlPlaneConfig *apisazure.ControlPlaneConfig
+
+		BeforeEach(func() {
+			oldControlPlaneConfig = &apisazure.ControlPlaneConfig{
+				KMS: &apisazure.KMS{
+					KeyVaultName: "my-vault",
+					KeyName:      "my-key",
+					KeyVersion:   "0123456789abcdef0123456789abcdef",
+				},
+			}
+		})
+
+		It("should allow to enable kms", func() {
+			controlPlaneConfig.KMS = oldControlPlaneConfig.KMS.DeepCopy()
+
+			Expect(ValidateControlPlaneConfigUpdate(&apisazure.ControlPlaneConfig{}, controlPlaneConfig, fldPath)).To(BeEmpty())
+		})
+
+		It("should forbid to disable kms", func() {
+			Expect(ValidateControlPlaneConfigUpdate(oldControlPlaneConfig, controlPlaneConfig, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeForbidden),
+					"Field": Equal("config.kms"),
+				})),
+			))
+		})
+
+		It("should allow to rotate the key version", func() {
+			controlPlaneConfig.KMS = oldControlPlaneConfig.KMS.DeepCopy()
+			controlPlaneConfig.KMS.KeyVersion = "fedcba9876543210fedcba9876543210"
+			controlPlaneConfig.KMS.PreviousKeyVersions = []string{"0123456789abcdef0123456789abcdef"}
+
+			Expect(ValidateControlPlaneConfigUpdate(oldControlPlaneConfig, controlPlaneConfig, fldPath)).To(BeEmpty())
+		})
+
+		It("should forbid to change the key vault or the key and to drop the former key version", func() {
+			controlPlaneConfig.KMS = &apisazure.KMS{
+				KeyVaultName: "other-vault",
+				KeyName:      "other-key",
+				KeyVersion:   "fedcba9876543210fedcba9876543210",
+			}
+
+			Expect(ValidateControlPlaneConfigUpdate(oldControlPlaneConfig, controlPlaneConfig, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("config.kms.keyVaultName"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("config.kms.keyName"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeForbidden),
+					"Field": Equal("config.kms.previousKeyVersions"),
+				})),
+			))
+		})
+
+		It("should allow to change the settings of an existing outbound rule", func() {
+			oldControlPlaneConfig.LoadBalancer = &apisazure.LoadBalancerConfig{OutboundRule: &apisazure.OutboundRule{}}
+			controlPlaneConfig.KMS = oldControlPlaneConfig.KMS.DeepCopy()
+			controlPlaneConfig.LoadBalancer.OutboundRule = &apisazure.OutboundRule{IdleTimeoutInMinutes: pointer.Int32Ptr(30)}
+
+			Expect(ValidateControlPlaneConfigUpdate(oldControlPlaneConfig, controlPlaneConfig, fldPath)).To(BeEmpty())
+		})
+
+		It("should forbid to add an outbound rule to an existing cluster", func() {
+			controlPlaneConfig.LoadBalancer.OutboundRule = &apisazure.OutboundRule{}
+
+			Expect(ValidateControlPlaneConfigUpdate(&apisazure.ControlPlaneConfig{}, controlPlaneConfig, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeForbidden),
+					"Field": Equal("config.loadBalancer.outboundRule"),
+				})),
+			))
+		})
+
+		It("should forbid to remove the outbound rule of an existing cluster", func() {
+			oldControlPlaneConfig.LoadBalancer = &apisazure.LoadBalancerConfig{OutboundRule: &apisazure.OutboundRule{}}
+			controlPlaneConfig.KMS = oldControlPlaneConfig.KMS.DeepCopy()
+
+			Expect(ValidateControlPlaneConfigUpdate(oldControlPlaneConfig, controlPlaneConfig, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeForbidden),
+					"Field": Equal("config.loadBalancer.outboundRule"),
+				})),
+			))
+		})
+	})
+})
@@ -15,13 +15,26 @@
 package validation
 
 import (
+	"fmt"
+	"regexp"
+	"strings"
+
 	apisazure "github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure"
+	"github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure/helper"
 
 	cidrvalidation "github.com/gardener/gardener/pkg/utils/validation/cidr"
 	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
+var (
+	// containerRegistryIDRegex matches the Azure resource id of a container registry.
+	containerRegistryIDRegex = regexp.MustCompile(`(?i)^/subscriptions/[^/]+/resourceGroups/[^/]+/providers/Microsoft\.ContainerRegistry/registries/[^/]+$`)
+	// diskEncryptionSetIDRegex matches the Azure resource id of a disk encryption set.
+	diskEncryptionSetIDRegex = regexp.MustCompile(`(?i)^/subscriptions/[^/]+/resourceGroups/[^/]+/providers/Microsoft\.Compute/diskEncryptionSets/[^/]+$`)
+)
+
 // ValidateInfrastructureConfig validates a InfrastructureConfig object.
 func ValidateInfrastructureConfig(infra *apisazure.InfrastructureConfig, nodesCIDR, podsCIDR, servicesCIDR *string, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
@@ -93,10 +106,20 @@ func ValidateInfrastructureConfig(infra *apisazure.InfrastructureConfig, nodesCI
 		allErrs = append(allErrs, field.Invalid(fldPath.Child("networks", "natGateway"), infra.Networks.NatGateway, "NatGateway is currently only supported for zoned cluster"))
 	}
 
+	if mode := infra.Networks.PodNetworkMode; mode != nil && *mode != apisazure.PodNetworkModeRoutes && *mode != apisazure.PodNetworkModeOverlay {
+		allErrs = append(allErrs, field.NotSupported(networksPath.Child("podNetworkMode"), *mode, []string{string(apisazure.PodNetworkModeRoutes), string(apisazure.PodNetworkModeOverlay)}))
+	}
+
 	if infra.Identity != nil && (infra.Identity.Name == "" || infra.Identity.ResourceGroup == "") {
 		allErrs = append(allErrs, field.Invalid(fldPath.Child("identity"), infra.Identity, "specifying an identity requires the name of the identity and the resource group which hosts the identity"))
 	}
 
+	if infra.DiskEncryption != nil {
+		allErrs = append(allErrs, validateDiskEncryption(infra.DiskEncryption, fldPath.Child("diskEncryption"))...)
+	}
+
+	allErrs = append(allErrs, validateContainerRegistries(infra.ContainerRegistries, fldPath.Child("containerRegistries"))...)
+
 	if nodes != nil {
 		allErrs = append(allErrs, nodes.ValidateSubset(workerCIDR)...)
 	}
@@ -104,6 +127,64 @@ func ValidateInfrastructureConfig(infra *apisazure.InfrastructureConfig, nodesCI
 	return allErrs
 }
 
+func validateDiskEncryption(diskEncryption *apisazure.DiskEncryption, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if diskEncryption.DiskEncryptionSetID != nil && !diskEncryptionSetIDRegex.MatchString(*diskEncryption.DiskEncryptionSetID) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("diskEncryptionSetID"), *diskEncryption.DiskEncryptionSetID, "must be the resource id of a disk encryption set in the format '/subscriptions/<subscription>/resourceGroups/<resource-group>/providers/Microsoft.Compute/diskEncryptionSets/<name>'"))
+	}
+
+	return allErrs
+}
+
+func validateContainerRegistries(registries []apisazure.ContainerRegistry, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	seen := sets.NewString()
+	for i, registry := range registries {
+		idPath := fldPath.Index(i).Child("id")
+		// Azure resource ids are case-insensitive.
+		id := strings.ToLower(registry.ID)
+		if !containerRegistryIDRegex.MatchString(registry.ID) {
+			allErrs = append(allErrs, field.Invalid(idPath, registry.ID, "must be the resource id of a container registry in the format '/subscriptions/<subscription>/resourceGroups/<resource-group>/providers/Microsoft.ContainerRegistry/registries/<name>'"))
+		} else if seen.Has(id) {
+			allErrs = append(allErrs, field.Duplicate(idPath, registry.ID))
+		}
+		seen.Insert(id)
+	}
+
+	return allErrs
+}
+
+// ValidateInfrastructureConfigAgainstCloudProfile validates the given InfrastructureConfig against the region specific
+// information of the cloud profile, i.e. whether the region supports zones or the domain counts of the availability
+// set are known for the region.
+// On updates only a changed zoned setting is validated, so that existing clusters are not rejected if the cloud profile
+// changes.
+func ValidateInfrastructureConfigAgainstCloudProfile(oldInfra, infra *apisazure.InfrastructureConfig, region string, cloudProfileConfig *apisazure.CloudProfileConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if cloudProfileConfig == nil || (oldInfra != nil && oldInfra.Zoned == infra.Zoned) {
+		return allErrs
+	}
+
+	if infra.Zoned {
+		if r, err := helper.FindRegion(cloudProfileConfig.Regions, region); err == nil && len(r.Zones) == 0 {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("zoned"), fmt.Sprintf("region %q does not support zones", region)))
+		}
+		return allErrs
+	}
+
+	if _, err := helper.FindFaultDomainCount(cloudProfileConfig, region); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("zoned"), infra.Zoned, fmt.Sprintf("the cloud profile does not define a fault domain count for region %q which is required for non zoned clusters", region)))
+	}
+	if _, err := helper.FindUpdateDomainCount(cloudProfileConfig, region); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("zoned"), infra.Zoned, fmt.Sprintf("the cloud profile does not define an update domain count for region %q which is required for non zoned clusters", region)))
+	}
+
+	return allErrs
+}
+
 // ValidateInfrastructureConfigUpdate validates a InfrastructureConfig object.
 func ValidateInfrastructureConfigUpdate(oldConfig, newConfig *apisazure.InfrastructureConfig, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
@@ -111,6 +192,17 @@ func ValidateInfrastructureConfigUpdate(oldConfig, newConfig *apisazure.Infrastr
 	allErrs = append(allErrs, apivalidation.ValidateImmutableField(newConfig.ResourceGroup, oldConfig.ResourceGroup, fldPath.Child("resourceGroup"))...)
 	allErrs = append(allErrs, apivalidation.ValidateImmutableField(newConfig.Networks.VNet, oldConfig.Networks.VNet, fldPath.Child("networks").Child("vnet"))...)
 	allErrs = append(allErrs, apivalidation.ValidateImmutableField(newConfig.Networks.Workers, oldConfig.Networks.Workers, fldPath.Child("networks").Child("workers"))...)
+	allErrs = append(allErrs, apivalidation.ValidateImmutableField(helper.PodNetworkModeOrDefault(newConfig), helper.PodNetworkModeOrDefault(oldConfig), fldPath.Child("networks").Child("podNetworkMode"))...)
+
+	// The disk encryption set is part of the parameters of the storage classes which cannot be changed.
+	var oldDiskEncryptionSetID, newDiskEncryptionSetID *string
+	if oldConfig.DiskEncryption != nil {
+		oldDiskEncryptionSetID = oldConfig.DiskEncryption.DiskEncryptionSetID
+	}
+	if newConfig.DiskEncryption != nil {
+		newDiskEncryptionSetID = newConfig.DiskEncryption.DiskEncryptionSetID
+	}
+	allErrs = append(allErrs, apivalidation.ValidateImmutableField(newDiskEncryptionSetID, oldDiskEncryptionSetID, fldPath.Child("diskEncryption", "diskEncryptionSetID"))...)
 
 	if oldConfig.Zoned && !newConfig.Zoned {
 		allErrs = append(allErrs, field.Forbidden(fldPath.Child("zoned"), "moving a zoned cluster to a non-zoned cluster is not allowed"))
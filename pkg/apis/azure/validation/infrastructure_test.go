@@ -271,6 +271,32 @@ var _ = Describe("InfrastructureConfig validation", func() {
 			})
 		})
 
+		Context("ContainerRegistries", func() {
+			It("should return no errors for valid container registries", func() {
+				infrastructureConfig.ContainerRegistries = []apisazure.ContainerRegistry{
+					{ID: "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.ContainerRegistry/registries/registry1"},
+					{ID: "/subscriptions/other-sub/resourceGroups/rg/providers/Microsoft.ContainerRegistry/registries/registry2"},
+				}
+				Expect(ValidateInfrastructureConfig(infrastructureConfig, &nodes, &pods, &services, fldPath)).To(BeEmpty())
+			})
+
+			It("should return errors for invalid and duplicate container registries", func() {
+				infrastructureConfig.ContainerRegistries = []apisazure.ContainerRegistry{
+					{ID: "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.ContainerRegistry/registries/registry1"},
+					{ID: "/subscriptions/sub/resourcegroups/rg/providers/microsoft.containerregistry/registries/Registry1"},
+					{ID: "registry2.azurecr.io"},
+				}
+				errorList := ValidateInfrastructureConfig(infrastructureConfig, &nodes, &pods, &services, fldPath)
+				Expect(errorList).To(ConsistOfFields(Fields{
+					"Type":  Equal(field.ErrorTypeDuplicate),
+					"Field": Equal("containerRegistries[1].id"),
+				}, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("containerRegistries[2].id"),
+				}))
+			})
+		})
+
 		Context("NatGateway", func() {
 			It("should return no errors using a NatGateway for a zoned cluster", func() {
 				infrastructureConfig.Zoned = true
@@ -290,6 +316,42 @@ var _ = Describe("InfrastructureConfig validation", func() {
 				}))
 			})
 		})
+
+		Context("DiskEncryption", func() {
+			It("should return no errors for a valid disk encryption set id", func() {
+				diskEncryptionSetID := "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/diskEncryptionSets/des"
+				infrastructureConfig.DiskEncryption = &apisazure.DiskEncryption{DiskEncryptionSetID: &diskEncryptionSetID}
+				Expect(ValidateInfrastructureConfig(infrastructureConfig, &nodes, &pods, &services, fldPath)).To(BeEmpty())
+			})
+
+			It("should return an error for an invalid disk encryption set id", func() {
+				diskEncryptionSetID := "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/disks/disk"
+				infrastructureConfig.DiskEncryption = &apisazure.DiskEncryption{DiskEncryptionSetID: &diskEncryptionSetID}
+				errorList := ValidateInfrastructureConfig(infrastructureConfig, &nodes, &pods, &services, fldPath)
+				Expect(errorList).To(ConsistOfFields(Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("diskEncryption.diskEncryptionSetID"),
+				}))
+			})
+		})
+
+		Context("PodNetworkMode", func() {
+			It("should return no errors for the overlay pod network mode", func() {
+				mode := apisazure.PodNetworkModeOverlay
+				infrastructureConfig.Networks.PodNetworkMode = &mode
+				Expect(ValidateInfrastructureConfig(infrastructureConfig, &nodes, &pods, &services, fldPath)).To(BeEmpty())
+			})
+
+			It("should return an error for an unsupported pod network mode", func() {
+				mode := apisazure.PodNetworkMode("Bridge")
+				infrastructureConfig.Networks.PodNetworkMode = &mode
+				errorList := ValidateInfrastructureConfig(infrastructureConfig, &nodes, &pods, &services, fldPath)
+				Expect(errorList).To(ConsistOfFields(Fields{
+					"Type":  Equal(field.ErrorTypeNotSupported),
+					"Field": Equal("networks.podNetworkMode"),
+				}))
+			})
+		})
 	})
 
 	Describe("#ValidateInfrastructureConfigUpdate", func() {
@@ -322,6 +384,40 @@ var _ = Describe("InfrastructureConfig validation", func() {
 			}))))
 		})
 
+		It("should allow setting the default pod network mode explicitly", func() {
+			newInfrastructureConfig := infrastructureConfig.DeepCopy()
+			mode := apisazure.PodNetworkModeRoutes
+			newInfrastructureConfig.Networks.PodNetworkMode = &mode
+
+			Expect(ValidateInfrastructureConfigUpdate(infrastructureConfig, newInfrastructureConfig, fldPath)).To(BeEmpty())
+		})
+
+		It("should forbid changing the pod network mode", func() {
+			newInfrastructureConfig := infrastructureConfig.DeepCopy()
+			mode := apisazure.PodNetworkModeOverlay
+			newInfrastructureConfig.Networks.PodNetworkMode = &mode
+
+			errorList := ValidateInfrastructureConfigUpdate(infrastructureConfig, newInfrastructureConfig, fldPath)
+
+			Expect(errorList).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":  Equal(field.ErrorTypeInvalid),
+				"Field": Equal("networks.podNetworkMode"),
+			}))))
+		})
+
+		It("should forbid changing the disk encryption set", func() {
+			diskEncryptionSetID := "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/diskEncryptionSets/des"
+			newInfrastructureConfig := infrastructureConfig.DeepCopy()
+			newInfrastructureConfig.DiskEncryption = &apisazure.DiskEncryption{DiskEncryptionSetID: &diskEncryptionSetID}
+
+			errorList := ValidateInfrastructureConfigUpdate(infrastructureConfig, newInfrastructureConfig, fldPath)
+
+			Expect(errorList).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":  Equal(field.ErrorTypeInvalid),
+				"Field": Equal("diskEncryption.diskEncryptionSetID"),
+			}))))
+		})
+
 		It("should forbid moving a zoned cluster to a non zoned cluster", func() {
 			newInfrastructureConfig := infrastructureConfig.DeepCopy()
 			infrastructureConfig.Zoned = true
@@ -334,4 +430,83 @@ var _ = Describe("InfrastructureConfig validation", func() {
 			}))))
 		})
 	})
+
+	Describe("#ValidateInfrastructureConfigAgainstCloudProfile", func() {
+		var (
+			region             = "westeurope"
+			cloudProfileConfig *apisazure.CloudProfileConfig
+		)
+
+		BeforeEach(func() {
+			cloudProfileConfig = &apisazure.CloudProfileConfig{
+				CountFaultDomains:  []apisazure.DomainCount{{Region: region, Count: 2}},
+				CountUpdateDomains: []apisazure.DomainCount{{Region: region, Count: 5}},
+				Regions:            []apisazure.Region{{Name: region, Zones: []string{"1", "2", "3"}}},
+			}
+		})
+
+		It("should pass if no cloud profile config is given", func() {
+			Expect(ValidateInfrastructureConfigAgainstCloudProfile(nil, infrastructureConfig, region, nil, fldPath)).To(BeEmpty())
+		})
+
+		It("should pass for a non zoned cluster in a region with domain counts", func() {
+			Expect(ValidateInfrastructureConfigAgainstCloudProfile(nil, infrastructureConfig, region, cloudProfileConfig, fldPath)).To(BeEmpty())
+		})
+
+		It("should pass for a zoned cluster in a region with zones", func() {
+			infrastructureConfig.Zoned = true
+
+			Expect(ValidateInfrastructureConfigAgainstCloudProfile(nil, infrastructureConfig, region, cloudProfileConfig, fldPath)).To(BeEmpty())
+		})
+
+		It("should forbid a non zoned cluster in a region without domain counts", func() {
+			errorList := ValidateInfrastructureConfigAgainstCloudProfile(nil, infrastructureConfig, "eastus", cloudProfileConfig, fldPath)
+
+			Expect(errorList).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(field.ErrorTypeInvalid),
+					"Field":  Equal("zoned"),
+					"Detail": ContainSubstring("fault domain count"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(field.ErrorTypeInvalid),
+					"Field":  Equal("zoned"),
+					"Detail": ContainSubstring("update domain count"),
+				})),
+			))
+		})
+
+		It("should forbid a zoned cluster in a region without zones", func() {
+			infrastructureConfig.Zoned = true
+			cloudProfileConfig.Regions[0].Zones = nil
+
+			errorList := ValidateInfrastructureConfigAgainstCloudProfile(nil, infrastructureConfig, region, cloudProfileConfig, fldPath)
+
+			Expect(errorList).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":  Equal(field.ErrorTypeForbidden),
+				"Field": Equal("zoned"),
+			}))))
+		})
+
+		It("should pass for an unchanged cluster even if the cloud profile does not support it anymore", func() {
+			infrastructureConfig.Zoned = true
+			oldInfrastructureConfig := infrastructureConfig.DeepCopy()
+			cloudProfileConfig.Regions[0].Zones = nil
+
+			Expect(ValidateInfrastructureConfigAgainstCloudProfile(oldInfrastructureConfig, infrastructureConfig, region, cloudProfileConfig, fldPath)).To(BeEmpty())
+		})
+
+		It("should forbid a cluster which is changed to zoned in a region without zones", func() {
+			oldInfrastructureConfig := infrastructureConfig.DeepCopy()
+			infrastructureConfig.Zoned = true
+			cloudProfileConfig.Regions[0].Zones = nil
+
+			errorList := ValidateInfrastructureConfigAgainstCloudProfile(oldInfrastructureConfig, infrastructureConfig, region, cloudProfileConfig, fldPath)
+
+			Expect(errorList).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":  Equal(field.ErrorTypeForbidden),
+				"Field": Equal("zoned"),
+			}))))
+		})
+	})
 })
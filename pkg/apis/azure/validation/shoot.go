@@ -15,10 +15,16 @@
 package validation
 
 import (
+	"fmt"
+
+	apisazure "github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure"
+	"github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure/helper"
+
 	"github.com/gardener/gardener/pkg/apis/core"
 	"github.com/gardener/gardener/pkg/apis/core/validation"
 	"k8s.io/apimachinery/pkg/util/sets"
 
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	apivalidation "k8s.io/apimachinery/pkg/api/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
@@ -84,6 +90,90 @@ func ValidateWorkersUpdate(oldWorkers, newWorkers []core.Worker, fldPath *field.
 	return allErrs
 }
 
+// ValidateWorkersAgainstCloudProfile validates the workers of a Shoot against the region specific information and the
+// machine type capabilities of the cloud profile, i.e. whether the zones exist in the region, the machine types are
+// available in the zones, the machine images can be resolved for the region and are compatible with the machine types,
+// and whether the machine types support the number of data volumes.
+// Only workers which are new or whose machine type, machine image, zones or data volumes differ from the old workers
+// are validated, so that existing workers are not rejected if the cloud profile changes.
+func ValidateWorkersAgainstCloudProfile(oldWorkers, workers []core.Worker, region string, cloudProfileConfig *apisazure.CloudProfileConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if cloudProfileConfig == nil {
+		return allErrs
+	}
+
+	// The region specific information is optional, hence a missing region is not an error.
+	regionInfo, _ := helper.FindRegion(cloudProfileConfig.Regions, region)
+
+	for i, worker := range workers {
+		if !workerChangedAgainstCloudProfile(oldWorkers, worker) {
+			continue
+		}
+		idxPath := fldPath.Index(i)
+
+		// The capabilities of the machine type are optional, hence a machine type which is not listed is not an error.
+		machineTypeInfo, _ := helper.FindMachineType(cloudProfileConfig.MachineTypes, worker.Machine.Type)
+
+		if image := worker.Machine.Image; image != nil && len(image.Version) > 0 {
+			if _, err := helper.FindImageFromCloudProfile(cloudProfileConfig, image.Name, image.Version, region, machineTypeInfo); err != nil {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("machine", "image"), fmt.Sprintf("%s:%s", image.Name, image.Version), err.Error()))
+			}
+		}
+
+		if machineTypeInfo != nil && machineTypeInfo.MaxDataDisks != nil && len(worker.DataVolumes) > int(*machineTypeInfo.MaxDataDisks) {
+			allErrs = append(allErrs, field.TooMany(idxPath.Child("dataVolumes"), len(worker.DataVolumes), int(*machineTypeInfo.MaxDataDisks)))
+		}
+
+		// Zones and machine types can only be checked if the cloud profile contains information about the region.
+		if regionInfo == nil {
+			continue
+		}
+
+		regionZones := sets.NewString(regionInfo.Zones...)
+		for j, zone := range worker.Zones {
+			if !regionZones.Has(zone) {
+				allErrs = append(allErrs, field.NotSupported(idxPath.Child("zones").Index(j), zone, regionZones.List()))
+			}
+		}
+
+		if len(regionInfo.MachineTypes) == 0 {
+			continue
+		}
+		machineType, err := helper.FindRegionMachineType(regionInfo.MachineTypes, worker.Machine.Type)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("machine", "type"), worker.Machine.Type, fmt.Sprintf("machine type is not available in region %q", region)))
+			continue
+		}
+		if len(machineType.Zones) == 0 {
+			continue
+		}
+		machineTypeZones := sets.NewString(machineType.Zones...)
+		for j, zone := range worker.Zones {
+			if regionZones.Has(zone) && !machineTypeZones.Has(zone) {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("zones").Index(j), zone, fmt.Sprintf("machine type %q is not available in this zone", worker.Machine.Type)))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// workerChangedAgainstCloudProfile returns true if the given worker is not part of the old workers or if one of its
+// properties which are validated against the cloud profile has changed.
+func workerChangedAgainstCloudProfile(oldWorkers []core.Worker, worker core.Worker) bool {
+	for _, oldWorker := range oldWorkers {
+		if oldWorker.Name != worker.Name {
+			continue
+		}
+		return oldWorker.Machine.Type != worker.Machine.Type ||
+			!apiequality.Semantic.DeepEqual(oldWorker.Machine.Image, worker.Machine.Image) ||
+			!apiequality.Semantic.DeepEqual(oldWorker.Zones, worker.Zones) ||
+			len(oldWorker.DataVolumes) != len(worker.DataVolumes)
+	}
+	return true
+}
+
 func validateVolume(vol *core.Volume, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	if vol.Type == nil {
@@ -15,6 +15,7 @@
 package validation_test
 
 import (
+	apisazure "github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure"
 	. "github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure/validation"
 	"github.com/gardener/gardener/pkg/apis/core"
 
@@ -250,6 +251,163 @@ var _ = Describe("Shoot validation", func() {
 			})
 		})
 	})
+
+	Describe("#ValidateWorkersAgainstCloudProfile", func() {
+		var (
+			region             = "westeurope"
+			urn                = "publisher:offer:sku:1.0.0"
+			workers            []core.Worker
+			cloudProfileConfig *apisazure.CloudProfileConfig
+			fldPath            = field.NewPath("workers")
+		)
+
+		BeforeEach(func() {
+			workers = []core.Worker{
+				{
+					Name: "worker1",
+					Machine: core.Machine{
+						Type:  "Standard_D2s_v3",
+						Image: &core.ShootMachineImage{Name: "ubuntu", Version: "1.0.0"},
+					},
+					Zones: []string{"1", "2"},
+				},
+			}
+			cloudProfileConfig = &apisazure.CloudProfileConfig{
+				MachineImages: []apisazure.MachineImages{
+					{Name: "ubuntu", Versions: []apisazure.MachineImageVersion{{Version: "1.0.0", URN: &urn}}},
+				},
+				Regions: []apisazure.Region{
+					{
+						Name:         region,
+						Zones:        []string{"1", "2", "3"},
+						MachineTypes: []apisazure.RegionMachineType{{Name: "Standard_D2s_v3"}, {Name: "Standard_M8ms", Zones: []string{"1"}}},
+					},
+				},
+			}
+		})
+
+		It("should pass if no cloud profile config is given", func() {
+			Expect(ValidateWorkersAgainstCloudProfile(nil, workers, region, nil, fldPath)).To(BeEmpty())
+		})
+
+		It("should pass because the workers match the region information", func() {
+			Expect(ValidateWorkersAgainstCloudProfile(nil, workers, region, cloudProfileConfig, fldPath)).To(BeEmpty())
+		})
+
+		It("should pass if the cloud profile has no information about the region", func() {
+			cloudProfileConfig.Regions = nil
+			workers[0].Zones = []string{"4"}
+
+			Expect(ValidateWorkersAgainstCloudProfile(nil, workers, region, cloudProfileConfig, fldPath)).To(BeEmpty())
+		})
+
+		It("should forbid an image which cannot be resolved for the region", func() {
+			workers[0].Machine.Image.Version = "2.0.0"
+
+			errorList := ValidateWorkersAgainstCloudProfile(nil, workers, region, cloudProfileConfig, fldPath)
+
+			Expect(errorList).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":  Equal(field.ErrorTypeInvalid),
+				"Field": Equal("workers[0].machine.image"),
+			}))))
+		})
+
+		It("should forbid zones which do not exist in the region", func() {
+			workers[0].Zones = []string{"1", "4"}
+
+			errorList := ValidateWorkersAgainstCloudProfile(nil, workers, region, cloudProfileConfig, fldPath)
+
+			Expect(errorList).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":  Equal(field.ErrorTypeNotSupported),
+				"Field": Equal("workers[0].zones[1]"),
+			}))))
+		})
+
+		It("should forbid machine types which are not available in the region", func() {
+			workers[0].Machine.Type = "Standard_D4s_v3"
+
+			errorList := ValidateWorkersAgainstCloudProfile(nil, workers, region, cloudProfileConfig, fldPath)
+
+			Expect(errorList).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":  Equal(field.ErrorTypeInvalid),
+				"Field": Equal("workers[0].machine.type"),
+			}))))
+		})
+
+		It("should forbid zones in which the machine type is not available", func() {
+			workers[0].Machine.Type = "Standard_M8ms"
+
+			errorList := ValidateWorkersAgainstCloudProfile(nil, workers, region, cloudProfileConfig, fldPath)
+
+			Expect(errorList).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":  Equal(field.ErrorTypeInvalid),
+				"Field": Equal("workers[0].zones[1]"),
+			}))))
+		})
+
+		It("should forbid an image which has no variant for the architecture of the machine type", func() {
+			architecture := "arm64"
+			cloudProfileConfig.MachineTypes = []apisazure.MachineType{{Name: "Standard_D2s_v3", Architecture: &architecture}}
+
+			errorList := ValidateWorkersAgainstCloudProfile(nil, workers, region, cloudProfileConfig, fldPath)
+
+			Expect(errorList).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":  Equal(field.ErrorTypeInvalid),
+				"Field": Equal("workers[0].machine.image"),
+			}))))
+		})
+
+		It("should forbid more data volumes than the machine type supports", func() {
+			maxDataDisks := int32(1)
+			cloudProfileConfig.MachineTypes = []apisazure.MachineType{{Name: "Standard_D2s_v3", MaxDataDisks: &maxDataDisks}}
+			workers[0].DataVolumes = []core.Volume{{Size: "10Gi"}, {Size: "20Gi"}}
+
+			errorList := ValidateWorkersAgainstCloudProfile(nil, workers, region, cloudProfileConfig, fldPath)
+
+			Expect(errorList).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":  Equal(field.ErrorTypeTooMany),
+				"Field": Equal("workers[0].dataVolumes"),
+			}))))
+		})
+
+		It("should not validate workers which are unchanged", func() {
+			workers[0].Zones = []string{"1", "4"}
+			workers[0].Machine.Image.Version = "2.0.0"
+			oldWorkers := copyWorkers(workers)
+
+			Expect(ValidateWorkersAgainstCloudProfile(oldWorkers, workers, region, cloudProfileConfig, fldPath)).To(BeEmpty())
+		})
+
+		It("should validate workers whose machine type changed", func() {
+			oldWorkers := copyWorkers(workers)
+			workers[0].Machine.Type = "Standard_M8ms"
+
+			errorList := ValidateWorkersAgainstCloudProfile(oldWorkers, workers, region, cloudProfileConfig, fldPath)
+
+			Expect(errorList).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":  Equal(field.ErrorTypeInvalid),
+				"Field": Equal("workers[0].zones[1]"),
+			}))))
+		})
+
+		It("should validate workers which are new", func() {
+			oldWorkers := copyWorkers(workers)
+			workers = append(workers, core.Worker{
+				Name: "worker2",
+				Machine: core.Machine{
+					Type:  "Standard_D2s_v3",
+					Image: &core.ShootMachineImage{Name: "ubuntu", Version: "2.0.0"},
+				},
+			})
+
+			errorList := ValidateWorkersAgainstCloudProfile(oldWorkers, workers, region, cloudProfileConfig, fldPath)
+
+			Expect(errorList).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":  Equal(field.ErrorTypeInvalid),
+				"Field": Equal("workers[1].machine.image"),
+			}))))
+		})
+	})
 })
 
 func copyWorkers(workers []core.Worker) []core.Worker {
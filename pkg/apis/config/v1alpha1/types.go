@@ -17,6 +17,7 @@ package v1alpha1
 import (
 	healthcheckconfigv1alpha1 "github.com/gardener/gardener-extensions/pkg/controller/healthcheck/config/v1alpha1"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	componentbaseconfigv1alpha1 "k8s.io/component-base/config/v1alpha1"
@@ -38,6 +39,26 @@ type ControllerConfiguration struct {
 	// HealthCheckConfig is the config for the health check controller
 	// +optional
 	HealthCheckConfig *healthcheckconfigv1alpha1.HealthCheckConfig `json:"healthCheckConfig,omitempty"`
+	// CloudControllerManager contains the defaults for the cloud-controller-managers of the shoots. They are
+	// overridden by the settings in the ControlPlaneConfig of a shoot.
+	// +optional
+	CloudControllerManager *CloudControllerManagerConfig `json:"cloudControllerManager,omitempty"`
+	// KubeAPIServerExposure contains the defaults for the exposure of the kube-apiservers of the shoots. They are
+	// overridden by the settings in the ControlPlaneConfig of a shoot.
+	// +optional
+	KubeAPIServerExposure *KubeAPIServerExposureConfig `json:"kubeAPIServerExposure,omitempty"`
+	// VirtualMachineHealthCheck contains the settings for the health check of the Azure virtual machines of the
+	// workers of the shoots.
+	// +optional
+	VirtualMachineHealthCheck *VirtualMachineHealthCheckConfig `json:"virtualMachineHealthCheck,omitempty"`
+	// KMS contains the settings for the KMS plugins of the kube-apiservers of the shoots which encrypt their secrets
+	// with a key of an Azure Key Vault.
+	// +optional
+	KMS *KMSConfig `json:"kms,omitempty"`
+	// AcrCredentialProvider contains the downloads of the kubelet image credential provider for the Azure Container
+	// Registry which is installed on the worker machines of the shoots as of Kubernetes 1.20.
+	// +optional
+	AcrCredentialProvider *AcrCredentialProviderConfig `json:"acrCredentialProvider,omitempty"`
 }
 
 // ETCD is an etcd configuration.
@@ -64,3 +85,149 @@ type ETCDBackup struct {
 	// +optional
 	Schedule *string `json:"schedule,omitempty"`
 }
+
+// CloudControllerManagerConfig contains the defaults for the cloud-controller-managers of the shoots.
+type CloudControllerManagerConfig struct {
+	// RateLimit contains the defaults for the client side rate limiting of the requests to the Azure API.
+	// +optional
+	RateLimit *CloudProviderRateLimit `json:"rateLimit,omitempty"`
+	// Backoff contains the defaults for the retries of failed requests to the Azure API.
+	// +optional
+	Backoff *CloudProviderBackoff `json:"backoff,omitempty"`
+}
+
+// CloudProviderRateLimit contains the defaults for the client side rate limiting of the requests to the Azure API.
+type CloudProviderRateLimit struct {
+	// QPS is the number of read requests per second.
+	// +optional
+	QPS *float64 `json:"qps,omitempty"`
+	// Bucket is the maximum number of read requests in a burst.
+	// +optional
+	Bucket *int32 `json:"bucket,omitempty"`
+	// QPSWrite is the number of write requests per second.
+	// +optional
+	QPSWrite *float64 `json:"qpsWrite,omitempty"`
+	// BucketWrite is the maximum number of write requests in a burst.
+	// +optional
+	BucketWrite *int32 `json:"bucketWrite,omitempty"`
+}
+
+// CloudProviderBackoff contains the defaults for the retries of failed requests to the Azure API.
+type CloudProviderBackoff struct {
+	// Retries is the number of retries of a failed request.
+	// +optional
+	Retries *int32 `json:"retries,omitempty"`
+	// Exponent is the factor by which the duration between two retries is multiplied.
+	// +optional
+	Exponent *float64 `json:"exponent,omitempty"`
+	// DurationSeconds is the initial duration between two retries in seconds.
+	// +optional
+	DurationSeconds *int32 `json:"durationSeconds,omitempty"`
+	// Jitter is the factor of the duration between two retries which is randomly added to it.
+	// +optional
+	Jitter *float64 `json:"jitter,omitempty"`
+}
+
+// KubeAPIServerExposureConfig contains the defaults for the exposure of the kube-apiservers of the shoots.
+type KubeAPIServerExposureConfig struct {
+	// Internal specifies whether the kube-apiservers are exposed by internal load balancers in the virtual network
+	// of the seed instead of public ones. It is fixed for each shoot when the shoot is created.
+	// +optional
+	Internal *bool `json:"internal,omitempty"`
+	// Subnet is the name of the subnet of the virtual network of the seed in which the internal load balancers are
+	// created. Defaults to the subnet of the nodes of the seed.
+	// +optional
+	Subnet *string `json:"subnet,omitempty"`
+	// PrivateLinkService contains the defaults for the publication of the internal load balancers through Private
+	// Link Services.
+	// +optional
+	PrivateLinkService *PrivateLinkServiceConfig `json:"privateLinkService,omitempty"`
+	// TCPIdleTimeoutInMinutes is the TCP idle timeout of the load balancers in minutes.
+	// +optional
+	TCPIdleTimeoutInMinutes *int32 `json:"tcpIdleTimeoutInMinutes,omitempty"`
+	// PublicIP contains the settings for the reservation of static public IPs for the kube-apiservers which are not
+	// exposed internally.
+	// +optional
+	PublicIP *PublicIPConfig `json:"publicIP,omitempty"`
+}
+
+// PrivateLinkServiceConfig contains the defaults for the publication of the internal load balancers of the
+// kube-apiservers through Private Link Services.
+type PrivateLinkServiceConfig struct {
+	// Enabled specifies whether the internal load balancers are published through Private Link Services.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// Subnet is the name of the subnet of the virtual network of the seed from which the source addresses of the
+	// Private Link Services are allocated. Defaults to the subnet of the internal load balancers.
+	// +optional
+	Subnet *string `json:"subnet,omitempty"`
+	// AllowedSubscriptions are the subscriptions which can create private endpoints for the Private Link Services.
+	// +optional
+	AllowedSubscriptions []string `json:"allowedSubscriptions,omitempty"`
+	// AutoApprovedSubscriptions are the subscriptions whose private endpoints are approved automatically.
+	// +optional
+	AutoApprovedSubscriptions []string `json:"autoApprovedSubscriptions,omitempty"`
+}
+
+// PublicIPConfig contains the settings for the reservation of static public IPs for the kube-apiservers.
+type PublicIPConfig struct {
+	// ResourceGroup is the name of the resource group in the subscription of the seed in which the public IPs are
+	// reserved. The cloud-controller-manager of the seed must be allowed to use the public IPs of this resource group.
+	ResourceGroup string `json:"resourceGroup"`
+	// SKU is the SKU of the public IPs which must match the SKU of the load balancers of the seed. Defaults to Standard.
+	// +optional
+	SKU *string `json:"sku,omitempty"`
+	// SecretRef is a reference to the secret which contains the credentials for the subscription of the seed.
+	SecretRef corev1.SecretReference `json:"secretRef"`
+}
+
+// KMSConfig contains the settings for the KMS plugins of the kube-apiservers of the shoots.
+type KMSConfig struct {
+	// ManagedIdentityClientID is the client id of a user-assigned managed identity which is assigned to the nodes of
+	// the seed and which the KMS plugins use to access the Key Vaults of the shoots. Defaults to the service principals
+	// of the cloud provider secrets of the shoots.
+	// +optional
+	ManagedIdentityClientID *string `json:"managedIdentityClientID,omitempty"`
+}
+
+// AcrCredentialProviderConfig contains the downloads of the kubelet image credential provider for the Azure Container
+// Registry.
+type AcrCredentialProviderConfig struct {
+	// Binaries are the downloads of the binary of the credential provider for the architectures of the worker
+	// machines. If they are not configured, the kubelets pull from the registries with the in-tree acr credentials.
+	// +optional
+	Binaries []AcrCredentialProviderBinary `json:"binaries,omitempty"`
+}
+
+// AcrCredentialProviderBinary is a download of the binary of the acr credential provider for an architecture.
+type AcrCredentialProviderBinary struct {
+	// Architecture is the architecture of the worker machines for which the binary is built, i.e. amd64 or arm64.
+	Architecture string `json:"architecture"`
+	// URL is the url from which the binary is downloaded.
+	URL string `json:"url"`
+	// SHA256 is the hex encoded sha256 checksum of the binary which is verified after the download.
+	SHA256 string `json:"sha256"`
+}
+
+// VirtualMachineHealthCheckConfig contains the settings for the health check which compares the machines of the
+// workers of the shoots with their Azure virtual machines.
+type VirtualMachineHealthCheckConfig struct {
+	// Remediation is the action which is taken for the machines whose virtual machines have failed or have been
+	// deleted. Defaults to None.
+	// +optional
+	Remediation *VirtualMachineRemediation `json:"remediation,omitempty"`
+}
+
+// VirtualMachineRemediation is an action which is taken for a machine whose virtual machine is broken.
+type VirtualMachineRemediation string
+
+const (
+	// VirtualMachineRemediationNone means that the broken virtual machines are only reported.
+	VirtualMachineRemediationNone VirtualMachineRemediation = "None"
+	// VirtualMachineRemediationReplace means that the machines of the broken virtual machines are marked as failed so
+	// that the machine-controller-manager replaces them.
+	VirtualMachineRemediationReplace VirtualMachineRemediation = "Replace"
+	// VirtualMachineRemediationRedeploy means that the failed virtual machines are redeployed to other Azure hosts.
+	// The machines of deleted virtual machines are replaced.
+	VirtualMachineRemediationRedeploy VirtualMachineRemediation = "Redeploy"
+)
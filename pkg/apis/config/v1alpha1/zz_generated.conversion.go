@@ -40,6 +40,56 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*AcrCredentialProviderBinary)(nil), (*config.AcrCredentialProviderBinary)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_AcrCredentialProviderBinary_To_config_AcrCredentialProviderBinary(a.(*AcrCredentialProviderBinary), b.(*config.AcrCredentialProviderBinary), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.AcrCredentialProviderBinary)(nil), (*AcrCredentialProviderBinary)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_AcrCredentialProviderBinary_To_v1alpha1_AcrCredentialProviderBinary(a.(*config.AcrCredentialProviderBinary), b.(*AcrCredentialProviderBinary), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*AcrCredentialProviderConfig)(nil), (*config.AcrCredentialProviderConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_AcrCredentialProviderConfig_To_config_AcrCredentialProviderConfig(a.(*AcrCredentialProviderConfig), b.(*config.AcrCredentialProviderConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.AcrCredentialProviderConfig)(nil), (*AcrCredentialProviderConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_AcrCredentialProviderConfig_To_v1alpha1_AcrCredentialProviderConfig(a.(*config.AcrCredentialProviderConfig), b.(*AcrCredentialProviderConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CloudControllerManagerConfig)(nil), (*config.CloudControllerManagerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_CloudControllerManagerConfig_To_config_CloudControllerManagerConfig(a.(*CloudControllerManagerConfig), b.(*config.CloudControllerManagerConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.CloudControllerManagerConfig)(nil), (*CloudControllerManagerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_CloudControllerManagerConfig_To_v1alpha1_CloudControllerManagerConfig(a.(*config.CloudControllerManagerConfig), b.(*CloudControllerManagerConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CloudProviderBackoff)(nil), (*config.CloudProviderBackoff)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_CloudProviderBackoff_To_config_CloudProviderBackoff(a.(*CloudProviderBackoff), b.(*config.CloudProviderBackoff), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.CloudProviderBackoff)(nil), (*CloudProviderBackoff)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_CloudProviderBackoff_To_v1alpha1_CloudProviderBackoff(a.(*config.CloudProviderBackoff), b.(*CloudProviderBackoff), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CloudProviderRateLimit)(nil), (*config.CloudProviderRateLimit)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_CloudProviderRateLimit_To_config_CloudProviderRateLimit(a.(*CloudProviderRateLimit), b.(*config.CloudProviderRateLimit), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.CloudProviderRateLimit)(nil), (*CloudProviderRateLimit)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_CloudProviderRateLimit_To_v1alpha1_CloudProviderRateLimit(a.(*config.CloudProviderRateLimit), b.(*CloudProviderRateLimit), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ControllerConfiguration)(nil), (*config.ControllerConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_ControllerConfiguration_To_config_ControllerConfiguration(a.(*ControllerConfiguration), b.(*config.ControllerConfiguration), scope)
 	}); err != nil {
@@ -80,15 +130,188 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*KMSConfig)(nil), (*config.KMSConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_KMSConfig_To_config_KMSConfig(a.(*KMSConfig), b.(*config.KMSConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.KMSConfig)(nil), (*KMSConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_KMSConfig_To_v1alpha1_KMSConfig(a.(*config.KMSConfig), b.(*KMSConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*KubeAPIServerExposureConfig)(nil), (*config.KubeAPIServerExposureConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_KubeAPIServerExposureConfig_To_config_KubeAPIServerExposureConfig(a.(*KubeAPIServerExposureConfig), b.(*config.KubeAPIServerExposureConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.KubeAPIServerExposureConfig)(nil), (*KubeAPIServerExposureConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_KubeAPIServerExposureConfig_To_v1alpha1_KubeAPIServerExposureConfig(a.(*config.KubeAPIServerExposureConfig), b.(*KubeAPIServerExposureConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PrivateLinkServiceConfig)(nil), (*config.PrivateLinkServiceConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_PrivateLinkServiceConfig_To_config_PrivateLinkServiceConfig(a.(*PrivateLinkServiceConfig), b.(*config.PrivateLinkServiceConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.PrivateLinkServiceConfig)(nil), (*PrivateLinkServiceConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_PrivateLinkServiceConfig_To_v1alpha1_PrivateLinkServiceConfig(a.(*config.PrivateLinkServiceConfig), b.(*PrivateLinkServiceConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PublicIPConfig)(nil), (*config.PublicIPConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_PublicIPConfig_To_config_PublicIPConfig(a.(*PublicIPConfig), b.(*config.PublicIPConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.PublicIPConfig)(nil), (*PublicIPConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_PublicIPConfig_To_v1alpha1_PublicIPConfig(a.(*config.PublicIPConfig), b.(*PublicIPConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*VirtualMachineHealthCheckConfig)(nil), (*config.VirtualMachineHealthCheckConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_VirtualMachineHealthCheckConfig_To_config_VirtualMachineHealthCheckConfig(a.(*VirtualMachineHealthCheckConfig), b.(*config.VirtualMachineHealthCheckConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.VirtualMachineHealthCheckConfig)(nil), (*VirtualMachineHealthCheckConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_VirtualMachineHealthCheckConfig_To_v1alpha1_VirtualMachineHealthCheckConfig(a.(*config.VirtualMachineHealthCheckConfig), b.(*VirtualMachineHealthCheckConfig), scope)
+	}); err != nil {
+		return err
+	}
+	return nil
+}
+
+func autoConvert_v1alpha1_AcrCredentialProviderBinary_To_config_AcrCredentialProviderBinary(in *AcrCredentialProviderBinary, out *config.AcrCredentialProviderBinary, s conversion.Scope) error {
+	out.Architecture = in.Architecture
+	out.URL = in.URL
+	out.SHA256 = in.SHA256
+	return nil
+}
+
+// Convert_v1alpha1_AcrCredentialProviderBinary_To_config_AcrCredentialProviderBinary is an autogenerated conversion function.
+func Convert_v1alpha1_AcrCredentialProviderBinary_To_config_AcrCredentialProviderBinary(in *AcrCredentialProviderBinary, out *config.AcrCredentialProviderBinary, s conversion.Scope) error {
+	return autoConvert_v1alpha1_AcrCredentialProviderBinary_To_config_AcrCredentialProviderBinary(in, out, s)
+}
+
+func autoConvert_config_AcrCredentialProviderBinary_To_v1alpha1_AcrCredentialProviderBinary(in *config.AcrCredentialProviderBinary, out *AcrCredentialProviderBinary, s conversion.Scope) error {
+	out.Architecture = in.Architecture
+	out.URL = in.URL
+	out.SHA256 = in.SHA256
+	return nil
+}
+
+// Convert_config_AcrCredentialProviderBinary_To_v1alpha1_AcrCredentialProviderBinary is an autogenerated conversion function.
+func Convert_config_AcrCredentialProviderBinary_To_v1alpha1_AcrCredentialProviderBinary(in *config.AcrCredentialProviderBinary, out *AcrCredentialProviderBinary, s conversion.Scope) error {
+	return autoConvert_config_AcrCredentialProviderBinary_To_v1alpha1_AcrCredentialProviderBinary(in, out, s)
+}
+
+func autoConvert_v1alpha1_AcrCredentialProviderConfig_To_config_AcrCredentialProviderConfig(in *AcrCredentialProviderConfig, out *config.AcrCredentialProviderConfig, s conversion.Scope) error {
+	out.Binaries = *(*[]config.AcrCredentialProviderBinary)(unsafe.Pointer(&in.Binaries))
+	return nil
+}
+
+// Convert_v1alpha1_AcrCredentialProviderConfig_To_config_AcrCredentialProviderConfig is an autogenerated conversion function.
+func Convert_v1alpha1_AcrCredentialProviderConfig_To_config_AcrCredentialProviderConfig(in *AcrCredentialProviderConfig, out *config.AcrCredentialProviderConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_AcrCredentialProviderConfig_To_config_AcrCredentialProviderConfig(in, out, s)
+}
+
+func autoConvert_config_AcrCredentialProviderConfig_To_v1alpha1_AcrCredentialProviderConfig(in *config.AcrCredentialProviderConfig, out *AcrCredentialProviderConfig, s conversion.Scope) error {
+	out.Binaries = *(*[]AcrCredentialProviderBinary)(unsafe.Pointer(&in.Binaries))
+	return nil
+}
+
+// Convert_config_AcrCredentialProviderConfig_To_v1alpha1_AcrCredentialProviderConfig is an autogenerated conversion function.
+func Convert_config_AcrCredentialProviderConfig_To_v1alpha1_AcrCredentialProviderConfig(in *config.AcrCredentialProviderConfig, out *AcrCredentialProviderConfig, s conversion.Scope) error {
+	return autoConvert_config_AcrCredentialProviderConfig_To_v1alpha1_AcrCredentialProviderConfig(in, out, s)
+}
+
+func autoConvert_v1alpha1_CloudControllerManagerConfig_To_config_CloudControllerManagerConfig(in *CloudControllerManagerConfig, out *config.CloudControllerManagerConfig, s conversion.Scope) error {
+	out.RateLimit = (*config.CloudProviderRateLimit)(unsafe.Pointer(in.RateLimit))
+	out.Backoff = (*config.CloudProviderBackoff)(unsafe.Pointer(in.Backoff))
+	return nil
+}
+
+// Convert_v1alpha1_CloudControllerManagerConfig_To_config_CloudControllerManagerConfig is an autogenerated conversion function.
+func Convert_v1alpha1_CloudControllerManagerConfig_To_config_CloudControllerManagerConfig(in *CloudControllerManagerConfig, out *config.CloudControllerManagerConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_CloudControllerManagerConfig_To_config_CloudControllerManagerConfig(in, out, s)
+}
+
+func autoConvert_config_CloudControllerManagerConfig_To_v1alpha1_CloudControllerManagerConfig(in *config.CloudControllerManagerConfig, out *CloudControllerManagerConfig, s conversion.Scope) error {
+	out.RateLimit = (*CloudProviderRateLimit)(unsafe.Pointer(in.RateLimit))
+	out.Backoff = (*CloudProviderBackoff)(unsafe.Pointer(in.Backoff))
+	return nil
+}
+
+// Convert_config_CloudControllerManagerConfig_To_v1alpha1_CloudControllerManagerConfig is an autogenerated conversion function.
+func Convert_config_CloudControllerManagerConfig_To_v1alpha1_CloudControllerManagerConfig(in *config.CloudControllerManagerConfig, out *CloudControllerManagerConfig, s conversion.Scope) error {
+	return autoConvert_config_CloudControllerManagerConfig_To_v1alpha1_CloudControllerManagerConfig(in, out, s)
+}
+
+func autoConvert_v1alpha1_CloudProviderBackoff_To_config_CloudProviderBackoff(in *CloudProviderBackoff, out *config.CloudProviderBackoff, s conversion.Scope) error {
+	out.Retries = (*int32)(unsafe.Pointer(in.Retries))
+	out.Exponent = (*float64)(unsafe.Pointer(in.Exponent))
+	out.DurationSeconds = (*int32)(unsafe.Pointer(in.DurationSeconds))
+	out.Jitter = (*float64)(unsafe.Pointer(in.Jitter))
+	return nil
+}
+
+// Convert_v1alpha1_CloudProviderBackoff_To_config_CloudProviderBackoff is an autogenerated conversion function.
+func Convert_v1alpha1_CloudProviderBackoff_To_config_CloudProviderBackoff(in *CloudProviderBackoff, out *config.CloudProviderBackoff, s conversion.Scope) error {
+	return autoConvert_v1alpha1_CloudProviderBackoff_To_config_CloudProviderBackoff(in, out, s)
+}
+
+func autoConvert_config_CloudProviderBackoff_To_v1alpha1_CloudProviderBackoff(in *config.CloudProviderBackoff, out *CloudProviderBackoff, s conversion.Scope) error {
+	out.Retries = (*int32)(unsafe.Pointer(in.Retries))
+	out.Exponent = (*float64)(unsafe.Pointer(in.Exponent))
+	out.DurationSeconds = (*int32)(unsafe.Pointer(in.DurationSeconds))
+	out.Jitter = (*float64)(unsafe.Pointer(in.Jitter))
+	return nil
+}
+
+// Convert_config_CloudProviderBackoff_To_v1alpha1_CloudProviderBackoff is an autogenerated conversion function.
+func Convert_config_CloudProviderBackoff_To_v1alpha1_CloudProviderBackoff(in *config.CloudProviderBackoff, out *CloudProviderBackoff, s conversion.Scope) error {
+	return autoConvert_config_CloudProviderBackoff_To_v1alpha1_CloudProviderBackoff(in, out, s)
+}
+
+func autoConvert_v1alpha1_CloudProviderRateLimit_To_config_CloudProviderRateLimit(in *CloudProviderRateLimit, out *config.CloudProviderRateLimit, s conversion.Scope) error {
+	out.QPS = (*float64)(unsafe.Pointer(in.QPS))
+	out.Bucket = (*int32)(unsafe.Pointer(in.Bucket))
+	out.QPSWrite = (*float64)(unsafe.Pointer(in.QPSWrite))
+	out.BucketWrite = (*int32)(unsafe.Pointer(in.BucketWrite))
+	return nil
+}
+
+// Convert_v1alpha1_CloudProviderRateLimit_To_config_CloudProviderRateLimit is an autogenerated conversion function.
+func Convert_v1alpha1_CloudProviderRateLimit_To_config_CloudProviderRateLimit(in *CloudProviderRateLimit, out *config.CloudProviderRateLimit, s conversion.Scope) error {
+	return autoConvert_v1alpha1_CloudProviderRateLimit_To_config_CloudProviderRateLimit(in, out, s)
+}
+
+func autoConvert_config_CloudProviderRateLimit_To_v1alpha1_CloudProviderRateLimit(in *config.CloudProviderRateLimit, out *CloudProviderRateLimit, s conversion.Scope) error {
+	out.QPS = (*float64)(unsafe.Pointer(in.QPS))
+	out.Bucket = (*int32)(unsafe.Pointer(in.Bucket))
+	out.QPSWrite = (*float64)(unsafe.Pointer(in.QPSWrite))
+	out.BucketWrite = (*int32)(unsafe.Pointer(in.BucketWrite))
 	return nil
 }
 
+// Convert_config_CloudProviderRateLimit_To_v1alpha1_CloudProviderRateLimit is an autogenerated conversion function.
+func Convert_config_CloudProviderRateLimit_To_v1alpha1_CloudProviderRateLimit(in *config.CloudProviderRateLimit, out *CloudProviderRateLimit, s conversion.Scope) error {
+	return autoConvert_config_CloudProviderRateLimit_To_v1alpha1_CloudProviderRateLimit(in, out, s)
+}
+
 func autoConvert_v1alpha1_ControllerConfiguration_To_config_ControllerConfiguration(in *ControllerConfiguration, out *config.ControllerConfiguration, s conversion.Scope) error {
 	out.ClientConnection = (*componentbaseconfig.ClientConnectionConfiguration)(unsafe.Pointer(in.ClientConnection))
 	if err := Convert_v1alpha1_ETCD_To_config_ETCD(&in.ETCD, &out.ETCD, s); err != nil {
 		return err
 	}
 	out.HealthCheckConfig = (*healthcheckconfig.HealthCheckConfig)(unsafe.Pointer(in.HealthCheckConfig))
+	out.CloudControllerManager = (*config.CloudControllerManagerConfig)(unsafe.Pointer(in.CloudControllerManager))
+	out.KubeAPIServerExposure = (*config.KubeAPIServerExposureConfig)(unsafe.Pointer(in.KubeAPIServerExposure))
+	out.VirtualMachineHealthCheck = (*config.VirtualMachineHealthCheckConfig)(unsafe.Pointer(in.VirtualMachineHealthCheck))
+	out.KMS = (*config.KMSConfig)(unsafe.Pointer(in.KMS))
+	out.AcrCredentialProvider = (*config.AcrCredentialProviderConfig)(unsafe.Pointer(in.AcrCredentialProvider))
 	return nil
 }
 
@@ -103,6 +326,11 @@ func autoConvert_config_ControllerConfiguration_To_v1alpha1_ControllerConfigurat
 		return err
 	}
 	out.HealthCheckConfig = (*healthcheckconfigv1alpha1.HealthCheckConfig)(unsafe.Pointer(in.HealthCheckConfig))
+	out.CloudControllerManager = (*CloudControllerManagerConfig)(unsafe.Pointer(in.CloudControllerManager))
+	out.KubeAPIServerExposure = (*KubeAPIServerExposureConfig)(unsafe.Pointer(in.KubeAPIServerExposure))
+	out.VirtualMachineHealthCheck = (*VirtualMachineHealthCheckConfig)(unsafe.Pointer(in.VirtualMachineHealthCheck))
+	out.KMS = (*KMSConfig)(unsafe.Pointer(in.KMS))
+	out.AcrCredentialProvider = (*AcrCredentialProviderConfig)(unsafe.Pointer(in.AcrCredentialProvider))
 	return nil
 }
 
@@ -182,3 +410,121 @@ func autoConvert_config_ETCDStorage_To_v1alpha1_ETCDStorage(in *config.ETCDStora
 func Convert_config_ETCDStorage_To_v1alpha1_ETCDStorage(in *config.ETCDStorage, out *ETCDStorage, s conversion.Scope) error {
 	return autoConvert_config_ETCDStorage_To_v1alpha1_ETCDStorage(in, out, s)
 }
+
+func autoConvert_v1alpha1_KMSConfig_To_config_KMSConfig(in *KMSConfig, out *config.KMSConfig, s conversion.Scope) error {
+	out.ManagedIdentityClientID = (*string)(unsafe.Pointer(in.ManagedIdentityClientID))
+	return nil
+}
+
+// Convert_v1alpha1_KMSConfig_To_config_KMSConfig is an autogenerated conversion function.
+func Convert_v1alpha1_KMSConfig_To_config_KMSConfig(in *KMSConfig, out *config.KMSConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_KMSConfig_To_config_KMSConfig(in, out, s)
+}
+
+func autoConvert_config_KMSConfig_To_v1alpha1_KMSConfig(in *config.KMSConfig, out *KMSConfig, s conversion.Scope) error {
+	out.ManagedIdentityClientID = (*string)(unsafe.Pointer(in.ManagedIdentityClientID))
+	return nil
+}
+
+// Convert_config_KMSConfig_To_v1alpha1_KMSConfig is an autogenerated conversion function.
+func Convert_config_KMSConfig_To_v1alpha1_KMSConfig(in *config.KMSConfig, out *KMSConfig, s conversion.Scope) error {
+	return autoConvert_config_KMSConfig_To_v1alpha1_KMSConfig(in, out, s)
+}
+
+func autoConvert_v1alpha1_KubeAPIServerExposureConfig_To_config_KubeAPIServerExposureConfig(in *KubeAPIServerExposureConfig, out *config.KubeAPIServerExposureConfig, s conversion.Scope) error {
+	out.Internal = (*bool)(unsafe.Pointer(in.Internal))
+	out.Subnet = (*string)(unsafe.Pointer(in.Subnet))
+	out.PrivateLinkService = (*config.PrivateLinkServiceConfig)(unsafe.Pointer(in.PrivateLinkService))
+	out.TCPIdleTimeoutInMinutes = (*int32)(unsafe.Pointer(in.TCPIdleTimeoutInMinutes))
+	out.PublicIP = (*config.PublicIPConfig)(unsafe.Pointer(in.PublicIP))
+	return nil
+}
+
+// Convert_v1alpha1_KubeAPIServerExposureConfig_To_config_KubeAPIServerExposureConfig is an autogenerated conversion function.
+func Convert_v1alpha1_KubeAPIServerExposureConfig_To_config_KubeAPIServerExposureConfig(in *KubeAPIServerExposureConfig, out *config.KubeAPIServerExposureConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_KubeAPIServerExposureConfig_To_config_KubeAPIServerExposureConfig(in, out, s)
+}
+
+func autoConvert_config_KubeAPIServerExposureConfig_To_v1alpha1_KubeAPIServerExposureConfig(in *config.KubeAPIServerExposureConfig, out *KubeAPIServerExposureConfig, s conversion.Scope) error {
+	out.Internal = (*bool)(unsafe.Pointer(in.Internal))
+	out.Subnet = (*string)(unsafe.Pointer(in.Subnet))
+	out.PrivateLinkService = (*PrivateLinkServiceConfig)(unsafe.Pointer(in.PrivateLinkService))
+	out.TCPIdleTimeoutInMinutes = (*int32)(unsafe.Pointer(in.TCPIdleTimeoutInMinutes))
+	out.PublicIP = (*PublicIPConfig)(unsafe.Pointer(in.PublicIP))
+	return nil
+}
+
+// Convert_config_KubeAPIServerExposureConfig_To_v1alpha1_KubeAPIServerExposureConfig is an autogenerated conversion function.
+func Convert_config_KubeAPIServerExposureConfig_To_v1alpha1_KubeAPIServerExposureConfig(in *config.KubeAPIServerExposureConfig, out *KubeAPIServerExposureConfig, s conversion.Scope) error {
+	return autoConvert_config_KubeAPIServerExposureConfig_To_v1alpha1_KubeAPIServerExposureConfig(in, out, s)
+}
+
+func autoConvert_v1alpha1_PrivateLinkServiceConfig_To_config_PrivateLinkServiceConfig(in *PrivateLinkServiceConfig, out *config.PrivateLinkServiceConfig, s conversion.Scope) error {
+	out.Enabled = (*bool)(unsafe.Pointer(in.Enabled))
+	out.Subnet = (*string)(unsafe.Pointer(in.Subnet))
+	out.AllowedSubscriptions = *(*[]string)(unsafe.Pointer(&in.AllowedSubscriptions))
+	out.AutoApprovedSubscriptions = *(*[]string)(unsafe.Pointer(&in.AutoApprovedSubscriptions))
+	return nil
+}
+
+// Convert_v1alpha1_PrivateLinkServiceConfig_To_config_PrivateLinkServiceConfig is an autogenerated conversion function.
+func Convert_v1alpha1_PrivateLinkServiceConfig_To_config_PrivateLinkServiceConfig(in *PrivateLinkServiceConfig, out *config.PrivateLinkServiceConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_PrivateLinkServiceConfig_To_config_PrivateLinkServiceConfig(in, out, s)
+}
+
+func autoConvert_config_PrivateLinkServiceConfig_To_v1alpha1_PrivateLinkServiceConfig(in *config.PrivateLinkServiceConfig, out *PrivateLinkServiceConfig, s conversion.Scope) error {
+	out.Enabled = (*bool)(unsafe.Pointer(in.Enabled))
+	out.Subnet = (*string)(unsafe.Pointer(in.Subnet))
+	out.AllowedSubscriptions = *(*[]string)(unsafe.Pointer(&in.AllowedSubscriptions))
+	out.AutoApprovedSubscriptions = *(*[]string)(unsafe.Pointer(&in.AutoApprovedSubscriptions))
+	return nil
+}
+
+// Convert_config_PrivateLinkServiceConfig_To_v1alpha1_PrivateLinkServiceConfig is an autogenerated conversion function.
+func Convert_config_PrivateLinkServiceConfig_To_v1alpha1_PrivateLinkServiceConfig(in *config.PrivateLinkServiceConfig, out *PrivateLinkServiceConfig, s conversion.Scope) error {
+	return autoConvert_config_PrivateLinkServiceConfig_To_v1alpha1_PrivateLinkServiceConfig(in, out, s)
+}
+
+func autoConvert_v1alpha1_PublicIPConfig_To_config_PublicIPConfig(in *PublicIPConfig, out *config.PublicIPConfig, s conversion.Scope) error {
+	out.ResourceGroup = in.ResourceGroup
+	out.SKU = (*string)(unsafe.Pointer(in.SKU))
+	out.SecretRef = in.SecretRef
+	return nil
+}
+
+// Convert_v1alpha1_PublicIPConfig_To_config_PublicIPConfig is an autogenerated conversion function.
+func Convert_v1alpha1_PublicIPConfig_To_config_PublicIPConfig(in *PublicIPConfig, out *config.PublicIPConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_PublicIPConfig_To_config_PublicIPConfig(in, out, s)
+}
+
+func autoConvert_config_PublicIPConfig_To_v1alpha1_PublicIPConfig(in *config.PublicIPConfig, out *PublicIPConfig, s conversion.Scope) error {
+	out.ResourceGroup = in.ResourceGroup
+	out.SKU = (*string)(unsafe.Pointer(in.SKU))
+	out.SecretRef = in.SecretRef
+	return nil
+}
+
+// Convert_config_PublicIPConfig_To_v1alpha1_PublicIPConfig is an autogenerated conversion function.
+func Convert_config_PublicIPConfig_To_v1alpha1_PublicIPConfig(in *config.PublicIPConfig, out *PublicIPConfig, s conversion.Scope) error {
+	return autoConvert_config_PublicIPConfig_To_v1alpha1_PublicIPConfig(in, out, s)
+}
+
+func autoConvert_v1alpha1_VirtualMachineHealthCheckConfig_To_config_VirtualMachineHealthCheckConfig(in *VirtualMachineHealthCheckConfig, out *config.VirtualMachineHealthCheckConfig, s conversion.Scope) error {
+	out.Remediation = (*config.VirtualMachineRemediation)(unsafe.Pointer(in.Remediation))
+	return nil
+}
+
+// Convert_v1alpha1_VirtualMachineHealthCheckConfig_To_config_VirtualMachineHealthCheckConfig is an autogenerated conversion function.
+func Convert_v1alpha1_VirtualMachineHealthCheckConfig_To_config_VirtualMachineHealthCheckConfig(in *VirtualMachineHealthCheckConfig, out *config.VirtualMachineHealthCheckConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_VirtualMachineHealthCheckConfig_To_config_VirtualMachineHealthCheckConfig(in, out, s)
+}
+
+func autoConvert_config_VirtualMachineHealthCheckConfig_To_v1alpha1_VirtualMachineHealthCheckConfig(in *config.VirtualMachineHealthCheckConfig, out *VirtualMachineHealthCheckConfig, s conversion.Scope) error {
+	out.Remediation = (*VirtualMachineRemediation)(unsafe.Pointer(in.Remediation))
+	return nil
+}
+
+// Convert_config_VirtualMachineHealthCheckConfig_To_v1alpha1_VirtualMachineHealthCheckConfig is an autogenerated conversion function.
+func Convert_config_VirtualMachineHealthCheckConfig_To_v1alpha1_VirtualMachineHealthCheckConfig(in *config.VirtualMachineHealthCheckConfig, out *VirtualMachineHealthCheckConfig, s conversion.Scope) error {
+	return autoConvert_config_VirtualMachineHealthCheckConfig_To_v1alpha1_VirtualMachineHealthCheckConfig(in, out, s)
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure/validation"
+	"github.com/gardener/gardener-extension-provider-azure/pkg/apis/config"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var supportedVirtualMachineRemediations = sets.NewString(
+	string(config.VirtualMachineRemediationNone),
+	string(config.VirtualMachineRemediationReplace),
+	string(config.VirtualMachineRemediationRedeploy),
+)
+
+var (
+	supportedAcrCredentialProviderArchitectures = sets.NewString("amd64", "arm64")
+	sha256Regex                                 = regexp.MustCompile(`^[0-9a-f]{64}$`)
+)
+
+// ValidateControllerConfiguration validates the given ControllerConfiguration.
+func ValidateControllerConfiguration(cfg *config.ControllerConfiguration) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if cfg.CloudControllerManager != nil {
+		allErrs = append(allErrs, validateCloudControllerManagerConfig(cfg.CloudControllerManager, field.NewPath("cloudControllerManager"))...)
+	}
+
+	if cfg.KMS != nil && cfg.KMS.ManagedIdentityClientID != nil && len(*cfg.KMS.ManagedIdentityClientID) == 0 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("kms", "managedIdentityClientID"), *cfg.KMS.ManagedIdentityClientID, "client id of the managed identity must not be empty"))
+	}
+
+	if cfg.AcrCredentialProvider != nil {
+		allErrs = append(allErrs, validateAcrCredentialProviderConfig(cfg.AcrCredentialProvider, field.NewPath("acrCredentialProvider"))...)
+	}
+
+	if cfg.VirtualMachineHealthCheck != nil && cfg.VirtualMachineHealthCheck.Remediation != nil {
+		if remediation := *cfg.VirtualMachineHealthCheck.Remediation; !supportedVirtualMachineRemediations.Has(string(remediation)) {
+			allErrs = append(allErrs, field.NotSupported(field.NewPath("virtualMachineHealthCheck", "remediation"), remediation, supportedVirtualMachineRemediations.List()))
+		}
+	}
+
+	return allErrs
+}
+
+// validateAcrCredentialProviderConfig validates the downloads of the acr credential provider, which are installed by a
+// shell script on the worker machines.
+func validateAcrCredentialProviderConfig(cfg *config.AcrCredentialProviderConfig, fldPath *field.Path) field.ErrorList {
+	var (
+		allErrs       = field.ErrorList{}
+		architectures = sets.NewString()
+	)
+
+	for i, binary := range cfg.Binaries {
+		idxPath := fldPath.Child("binaries").Index(i)
+
+		if !supportedAcrCredentialProviderArchitectures.Has(binary.Architecture) {
+			allErrs = append(allErrs, field.NotSupported(idxPath.Child("architecture"), binary.Architecture, supportedAcrCredentialProviderArchitectures.List()))
+		} else if architectures.Has(binary.Architecture) {
+			allErrs = append(allErrs, field.Duplicate(idxPath.Child("architecture"), binary.Architecture))
+		}
+		architectures.Insert(binary.Architecture)
+
+		if u, err := url.Parse(binary.URL); err != nil || u.Scheme != "https" || len(u.Host) == 0 || strings.Contains(binary.URL, "'") {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("url"), binary.URL, "must be an https url without single quotes"))
+		}
+
+		if !sha256Regex.MatchString(binary.SHA256) {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("sha256"), binary.SHA256, "must be a lower case hex encoded sha256 checksum"))
+		}
+	}
+
+	return allErrs
+}
+
+// validateCloudControllerManagerConfig validates the rate limit and backoff defaults with the same ranges as the
+// settings in the ControlPlaneConfigs of the shoots, which override them.
+func validateCloudControllerManagerConfig(cfg *config.CloudControllerManagerConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if rateLimit := cfg.RateLimit; rateLimit != nil {
+		allErrs = append(allErrs, validation.ValidateCloudProviderRateLimit(rateLimit.QPS, rateLimit.Bucket, rateLimit.QPSWrite, rateLimit.BucketWrite, fldPath.Child("rateLimit"))...)
+	}
+
+	if backoff := cfg.Backoff; backoff != nil {
+		allErrs = append(allErrs, validation.ValidateCloudProviderBackoff(backoff.Retries, backoff.Exponent, backoff.DurationSeconds, backoff.Jitter, fldPath.Child("backoff"))...)
+	}
+
+	return allErrs
+}
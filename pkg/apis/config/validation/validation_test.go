@@ -0,0 +1,173 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation_test
+
+import (
+	"strings"
+
+	"github.com/gardener/gardener-extension-provider-azure/pkg/apis/config"
+	. "github.com/gardener/gardener-extension-provider-azure/pkg/apis/config/validation"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/pointer"
+)
+
+var _ = Describe("ControllerConfiguration validation", func() {
+	var cfg *config.ControllerConfiguration
+
+	BeforeEach(func() {
+		cfg = &config.ControllerConfiguration{}
+	})
+
+	Describe("#ValidateControllerConfiguration", func() {
+		It("should allow an empty configuration", func() {
+			Expect(ValidateControllerConfiguration(cfg)).To(BeEmpty())
+		})
+
+		It("should allow valid cloud-controller-manager defaults", func() {
+			cfg.CloudControllerManager = &config.CloudControllerManagerConfig{
+				RateLimit: &config.CloudProviderRateLimit{
+					QPS:    float64Ptr(10),
+					Bucket: pointer.Int32Ptr(100),
+				},
+				Backoff: &config.CloudProviderBackoff{
+					Retries:         pointer.Int32Ptr(6),
+					Exponent:        float64Ptr(1.5),
+					DurationSeconds: pointer.Int32Ptr(5),
+					Jitter:          float64Ptr(1),
+				},
+			}
+
+			Expect(ValidateControllerConfiguration(cfg)).To(BeEmpty())
+		})
+
+		It("should forbid invalid cloud-controller-manager defaults", func() {
+			cfg.CloudControllerManager = &config.CloudControllerManagerConfig{
+				RateLimit: &config.CloudProviderRateLimit{
+					QPSWrite:    float64Ptr(0),
+					BucketWrite: pointer.Int32Ptr(-1),
+				},
+				Backoff: &config.CloudProviderBackoff{
+					Retries: pointer.Int32Ptr(21),
+					Jitter:  float64Ptr(0),
+				},
+			}
+
+			Expect(ValidateControllerConfiguration(cfg)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("cloudControllerManager.rateLimit.qpsWrite"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("cloudControllerManager.rateLimit.bucketWrite"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("cloudControllerManager.backoff.retries"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("cloudControllerManager.backoff.jitter"),
+				})),
+			))
+		})
+
+		It("should forbid an empty client id of the managed identity of the KMS plugins", func() {
+			cfg.KMS = &config.KMSConfig{ManagedIdentityClientID: pointer.StringPtr("")}
+
+			Expect(ValidateControllerConfiguration(cfg)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("kms.managedIdentityClientID"),
+				})),
+			))
+		})
+
+		It("should allow a supported remediation of the virtual machine health check", func() {
+			remediation := config.VirtualMachineRemediationRedeploy
+			cfg.VirtualMachineHealthCheck = &config.VirtualMachineHealthCheckConfig{Remediation: &remediation}
+
+			Expect(ValidateControllerConfiguration(cfg)).To(BeEmpty())
+		})
+
+		It("should forbid an unsupported remediation of the virtual machine health check", func() {
+			remediation := config.VirtualMachineRemediation("Restart")
+			cfg.VirtualMachineHealthCheck = &config.VirtualMachineHealthCheckConfig{Remediation: &remediation}
+
+			Expect(ValidateControllerConfiguration(cfg)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeNotSupported),
+					"Field": Equal("virtualMachineHealthCheck.remediation"),
+				})),
+			))
+		})
+
+		It("should allow valid downloads of the acr credential provider", func() {
+			cfg.AcrCredentialProvider = &config.AcrCredentialProviderConfig{
+				Binaries: []config.AcrCredentialProviderBinary{
+					{Architecture: "amd64", URL: "https://example.com/acr-credential-provider-amd64", SHA256: strings.Repeat("a", 64)},
+					{Architecture: "arm64", URL: "https://example.com/acr-credential-provider-arm64", SHA256: strings.Repeat("b", 64)},
+				},
+			}
+
+			Expect(ValidateControllerConfiguration(cfg)).To(BeEmpty())
+		})
+
+		It("should forbid invalid downloads of the acr credential provider", func() {
+			cfg.AcrCredentialProvider = &config.AcrCredentialProviderConfig{
+				Binaries: []config.AcrCredentialProviderBinary{
+					{Architecture: "amd64", URL: "http://example.com/acr-credential-provider", SHA256: strings.Repeat("a", 64)},
+					{Architecture: "amd64", URL: "https://example.com/acr-credential-provider", SHA256: "abc"},
+					{Architecture: "s390x", URL: "https://example.com/'acr-credential-provider'", SHA256: strings.Repeat("A", 64)},
+				},
+			}
+
+			Expect(ValidateControllerConfiguration(cfg)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("acrCredentialProvider.binaries[0].url"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeDuplicate),
+					"Field": Equal("acrCredentialProvider.binaries[1].architecture"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("acrCredentialProvider.binaries[1].sha256"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeNotSupported),
+					"Field": Equal("acrCredentialProvider.binaries[2].architecture"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("acrCredentialProvider.binaries[2].url"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("acrCredentialProvider.binaries[2].sha256"),
+				})),
+			))
+		})
+	})
+})
+
+func float64Ptr(f float64) *float64 {
+	return &f
+}
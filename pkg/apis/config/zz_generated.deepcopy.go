@@ -26,6 +26,141 @@ import (
 	componentbaseconfig "k8s.io/component-base/config"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AcrCredentialProviderBinary) DeepCopyInto(out *AcrCredentialProviderBinary) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AcrCredentialProviderBinary.
+func (in *AcrCredentialProviderBinary) DeepCopy() *AcrCredentialProviderBinary {
+	if in == nil {
+		return nil
+	}
+	out := new(AcrCredentialProviderBinary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AcrCredentialProviderConfig) DeepCopyInto(out *AcrCredentialProviderConfig) {
+	*out = *in
+	if in.Binaries != nil {
+		in, out := &in.Binaries, &out.Binaries
+		*out = make([]AcrCredentialProviderBinary, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AcrCredentialProviderConfig.
+func (in *AcrCredentialProviderConfig) DeepCopy() *AcrCredentialProviderConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AcrCredentialProviderConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudControllerManagerConfig) DeepCopyInto(out *CloudControllerManagerConfig) {
+	*out = *in
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(CloudProviderRateLimit)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Backoff != nil {
+		in, out := &in.Backoff, &out.Backoff
+		*out = new(CloudProviderBackoff)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudControllerManagerConfig.
+func (in *CloudControllerManagerConfig) DeepCopy() *CloudControllerManagerConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudControllerManagerConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudProviderBackoff) DeepCopyInto(out *CloudProviderBackoff) {
+	*out = *in
+	if in.Retries != nil {
+		in, out := &in.Retries, &out.Retries
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Exponent != nil {
+		in, out := &in.Exponent, &out.Exponent
+		*out = new(float64)
+		**out = **in
+	}
+	if in.DurationSeconds != nil {
+		in, out := &in.DurationSeconds, &out.DurationSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Jitter != nil {
+		in, out := &in.Jitter, &out.Jitter
+		*out = new(float64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudProviderBackoff.
+func (in *CloudProviderBackoff) DeepCopy() *CloudProviderBackoff {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudProviderBackoff)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudProviderRateLimit) DeepCopyInto(out *CloudProviderRateLimit) {
+	*out = *in
+	if in.QPS != nil {
+		in, out := &in.QPS, &out.QPS
+		*out = new(float64)
+		**out = **in
+	}
+	if in.Bucket != nil {
+		in, out := &in.Bucket, &out.Bucket
+		*out = new(int32)
+		**out = **in
+	}
+	if in.QPSWrite != nil {
+		in, out := &in.QPSWrite, &out.QPSWrite
+		*out = new(float64)
+		**out = **in
+	}
+	if in.BucketWrite != nil {
+		in, out := &in.BucketWrite, &out.BucketWrite
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudProviderRateLimit.
+func (in *CloudProviderRateLimit) DeepCopy() *CloudProviderRateLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudProviderRateLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ControllerConfiguration) DeepCopyInto(out *ControllerConfiguration) {
 	*out = *in
@@ -41,6 +176,31 @@ func (in *ControllerConfiguration) DeepCopyInto(out *ControllerConfiguration) {
 		*out = new(healthcheckconfig.HealthCheckConfig)
 		**out = **in
 	}
+	if in.CloudControllerManager != nil {
+		in, out := &in.CloudControllerManager, &out.CloudControllerManager
+		*out = new(CloudControllerManagerConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KubeAPIServerExposure != nil {
+		in, out := &in.KubeAPIServerExposure, &out.KubeAPIServerExposure
+		*out = new(KubeAPIServerExposureConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VirtualMachineHealthCheck != nil {
+		in, out := &in.VirtualMachineHealthCheck, &out.VirtualMachineHealthCheck
+		*out = new(VirtualMachineHealthCheckConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KMS != nil {
+		in, out := &in.KMS, &out.KMS
+		*out = new(KMSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AcrCredentialProvider != nil {
+		in, out := &in.AcrCredentialProvider, &out.AcrCredentialProvider
+		*out = new(AcrCredentialProviderConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -126,3 +286,144 @@ func (in *ETCDStorage) DeepCopy() *ETCDStorage {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KMSConfig) DeepCopyInto(out *KMSConfig) {
+	*out = *in
+	if in.ManagedIdentityClientID != nil {
+		in, out := &in.ManagedIdentityClientID, &out.ManagedIdentityClientID
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KMSConfig.
+func (in *KMSConfig) DeepCopy() *KMSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(KMSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeAPIServerExposureConfig) DeepCopyInto(out *KubeAPIServerExposureConfig) {
+	*out = *in
+	if in.Internal != nil {
+		in, out := &in.Internal, &out.Internal
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Subnet != nil {
+		in, out := &in.Subnet, &out.Subnet
+		*out = new(string)
+		**out = **in
+	}
+	if in.PrivateLinkService != nil {
+		in, out := &in.PrivateLinkService, &out.PrivateLinkService
+		*out = new(PrivateLinkServiceConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TCPIdleTimeoutInMinutes != nil {
+		in, out := &in.TCPIdleTimeoutInMinutes, &out.TCPIdleTimeoutInMinutes
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PublicIP != nil {
+		in, out := &in.PublicIP, &out.PublicIP
+		*out = new(PublicIPConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeAPIServerExposureConfig.
+func (in *KubeAPIServerExposureConfig) DeepCopy() *KubeAPIServerExposureConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeAPIServerExposureConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrivateLinkServiceConfig) DeepCopyInto(out *PrivateLinkServiceConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Subnet != nil {
+		in, out := &in.Subnet, &out.Subnet
+		*out = new(string)
+		**out = **in
+	}
+	if in.AllowedSubscriptions != nil {
+		in, out := &in.AllowedSubscriptions, &out.AllowedSubscriptions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AutoApprovedSubscriptions != nil {
+		in, out := &in.AutoApprovedSubscriptions, &out.AutoApprovedSubscriptions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrivateLinkServiceConfig.
+func (in *PrivateLinkServiceConfig) DeepCopy() *PrivateLinkServiceConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PrivateLinkServiceConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PublicIPConfig) DeepCopyInto(out *PublicIPConfig) {
+	*out = *in
+	if in.SKU != nil {
+		in, out := &in.SKU, &out.SKU
+		*out = new(string)
+		**out = **in
+	}
+	out.SecretRef = in.SecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublicIPConfig.
+func (in *PublicIPConfig) DeepCopy() *PublicIPConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PublicIPConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineHealthCheckConfig) DeepCopyInto(out *VirtualMachineHealthCheckConfig) {
+	*out = *in
+	if in.Remediation != nil {
+		in, out := &in.Remediation, &out.Remediation
+		*out = new(VirtualMachineRemediation)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineHealthCheckConfig.
+func (in *VirtualMachineHealthCheckConfig) DeepCopy() *VirtualMachineHealthCheckConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineHealthCheckConfig)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+
+	"github.com/gardener/gardener-extension-provider-azure/pkg/internal"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2019-07-01/compute"
+	"github.com/Azure/go-autorest/autorest/azure/auth"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NewComputeClientFromSecretRef retrieves the azure compute client from the secret reference.
+func NewComputeClientFromSecretRef(ctx context.Context, c client.Client, secretRef *corev1.SecretReference) (*ComputeClient, error) {
+	clientAuth, err := internal.GetClientAuthData(ctx, c, *secretRef)
+	if err != nil {
+		return nil, err
+	}
+
+	clientCredConfig := auth.NewClientCredentialsConfig(clientAuth.ClientID, clientAuth.ClientSecret, clientAuth.TenantID)
+	authorizer, err := clientCredConfig.Authorizer()
+	if err != nil {
+		return nil, err
+	}
+
+	virtualMachinesClient := compute.NewVirtualMachinesClient(clientAuth.SubscriptionID)
+	virtualMachinesClient.Authorizer = authorizer
+
+	return &ComputeClient{
+		virtualMachinesClient: virtualMachinesClient,
+	}, nil
+}
+
+// ListVirtualMachines returns all virtual machines of the given resource group.
+func (c *ComputeClient) ListVirtualMachines(ctx context.Context, resourceGroup string) ([]compute.VirtualMachine, error) {
+	page, err := c.virtualMachinesClient.List(ctx, resourceGroup)
+	if err != nil {
+		return nil, err
+	}
+
+	var virtualMachines []compute.VirtualMachine
+	for page.NotDone() {
+		virtualMachines = append(virtualMachines, page.Values()...)
+		if err := page.NextWithContext(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return virtualMachines, nil
+}
+
+// GetVirtualMachineInstanceView returns the instance view of the virtual machine with the given name.
+func (c *ComputeClient) GetVirtualMachineInstanceView(ctx context.Context, resourceGroup, name string) (*compute.VirtualMachineInstanceView, error) {
+	instanceView, err := c.virtualMachinesClient.InstanceView(ctx, resourceGroup, name)
+	if err != nil {
+		return nil, err
+	}
+	return &instanceView, nil
+}
+
+// RedeployVirtualMachine starts to redeploy the virtual machine with the given name to another Azure host. It does
+// not wait until the virtual machine has been redeployed.
+func (c *ComputeClient) RedeployVirtualMachine(ctx context.Context, resourceGroup, name string) error {
+	_, err := c.virtualMachinesClient.Redeploy(ctx, resourceGroup, name)
+	return err
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gardener/gardener-extension-provider-azure/pkg/internal"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2019-06-01/network"
+	"github.com/Azure/go-autorest/autorest/azure/auth"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NewNetworkClientFromSecretRef retrieves the azure network client from the secret reference.
+func NewNetworkClientFromSecretRef(ctx context.Context, c client.Client, secretRef *corev1.SecretReference) (*NetworkClient, error) {
+	clientAuth, err := internal.GetClientAuthData(ctx, c, *secretRef)
+	if err != nil {
+		return nil, err
+	}
+
+	clientCredConfig := auth.NewClientCredentialsConfig(clientAuth.ClientID, clientAuth.ClientSecret, clientAuth.TenantID)
+	authorizer, err := clientCredConfig.Authorizer()
+	if err != nil {
+		return nil, err
+	}
+
+	publicIPAddressesClient := network.NewPublicIPAddressesClient(clientAuth.SubscriptionID)
+	publicIPAddressesClient.Authorizer = authorizer
+
+	loadBalancersClient := network.NewLoadBalancersClient(clientAuth.SubscriptionID)
+	loadBalancersClient.Authorizer = authorizer
+
+	return &NetworkClient{
+		publicIPAddressesClient: publicIPAddressesClient,
+		loadBalancersClient:     loadBalancersClient,
+	}, nil
+}
+
+// CreateOrUpdatePublicIP creates or updates the public IP with the given name and returns it.
+func (c *NetworkClient) CreateOrUpdatePublicIP(ctx context.Context, resourceGroup, name string, publicIP network.PublicIPAddress) (*network.PublicIPAddress, error) {
+	future, err := c.publicIPAddressesClient.CreateOrUpdate(ctx, resourceGroup, name, publicIP)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := future.WaitForCompletionRef(ctx, c.publicIPAddressesClient.Client); err != nil {
+		return nil, err
+	}
+
+	result, err := future.Result(c.publicIPAddressesClient)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetPublicIP returns the public IP with the given name. It returns nil if the public IP does not exist.
+func (c *NetworkClient) GetPublicIP(ctx context.Context, resourceGroup, name string) (*network.PublicIPAddress, error) {
+	publicIP, err := c.publicIPAddressesClient.Get(ctx, resourceGroup, name, "")
+	if err != nil {
+		if publicIP.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &publicIP, nil
+}
+
+// DeletePublicIP deletes the public IP with the given name. It does not fail if the public IP does not exist.
+func (c *NetworkClient) DeletePublicIP(ctx context.Context, resourceGroup, name string) error {
+	future, err := c.publicIPAddressesClient.Delete(ctx, resourceGroup, name)
+	if err != nil {
+		return err
+	}
+
+	return future.WaitForCompletionRef(ctx, c.publicIPAddressesClient.Client)
+}
+
+// GetLoadBalancer returns the load balancer with the given name. It returns nil if the load balancer does not exist.
+func (c *NetworkClient) GetLoadBalancer(ctx context.Context, resourceGroup, name string) (*network.LoadBalancer, error) {
+	loadBalancer, err := c.loadBalancersClient.Get(ctx, resourceGroup, name, "")
+	if err != nil {
+		if loadBalancer.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &loadBalancer, nil
+}
@@ -17,6 +17,8 @@ package client
 import (
 	"context"
 
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2019-07-01/compute"
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2019-06-01/network"
 	"github.com/Azure/azure-storage-blob-go/azblob"
 )
 
@@ -40,3 +42,32 @@ type Storage interface {
 	CreateContainerIfNotExists(ctx context.Context, container string) error
 	DeleteContainerIfExists(ctx context.Context, container string) error
 }
+
+// NetworkClient represents a Azure network client.
+type NetworkClient struct {
+	// publicIPAddressesClient is the azure public IP addresses client.
+	publicIPAddressesClient network.PublicIPAddressesClient
+	// loadBalancersClient is the azure load balancers client.
+	loadBalancersClient network.LoadBalancersClient
+}
+
+// Network represents a Azure network client.
+type Network interface {
+	CreateOrUpdatePublicIP(ctx context.Context, resourceGroup, name string, publicIP network.PublicIPAddress) (*network.PublicIPAddress, error)
+	GetPublicIP(ctx context.Context, resourceGroup, name string) (*network.PublicIPAddress, error)
+	DeletePublicIP(ctx context.Context, resourceGroup, name string) error
+	GetLoadBalancer(ctx context.Context, resourceGroup, name string) (*network.LoadBalancer, error)
+}
+
+// ComputeClient represents a Azure compute client.
+type ComputeClient struct {
+	// virtualMachinesClient is the azure virtual machines client.
+	virtualMachinesClient compute.VirtualMachinesClient
+}
+
+// Compute represents a Azure compute client.
+type Compute interface {
+	ListVirtualMachines(ctx context.Context, resourceGroup string) ([]compute.VirtualMachine, error)
+	GetVirtualMachineInstanceView(ctx context.Context, resourceGroup, name string) (*compute.VirtualMachineInstanceView, error)
+	RedeployVirtualMachine(ctx context.Context, resourceGroup, name string) error
+}
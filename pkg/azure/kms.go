@@ -0,0 +1,34 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+// KMSPluginSocketDir is the directory which contains the sockets of the Azure KMS plugins in the kube-apiserver pod.
+const KMSPluginSocketDir = "/var/run/kmsplugin"
+
+// KMSPluginProviderName returns the name of the KMS provider in the encryption configuration of the kube-apiserver for
+// the given key version. The name is part of the prefix of the data encrypted in etcd, hence it must not change.
+func KMSPluginProviderName(keyVersion string) string {
+	return "azurekms-" + keyVersion
+}
+
+// KMSPluginEndpoint returns the endpoint of the Azure KMS plugin for the given key version.
+func KMSPluginEndpoint(keyVersion string) string {
+	return fmt.Sprintf("unix://%s", filepath.Join(KMSPluginSocketDir, keyVersion+".sock"))
+}
@@ -31,6 +31,30 @@ const (
 	MachineControllerManagerName = "machine-controller-manager"
 	// CloudControllerManagerImageName is the name of the cloud-controller-manager image.
 	CloudControllerManagerImageName = "cloud-controller-manager"
+	// CloudNodeManagerImageName is the name of the cloud-node-manager image.
+	CloudNodeManagerImageName = "cloud-node-manager"
+	// CSIDriverDiskImageName is the name of the csi-driver-disk image.
+	CSIDriverDiskImageName = "csi-driver-disk"
+	// CSIDriverFileImageName is the name of the csi-driver-file image.
+	CSIDriverFileImageName = "csi-driver-file"
+	// CSIProvisionerImageName is the name of the csi-provisioner image.
+	CSIProvisionerImageName = "csi-provisioner"
+	// CSIAttacherImageName is the name of the csi-attacher image.
+	CSIAttacherImageName = "csi-attacher"
+	// CSIResizerImageName is the name of the csi-resizer image.
+	CSIResizerImageName = "csi-resizer"
+	// CSINodeDriverRegistrarImageName is the name of the csi-node-driver-registrar image.
+	CSINodeDriverRegistrarImageName = "csi-node-driver-registrar"
+	// CSISnapshotterImageName is the name of the csi-snapshotter image.
+	CSISnapshotterImageName = "csi-snapshotter"
+	// CSISnapshotControllerImageName is the name of the csi-snapshot-controller image.
+	CSISnapshotControllerImageName = "csi-snapshot-controller"
+	// KMSPluginImageName is the name of the Azure KMS plugin image.
+	KMSPluginImageName = "azure-kms-plugin"
+	// CSILivenessProbeImageName is the name of the csi-liveness-probe image.
+	CSILivenessProbeImageName = "csi-liveness-probe"
+	// ScheduledEventsHandlerImageName is the name of the scheduled-events-handler image.
+	ScheduledEventsHandlerImageName = "scheduled-events-handler"
 
 	// SubscriptionIDKey is the key for the subscription ID.
 	SubscriptionIDKey = "subscriptionID"
@@ -64,6 +88,32 @@ const (
 	CloudProviderAcrConfigName = "kubelet-acr-config"
 	// CloudProviderAcrConfigMapKey is the key storing the cloud provider config as value in the acr cloud provider configmap.
 	CloudProviderAcrConfigMapKey = "acr.conf"
+	// CloudProviderAcrCredentialProviderConfigName is the name of the configmap containing the configuration of the
+	// kubelet image credential provider for the acr.
+	CloudProviderAcrCredentialProviderConfigName = "kubelet-acr-credential-provider-config"
+	// CloudProviderAcrCredentialProviderConfigMapKey is the key storing the configuration of the kubelet image
+	// credential provider as value in the acr credential provider configmap.
+	CloudProviderAcrCredentialProviderConfigMapKey = "credential-provider-config.yaml"
+	// AcrCredentialProviderKubernetesVersion is the Kubernetes version as of which the kubelet pulls images from the
+	// acr with the image credential provider instead of the in-tree acr credentials.
+	AcrCredentialProviderKubernetesVersion = "1.20"
+	// KubeletCredentialProvidersBetaKubernetesVersion is the Kubernetes version as of which the image credential
+	// providers of the kubelet are beta and thus enabled. Before, the KubeletCredentialProviders feature gate is required.
+	KubeletCredentialProvidersBetaKubernetesVersion = "1.24"
+	// KMSPluginSecretName is the name of the secret containing the configuration of the Azure KMS plugin and the
+	// encryption configuration of the kube-apiserver.
+	KMSPluginSecretName = "kms-plugin"
+	// KMSPluginConfigKey is the key storing the configuration of the Azure KMS plugin in the KMS plugin secret.
+	KMSPluginConfigKey = "azure.json"
+	// KMSEncryptionConfigurationKey is the key storing the encryption configuration of the kube-apiserver in the KMS
+	// plugin secret.
+	KMSEncryptionConfigurationKey = "encryption-configuration.yaml"
+	// LoadBalancerMigrationBackupName is the name of the secret containing the backup of the services of type
+	// LoadBalancer of a shoot while its load balancers are migrated from the basic to the standard SKU.
+	LoadBalancerMigrationBackupName = "load-balancer-migration-backup"
+	// LoadBalancerMigrationBackupKey is the key storing the services of type LoadBalancer in the load balancer
+	// migration backup secret.
+	LoadBalancerMigrationBackupKey = "services.json"
 	// BackupSecretName is the name of the secret containing the credentials for storing the backups of Shoot clusters.
 	BackupSecretName = "etcd-backup"
 	// MachineControllerManagerVpaName is the name of the VerticalPodAutoscaler of the machine-controller-manager deployment.
@@ -72,6 +122,28 @@ const (
 	MachineControllerManagerMonitoringConfigName = "machine-controller-manager-monitoring-config"
 	// CloudControllerManagerName is a constant for the name of the CloudController deployed by the worker controller.
 	CloudControllerManagerName = "cloud-controller-manager"
+	// CloudNodeManagerName is a constant for the name of the cloud-node-manager daemonset in the shoot.
+	CloudNodeManagerName = "cloud-node-manager"
+	// CSIControllerDiskName is a constant for the name of the Azure Disk CSI controller deployment in the seed.
+	CSIControllerDiskName = "csi-driver-controller-disk"
+	// CSIControllerFileName is a constant for the name of the Azure File CSI controller deployment in the seed.
+	CSIControllerFileName = "csi-driver-controller-file"
+	// CSISnapshotControllerName is a constant for the name of the CSI snapshot controller deployment in the seed.
+	CSISnapshotControllerName = "csi-snapshot-controller"
+	// CSINodeDiskName is a constant for the name of the Azure Disk CSI node daemonset in the shoot.
+	CSINodeDiskName = "csi-driver-node-disk"
+	// CSINodeFileName is a constant for the name of the Azure File CSI node daemonset in the shoot.
+	CSINodeFileName = "csi-driver-node-file"
+	// CSIMigrationKubernetesVersion is the Kubernetes version as of which the in-tree volume plugins are migrated to
+	// the Azure Disk and Azure File CSI drivers.
+	CSIMigrationKubernetesVersion = "1.19"
+	// CSIMigrationAzureFileKubernetesVersion is the Kubernetes version as of which the migration of the in-tree Azure
+	// File volume plugin to the CSI driver is beta and thus enabled.
+	CSIMigrationAzureFileKubernetesVersion = "1.21"
+
+	// MigrateLoadBalancerSKUAnnotation is the annotation of a shoot which triggers the migration of its load balancers
+	// from the basic to the standard SKU if it is set to `true`.
+	MigrateLoadBalancerSKUAnnotation = "azure.provider.extensions.gardener.cloud/migrate-load-balancer-sku"
 )
 
 var (
@@ -19,6 +19,7 @@ import (
 
 	"github.com/gardener/gardener-extension-provider-azure/pkg/apis/config"
 	configloader "github.com/gardener/gardener-extension-provider-azure/pkg/apis/config/loader"
+	configvalidation "github.com/gardener/gardener-extension-provider-azure/pkg/apis/config/validation"
 	healthcheckconfig "github.com/gardener/gardener-extensions/pkg/controller/healthcheck/config"
 
 	"github.com/spf13/pflag"
@@ -52,6 +53,10 @@ func (c *ConfigOptions) Complete() error {
 		return err
 	}
 
+	if errs := configvalidation.ValidateControllerConfiguration(config); len(errs) > 0 {
+		return fmt.Errorf("invalid controller configuration: %v", errs.ToAggregate())
+	}
+
 	c.config = &Config{config}
 	return nil
 }
@@ -94,3 +99,38 @@ func (c *Config) ApplyHealthCheckConfig(config *healthcheckconfig.HealthCheckCon
 		*config = *c.Config.HealthCheckConfig
 	}
 }
+
+// ApplyCloudControllerManager sets the given cloud-controller-manager defaults to those of this Config.
+func (c *Config) ApplyCloudControllerManager(cloudControllerManager *config.CloudControllerManagerConfig) {
+	if c.Config.CloudControllerManager != nil {
+		*cloudControllerManager = *c.Config.CloudControllerManager
+	}
+}
+
+// ApplyKubeAPIServerExposure sets the given kube-apiserver exposure defaults to those of this Config.
+func (c *Config) ApplyKubeAPIServerExposure(kubeAPIServerExposure *config.KubeAPIServerExposureConfig) {
+	if c.Config.KubeAPIServerExposure != nil {
+		*kubeAPIServerExposure = *c.Config.KubeAPIServerExposure
+	}
+}
+
+// ApplyKMS sets the given KMS plugin settings to those of this Config.
+func (c *Config) ApplyKMS(kms *config.KMSConfig) {
+	if c.Config.KMS != nil {
+		*kms = *c.Config.KMS
+	}
+}
+
+// ApplyAcrCredentialProvider sets the given acr credential provider settings to those of this Config.
+func (c *Config) ApplyAcrCredentialProvider(acrCredentialProvider *config.AcrCredentialProviderConfig) {
+	if c.Config.AcrCredentialProvider != nil {
+		*acrCredentialProvider = *c.Config.AcrCredentialProvider
+	}
+}
+
+// ApplyVirtualMachineHealthCheck sets the given virtual machine health check settings to those of this Config.
+func (c *Config) ApplyVirtualMachineHealthCheck(virtualMachineHealthCheck *config.VirtualMachineHealthCheckConfig) {
+	if c.Config.VirtualMachineHealthCheck != nil {
+		*virtualMachineHealthCheck = *c.Config.VirtualMachineHealthCheck
+	}
+}
@@ -0,0 +1,298 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	azureapihelper "github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure/helper"
+	"github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure/v1alpha1"
+	"github.com/gardener/gardener-extension-provider-azure/pkg/apis/config"
+	azureclient "github.com/gardener/gardener-extension-provider-azure/pkg/azure/client"
+	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
+	"github.com/gardener/gardener-extensions/pkg/controller/controlplane"
+	controllererrors "github.com/gardener/gardener-extensions/pkg/controller/error"
+	"github.com/gardener/gardener-extensions/pkg/util"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2019-06-01/network"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
+)
+
+const (
+	// defaultPublicIPSKU is the SKU of the static public IPs of the kube-apiservers if the seed does not configure one.
+	defaultPublicIPSKU = "Standard"
+	// publicIPReleaseRequeueAfter is the duration after which the release of a static public IP is retried if it is
+	// still used by the load balancer of the kube-apiserver.
+	publicIPReleaseRequeueAfter = 30 * time.Second
+)
+
+// newNetworkClient creates the network client for the subscription of the seed. It is a variable to be able to
+// replace it in the tests.
+var newNetworkClient = func(ctx context.Context, c client.Client, secretRef *corev1.SecretReference) (azureclient.Network, error) {
+	return azureclient.NewNetworkClientFromSecretRef(ctx, c, secretRef)
+}
+
+// NewActuator creates a new controlplane.Actuator which reserves a static public IP for the kube-apiserver when it
+// reconciles a ControlPlane with purpose exposure, and which delegates everything else to the given actuator.
+func NewActuator(a controlplane.Actuator, kubeAPIServerExposure *config.KubeAPIServerExposureConfig, logger logr.Logger) controlplane.Actuator {
+	return &actuator{
+		Actuator:              a,
+		kubeAPIServerExposure: kubeAPIServerExposure,
+		logger:                logger.WithName("azure-controlplane-actuator"),
+	}
+}
+
+type actuator struct {
+	controlplane.Actuator
+	kubeAPIServerExposure *config.KubeAPIServerExposureConfig
+	client                client.Client
+	logger                logr.Logger
+}
+
+// InjectFunc enables injecting Kubernetes dependencies into the dependencies of the actuator.
+func (a *actuator) InjectFunc(f inject.Func) error {
+	return f(a.Actuator)
+}
+
+// InjectClient injects the given client into the actuator.
+func (a *actuator) InjectClient(client client.Client) error {
+	a.client = client
+	return nil
+}
+
+// Reconcile reconciles the given controlplane and cluster, reserving or releasing the static public IP of the
+// kube-apiserver if the controlplane has the purpose exposure, and migrating the load balancers of the shoot to the
+// standard SKU otherwise.
+func (a *actuator) Reconcile(ctx context.Context, cp *extensionsv1alpha1.ControlPlane, cluster *extensionscontroller.Cluster) (bool, error) {
+	requeue, err := a.Actuator.Reconcile(ctx, cp, cluster)
+	if err != nil {
+		return requeue, err
+	}
+
+	if isExposure(cp) {
+		return requeue, a.reconcileExposure(ctx, cp, cluster)
+	}
+	return requeue, a.migrateLoadBalancers(ctx, cp, cluster)
+}
+
+// Delete deletes the given controlplane, releasing the static public IP of the kube-apiserver if the controlplane has
+// the purpose exposure.
+func (a *actuator) Delete(ctx context.Context, cp *extensionsv1alpha1.ControlPlane, cluster *extensionscontroller.Cluster) error {
+	if err := a.Actuator.Delete(ctx, cp, cluster); err != nil || !isExposure(cp) {
+		return err
+	}
+
+	return a.deletePublicIP(ctx, cp)
+}
+
+// reconcileExposure fixes whether the kube-apiserver is exposed by an internal load balancer when the shoot is created,
+// so that a later change of the defaults of the seed does not change the address of the kube-apiserver of an existing
+// shoot, and reserves the static public IP of a public kube-apiserver.
+func (a *actuator) reconcileExposure(ctx context.Context, cp *extensionsv1alpha1.ControlPlane, cluster *extensionscontroller.Cluster) error {
+	status, err := getControlPlaneStatus(cp)
+	if err != nil {
+		return err
+	}
+
+	exposure := status.KubeAPIServerExposure
+	if exposure == nil {
+		exposure = &v1alpha1.KubeAPIServerExposureStatus{
+			Internal: a.kubeAPIServerExposure != nil && a.kubeAPIServerExposure.Internal != nil && *a.kubeAPIServerExposure.Internal,
+		}
+		if err := a.updateStatus(ctx, cp, func(status *v1alpha1.ControlPlaneStatus) {
+			status.KubeAPIServerExposure = exposure
+		}); err != nil {
+			return err
+		}
+	}
+
+	// The kube-apiserver of a private cluster does not need a public IP.
+	if exposure.Internal {
+		return a.deletePublicIP(ctx, cp)
+	}
+	return a.reconcilePublicIP(ctx, cp, cluster)
+}
+
+func (a *actuator) reconcilePublicIP(ctx context.Context, cp *extensionsv1alpha1.ControlPlane, cluster *extensionscontroller.Cluster) error {
+	if a.kubeAPIServerExposure == nil || a.kubeAPIServerExposure.PublicIP == nil {
+		return nil
+	}
+	publicIPConfig := a.kubeAPIServerExposure.PublicIP
+
+	cpConfig, err := azureapihelper.ControlPlaneConfigFromCluster(cluster)
+	if err != nil {
+		return err
+	}
+
+	var dnsLabel *string
+	if cpConfig != nil && cpConfig.KubeAPIServerExposure != nil {
+		dnsLabel = cpConfig.KubeAPIServerExposure.DNSLabel
+	}
+
+	networkClient, err := newNetworkClient(ctx, a.client, &publicIPConfig.SecretRef)
+	if err != nil {
+		return errors.Wrap(err, "could not create network client")
+	}
+
+	a.logger.Info("Reserving public IP of kube-apiserver", "controlplane", cp.Name, "namespace", cp.Namespace)
+	publicIP, err := networkClient.CreateOrUpdatePublicIP(ctx, publicIPConfig.ResourceGroup, cp.Namespace, getPublicIPParameters(publicIPConfig, cp.Spec.Region, dnsLabel))
+	if err != nil {
+		return errors.Wrapf(err, "could not reserve public IP '%s/%s'", publicIPConfig.ResourceGroup, cp.Namespace)
+	}
+
+	status := &v1alpha1.PublicIPStatus{
+		Name:          cp.Namespace,
+		ResourceGroup: publicIPConfig.ResourceGroup,
+	}
+	if props := publicIP.PublicIPAddressPropertiesFormat; props != nil {
+		if props.IPAddress != nil {
+			status.IPAddress = *props.IPAddress
+		}
+		if props.DNSSettings != nil {
+			status.FQDN = props.DNSSettings.Fqdn
+		}
+	}
+	if len(status.IPAddress) == 0 {
+		return errors.Errorf("public IP '%s/%s' has no address yet", publicIPConfig.ResourceGroup, cp.Namespace)
+	}
+
+	return a.updatePublicIPStatus(ctx, cp, status)
+}
+
+func (a *actuator) deletePublicIP(ctx context.Context, cp *extensionsv1alpha1.ControlPlane) error {
+	status, err := getControlPlaneStatus(cp)
+	if err != nil {
+		return err
+	}
+	if status.PublicIP == nil {
+		return nil
+	}
+	resourceGroup, name := status.PublicIP.ResourceGroup, status.PublicIP.Name
+
+	// The public IP is kept in the status, so that it is released once the configuration of the seed is restored.
+	if a.kubeAPIServerExposure == nil || a.kubeAPIServerExposure.PublicIP == nil {
+		a.logger.Info("Cannot release public IP of kube-apiserver without the credentials of the seed, skipping", "publicIP", resourceGroup+"/"+name, "controlplane", cp.Name, "namespace", cp.Namespace)
+		return nil
+	}
+
+	networkClient, err := newNetworkClient(ctx, a.client, &a.kubeAPIServerExposure.PublicIP.SecretRef)
+	if err != nil {
+		return errors.Wrap(err, "could not create network client")
+	}
+
+	publicIP, err := networkClient.GetPublicIP(ctx, resourceGroup, name)
+	if err != nil {
+		return errors.Wrapf(err, "could not get public IP '%s/%s'", resourceGroup, name)
+	}
+	if publicIP != nil {
+		// Azure does not delete a public IP as long as the load balancer of the kube-apiserver service uses it.
+		if props := publicIP.PublicIPAddressPropertiesFormat; props != nil && props.IPConfiguration != nil {
+			return &controllererrors.RequeueAfterError{
+				Cause:        errors.Errorf("waiting until public IP '%s/%s' is released by the load balancer of the kube-apiserver", resourceGroup, name),
+				RequeueAfter: publicIPReleaseRequeueAfter,
+			}
+		}
+
+		a.logger.Info("Releasing public IP of kube-apiserver", "controlplane", cp.Name, "namespace", cp.Namespace)
+		if err := networkClient.DeletePublicIP(ctx, resourceGroup, name); err != nil {
+			return errors.Wrapf(err, "could not release public IP '%s/%s'", resourceGroup, name)
+		}
+	}
+
+	// The status of a deleted controlplane does not need to be updated.
+	if cp.DeletionTimestamp != nil {
+		return nil
+	}
+	return a.updatePublicIPStatus(ctx, cp, nil)
+}
+
+func (a *actuator) updatePublicIPStatus(ctx context.Context, cp *extensionsv1alpha1.ControlPlane, publicIP *v1alpha1.PublicIPStatus) error {
+	return a.updateStatus(ctx, cp, func(status *v1alpha1.ControlPlaneStatus) {
+		status.PublicIP = publicIP
+	})
+}
+
+// updateStatus updates the provider status of the given controlplane with the given function, keeping the fields which
+// are not changed by it.
+func (a *actuator) updateStatus(ctx context.Context, cp *extensionsv1alpha1.ControlPlane, mutate func(*v1alpha1.ControlPlaneStatus)) error {
+	return extensionscontroller.TryUpdateStatus(ctx, retry.DefaultBackoff, a.client, cp, func() error {
+		status, err := getControlPlaneStatus(cp)
+		if err != nil {
+			return err
+		}
+		mutate(status)
+		cp.Status.ProviderStatus = &runtime.RawExtension{Object: status}
+		return nil
+	})
+}
+
+// getControlPlaneStatus returns the provider status of the given controlplane, or an empty one if it has none yet.
+func getControlPlaneStatus(cp *extensionsv1alpha1.ControlPlane) (*v1alpha1.ControlPlaneStatus, error) {
+	status := &v1alpha1.ControlPlaneStatus{}
+	if providerStatus := cp.Status.ProviderStatus; providerStatus != nil {
+		if object, ok := providerStatus.Object.(*v1alpha1.ControlPlaneStatus); ok {
+			status = object.DeepCopy()
+		} else if providerStatus.Raw != nil {
+			if err := json.Unmarshal(providerStatus.Raw, status); err != nil {
+				return nil, errors.Wrapf(err, "could not decode providerStatus of controlplane '%s'", util.ObjectName(cp))
+			}
+		}
+	}
+
+	status.TypeMeta = metav1.TypeMeta{
+		APIVersion: v1alpha1.SchemeGroupVersion.String(),
+		Kind:       "ControlPlaneStatus",
+	}
+	return status, nil
+}
+
+// getPublicIPParameters returns the parameters of the static public IP of the kube-apiserver.
+func getPublicIPParameters(publicIPConfig *config.PublicIPConfig, region string, dnsLabel *string) network.PublicIPAddress {
+	sku := defaultPublicIPSKU
+	if publicIPConfig.SKU != nil {
+		sku = *publicIPConfig.SKU
+	}
+
+	publicIP := network.PublicIPAddress{
+		Location: &region,
+		Sku: &network.PublicIPAddressSku{
+			Name: network.PublicIPAddressSkuName(sku),
+		},
+		PublicIPAddressPropertiesFormat: &network.PublicIPAddressPropertiesFormat{
+			PublicIPAllocationMethod: network.Static,
+			PublicIPAddressVersion:   network.IPv4,
+		},
+	}
+	if dnsLabel != nil {
+		publicIP.DNSSettings = &network.PublicIPAddressDNSSettings{
+			DomainNameLabel: dnsLabel,
+		}
+	}
+	return publicIP
+}
+
+func isExposure(cp *extensionsv1alpha1.ControlPlane) bool {
+	return cp.Spec.Purpose != nil && *cp.Spec.Purpose == extensionsv1alpha1.Exposure
+}
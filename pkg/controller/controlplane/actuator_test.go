@@ -0,0 +1,549 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure/v1alpha1"
+	"github.com/gardener/gardener-extension-provider-azure/pkg/apis/config"
+	"github.com/gardener/gardener-extension-provider-azure/pkg/azure"
+	azureclient "github.com/gardener/gardener-extension-provider-azure/pkg/azure/client"
+	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
+	"github.com/gardener/gardener-extensions/pkg/controller/controlplane"
+	controllererrors "github.com/gardener/gardener-extensions/pkg/controller/error"
+	mockclient "github.com/gardener/gardener-extensions/pkg/mock/controller-runtime/client"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2019-06-01/network"
+	resourcesv1alpha1 "github.com/gardener/gardener-resource-manager/pkg/apis/resources/v1alpha1"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	kutil "github.com/gardener/gardener/pkg/utils/kubernetes"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
+)
+
+type fakeActuator struct {
+	reconciled, deleted bool
+}
+
+func (a *fakeActuator) Reconcile(context.Context, *extensionsv1alpha1.ControlPlane, *extensionscontroller.Cluster) (bool, error) {
+	a.reconciled = true
+	return false, nil
+}
+
+func (a *fakeActuator) Delete(context.Context, *extensionsv1alpha1.ControlPlane, *extensionscontroller.Cluster) error {
+	a.deleted = true
+	return nil
+}
+
+type fakeNetwork struct {
+	deletedPublicIPs []string
+	usedPublicIPs    map[string]bool
+	loadBalancers    map[string]bool
+}
+
+func (n *fakeNetwork) CreateOrUpdatePublicIP(_ context.Context, _, _ string, publicIP network.PublicIPAddress) (*network.PublicIPAddress, error) {
+	publicIP.IPAddress = pointer.StringPtr("1.2.3.4")
+	return &publicIP, nil
+}
+
+func (n *fakeNetwork) GetPublicIP(_ context.Context, resourceGroup, name string) (*network.PublicIPAddress, error) {
+	publicIP := &network.PublicIPAddress{Name: &name, PublicIPAddressPropertiesFormat: &network.PublicIPAddressPropertiesFormat{}}
+	if n.usedPublicIPs[resourceGroup+"/"+name] {
+		publicIP.IPConfiguration = &network.IPConfiguration{Name: pointer.StringPtr("kube-apiserver")}
+	}
+	return publicIP, nil
+}
+
+func (n *fakeNetwork) DeletePublicIP(_ context.Context, resourceGroup, name string) error {
+	n.deletedPublicIPs = append(n.deletedPublicIPs, resourceGroup+"/"+name)
+	return nil
+}
+
+func (n *fakeNetwork) GetLoadBalancer(_ context.Context, resourceGroup, name string) (*network.LoadBalancer, error) {
+	if !n.loadBalancers[resourceGroup+"/"+name] {
+		return nil, nil
+	}
+	return &network.LoadBalancer{Name: &name}, nil
+}
+
+type fakeStatusWriter struct {
+	status *v1alpha1.ControlPlaneStatus
+	states []v1alpha1.LoadBalancerMigrationState
+}
+
+func (w *fakeStatusWriter) Update(_ context.Context, obj runtime.Object, _ ...client.UpdateOption) error {
+	w.status = obj.(*extensionsv1alpha1.ControlPlane).Status.ProviderStatus.Object.(*v1alpha1.ControlPlaneStatus)
+	if w.status.LoadBalancerMigration != nil {
+		w.states = append(w.states, w.status.LoadBalancerMigration.State)
+	}
+	return nil
+}
+
+func (w *fakeStatusWriter) Patch(context.Context, runtime.Object, client.Patch, ...client.PatchOption) error {
+	return nil
+}
+
+var _ = Describe("Actuator", func() {
+	var (
+		exposure = extensionsv1alpha1.Exposure
+
+		kubeAPIServerExposure *config.KubeAPIServerExposureConfig
+		delegate              *fakeActuator
+		networkClient         *fakeNetwork
+		oldNewNetworkClient   func(context.Context, client.Client, *corev1.SecretReference) (azureclient.Network, error)
+		newNetworkClientCalls int
+	)
+
+	BeforeEach(func() {
+		kubeAPIServerExposure = &config.KubeAPIServerExposureConfig{
+			PublicIP: &config.PublicIPConfig{
+				ResourceGroup: "public-ips",
+				SecretRef:     corev1.SecretReference{Name: "seed", Namespace: "garden"},
+			},
+		}
+		delegate = &fakeActuator{}
+		networkClient = &fakeNetwork{}
+		newNetworkClientCalls = 0
+
+		oldNewNetworkClient = newNetworkClient
+		newNetworkClient = func(_ context.Context, _ client.Client, secretRef *corev1.SecretReference) (azureclient.Network, error) {
+			Expect(secretRef).To(Equal(&kubeAPIServerExposure.PublicIP.SecretRef))
+			newNetworkClientCalls++
+			return networkClient, nil
+		}
+	})
+
+	AfterEach(func() {
+		newNetworkClient = oldNewNetworkClient
+	})
+
+	Describe("#Reconcile", func() {
+		It("should only delegate the reconciliation of a controlplane without purpose exposure", func() {
+			a := NewActuator(delegate, kubeAPIServerExposure, log.Log)
+
+			requeue, err := a.Reconcile(context.TODO(), &extensionsv1alpha1.ControlPlane{}, &extensionscontroller.Cluster{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requeue).To(BeFalse())
+			Expect(delegate.reconciled).To(BeTrue())
+			Expect(newNetworkClientCalls).To(BeZero())
+		})
+
+		Context("controlplane with purpose exposure", func() {
+			var (
+				ctx = context.TODO()
+
+				ctrl         *gomock.Controller
+				c            *mockclient.MockClient
+				statusWriter *fakeStatusWriter
+
+				cluster *extensionscontroller.Cluster
+				cp      *extensionsv1alpha1.ControlPlane
+			)
+
+			BeforeEach(func() {
+				ctrl = gomock.NewController(GinkgoT())
+				c = mockclient.NewMockClient(ctrl)
+				statusWriter = &fakeStatusWriter{}
+
+				cluster = &extensionscontroller.Cluster{Shoot: &gardencorev1beta1.Shoot{}}
+				cp = &extensionsv1alpha1.ControlPlane{
+					ObjectMeta: metav1.ObjectMeta{Name: "control-plane-exposure", Namespace: "shoot--foo--bar"},
+					Spec:       extensionsv1alpha1.ControlPlaneSpec{Purpose: &exposure, Region: "westeurope"},
+				}
+
+				c.EXPECT().Get(ctx, kutil.Key(cp.Namespace, cp.Name), cp).Return(nil).AnyTimes()
+				c.EXPECT().Status().Return(statusWriter).AnyTimes()
+			})
+
+			AfterEach(func() {
+				ctrl.Finish()
+			})
+
+			It("should fix the internal exposure of the seed when the shoot is created and not reserve a public IP", func() {
+				kubeAPIServerExposure.Internal = pointer.BoolPtr(true)
+				a := NewActuator(delegate, kubeAPIServerExposure, log.Log)
+				Expect(a.(inject.Client).InjectClient(c)).To(Succeed())
+
+				_, err := a.Reconcile(ctx, cp, cluster)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(delegate.reconciled).To(BeTrue())
+				Expect(newNetworkClientCalls).To(BeZero())
+				Expect(statusWriter.status.KubeAPIServerExposure).To(Equal(&v1alpha1.KubeAPIServerExposureStatus{Internal: true}))
+			})
+
+			It("should keep the exposure which has been fixed when the shoot was created", func() {
+				kubeAPIServerExposure.Internal = pointer.BoolPtr(true)
+				a := NewActuator(delegate, kubeAPIServerExposure, log.Log)
+				Expect(a.(inject.Client).InjectClient(c)).To(Succeed())
+				cp.Status.ProviderStatus = &runtime.RawExtension{
+					Raw: encode(&v1alpha1.ControlPlaneStatus{
+						TypeMeta:              metav1.TypeMeta{APIVersion: v1alpha1.SchemeGroupVersion.String(), Kind: "ControlPlaneStatus"},
+						KubeAPIServerExposure: &v1alpha1.KubeAPIServerExposureStatus{Internal: false},
+					}),
+				}
+
+				_, err := a.Reconcile(ctx, cp, cluster)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(newNetworkClientCalls).To(Equal(1))
+				Expect(statusWriter.status.KubeAPIServerExposure).To(Equal(&v1alpha1.KubeAPIServerExposureStatus{Internal: false}))
+				Expect(statusWriter.status.PublicIP).To(Equal(&v1alpha1.PublicIPStatus{
+					Name:          cp.Namespace,
+					ResourceGroup: "public-ips",
+					IPAddress:     "1.2.3.4",
+				}))
+			})
+		})
+	})
+
+	Describe("#Delete", func() {
+		var cp *extensionsv1alpha1.ControlPlane
+
+		BeforeEach(func() {
+			now := metav1.Now()
+			cp = &extensionsv1alpha1.ControlPlane{
+				ObjectMeta: metav1.ObjectMeta{DeletionTimestamp: &now},
+				Spec:       extensionsv1alpha1.ControlPlaneSpec{Purpose: &exposure},
+				Status: extensionsv1alpha1.ControlPlaneStatus{
+					DefaultStatus: extensionsv1alpha1.DefaultStatus{
+						ProviderStatus: &runtime.RawExtension{
+							Raw: encode(&v1alpha1.ControlPlaneStatus{
+								TypeMeta: metav1.TypeMeta{APIVersion: v1alpha1.SchemeGroupVersion.String(), Kind: "ControlPlaneStatus"},
+								PublicIP: &v1alpha1.PublicIPStatus{Name: "shoot--foo--bar", ResourceGroup: "public-ips", IPAddress: "1.2.3.4"},
+							}),
+						},
+					},
+				},
+			}
+		})
+
+		It("should release the public IP of a controlplane with purpose exposure", func() {
+			a := NewActuator(delegate, kubeAPIServerExposure, log.Log)
+
+			err := a.Delete(context.TODO(), cp, &extensionscontroller.Cluster{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(delegate.deleted).To(BeTrue())
+			Expect(networkClient.deletedPublicIPs).To(ConsistOf("public-ips/shoot--foo--bar"))
+		})
+
+		It("should wait until the load balancer of the kube-apiserver has released the public IP", func() {
+			networkClient.usedPublicIPs = map[string]bool{"public-ips/shoot--foo--bar": true}
+			a := NewActuator(delegate, kubeAPIServerExposure, log.Log)
+
+			err := a.Delete(context.TODO(), cp, &extensionscontroller.Cluster{})
+			Expect(err).To(BeAssignableToTypeOf(&controllererrors.RequeueAfterError{}))
+			Expect(networkClient.deletedPublicIPs).To(BeEmpty())
+		})
+
+		It("should not block the deletion if the seed does not configure the public IPs anymore", func() {
+			kubeAPIServerExposure.PublicIP = nil
+			a := NewActuator(delegate, kubeAPIServerExposure, log.Log)
+
+			err := a.Delete(context.TODO(), cp, &extensionscontroller.Cluster{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(delegate.deleted).To(BeTrue())
+			Expect(newNetworkClientCalls).To(BeZero())
+		})
+
+		It("should not release a public IP if none has been reserved", func() {
+			a := NewActuator(delegate, kubeAPIServerExposure, log.Log)
+			cp := &extensionsv1alpha1.ControlPlane{
+				Spec: extensionsv1alpha1.ControlPlaneSpec{Purpose: &exposure},
+			}
+
+			err := a.Delete(context.TODO(), cp, &extensionscontroller.Cluster{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(delegate.deleted).To(BeTrue())
+			Expect(newNetworkClientCalls).To(BeZero())
+		})
+	})
+
+	Describe("#migrateLoadBalancers", func() {
+		var (
+			ctx = context.TODO()
+			ns  = "shoot--foo--bar"
+
+			ctrl              *gomock.Controller
+			c                 *mockclient.MockClient
+			shootClient       *mockclient.MockClient
+			statusWriter      *fakeStatusWriter
+			oldNewShootClient func(context.Context, client.Client, string) (client.Client, error)
+
+			a       controlplane.Actuator
+			cluster *extensionscontroller.Cluster
+			cp      *extensionsv1alpha1.ControlPlane
+			backup  *corev1.Secret
+
+			lbService = corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "ingress", Namespace: "default"},
+				Spec: corev1.ServiceSpec{
+					Type:      corev1.ServiceTypeLoadBalancer,
+					ClusterIP: "10.0.0.10",
+					Ports:     []corev1.ServicePort{{Name: "https", Port: 443}},
+				},
+			}
+			clusterIPService = corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "kubernetes", Namespace: "default"},
+				Spec: corev1.ServiceSpec{
+					Type:      corev1.ServiceTypeClusterIP,
+					ClusterIP: "10.0.0.1",
+				},
+			}
+
+			getDeployment = func(deployment *appsv1.Deployment) interface{} {
+				return func(_ context.Context, _ client.ObjectKey, obj runtime.Object) error {
+					*obj.(*appsv1.Deployment) = *deployment
+					return nil
+				}
+			}
+
+			managedResource *resourcesv1alpha1.ManagedResource
+			ignoreUpdates   []bool
+		)
+
+		BeforeEach(func() {
+			ctrl = gomock.NewController(GinkgoT())
+			c = mockclient.NewMockClient(ctrl)
+			shootClient = mockclient.NewMockClient(ctrl)
+			statusWriter = &fakeStatusWriter{}
+			backup = nil
+			managedResource = &resourcesv1alpha1.ManagedResource{ObjectMeta: metav1.ObjectMeta{Name: "addons", Namespace: ns}}
+			ignoreUpdates = nil
+
+			oldNewShootClient = newShootClient
+			newShootClient = func(context.Context, client.Client, string) (client.Client, error) {
+				return shootClient, nil
+			}
+			newNetworkClient = func(_ context.Context, _ client.Client, secretRef *corev1.SecretReference) (azureclient.Network, error) {
+				Expect(secretRef).To(Equal(&cp.Spec.SecretRef))
+				return networkClient, nil
+			}
+
+			a = NewActuator(delegate, kubeAPIServerExposure, log.Log)
+			Expect(a.(inject.Client).InjectClient(c)).To(Succeed())
+
+			cluster = &extensionscontroller.Cluster{
+				Shoot: &gardencorev1beta1.Shoot{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{azure.MigrateLoadBalancerSKUAnnotation: "true"},
+					},
+				},
+			}
+			cp = &extensionsv1alpha1.ControlPlane{
+				ObjectMeta: metav1.ObjectMeta{Name: "control-plane", Namespace: ns},
+				Spec: extensionsv1alpha1.ControlPlaneSpec{
+					SecretRef: corev1.SecretReference{Name: "cloudprovider", Namespace: ns},
+					InfrastructureProviderStatus: &runtime.RawExtension{
+						Raw: encode(&v1alpha1.InfrastructureStatus{
+							TypeMeta:      metav1.TypeMeta{APIVersion: v1alpha1.SchemeGroupVersion.String(), Kind: "InfrastructureStatus"},
+							ResourceGroup: v1alpha1.ResourceGroup{Name: ns},
+						}),
+					},
+				},
+			}
+
+			c.EXPECT().Get(ctx, kutil.Key(ns, "control-plane"), cp).Return(nil).AnyTimes()
+			c.EXPECT().Status().Return(statusWriter).AnyTimes()
+			c.EXPECT().Get(ctx, kutil.Key(ns, azure.LoadBalancerMigrationBackupName), gomock.AssignableToTypeOf(&corev1.Secret{})).DoAndReturn(func(_ context.Context, _ client.ObjectKey, obj runtime.Object) error {
+				if backup == nil {
+					return apierrors.NewNotFound(corev1.Resource("secrets"), azure.LoadBalancerMigrationBackupName)
+				}
+				*obj.(*corev1.Secret) = *backup
+				return nil
+			}).AnyTimes()
+			c.EXPECT().Get(ctx, kutil.Key(ns, "addons"), gomock.AssignableToTypeOf(&resourcesv1alpha1.ManagedResource{})).DoAndReturn(func(_ context.Context, _ client.ObjectKey, obj runtime.Object) error {
+				*obj.(*resourcesv1alpha1.ManagedResource) = *managedResource.DeepCopy()
+				return nil
+			}).AnyTimes()
+			c.EXPECT().Update(ctx, gomock.AssignableToTypeOf(&resourcesv1alpha1.ManagedResource{})).DoAndReturn(func(_ context.Context, obj runtime.Object, _ ...client.UpdateOption) error {
+				managedResource = obj.(*resourcesv1alpha1.ManagedResource).DeepCopy()
+				ignoreUpdates = append(ignoreUpdates, managedResource.Annotations[resourcesv1alpha1.ResourceManagerIgnoreAnnotation] == "true")
+				return nil
+			}).AnyTimes()
+		})
+
+		AfterEach(func() {
+			ctrl.Finish()
+			newShootClient = oldNewShootClient
+		})
+
+		It("should not migrate the load balancers of a shoot without the annotation", func() {
+			cluster.Shoot.Annotations = nil
+
+			_, err := a.Reconcile(ctx, cp, cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(statusWriter.states).To(BeEmpty())
+		})
+
+		It("should not migrate the load balancers of a zoned shoot", func() {
+			cp.Spec.InfrastructureProviderStatus.Raw = encode(&v1alpha1.InfrastructureStatus{
+				TypeMeta: metav1.TypeMeta{APIVersion: v1alpha1.SchemeGroupVersion.String(), Kind: "InfrastructureStatus"},
+				Zoned:    true,
+			})
+
+			_, err := a.Reconcile(ctx, cp, cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(statusWriter.states).To(BeEmpty())
+		})
+
+		It("should back up and delete the services and wait until the load balancers are deleted", func() {
+			networkClient.loadBalancers = map[string]bool{ns + "/" + ns + "-internal": true}
+
+			seed := "seed"
+			c.EXPECT().List(ctx, &resourcesv1alpha1.ManagedResourceList{}, client.InNamespace(ns)).DoAndReturn(func(_ context.Context, list runtime.Object, _ ...client.ListOption) error {
+				list.(*resourcesv1alpha1.ManagedResourceList).Items = []resourcesv1alpha1.ManagedResource{
+					*managedResource,
+					{ObjectMeta: metav1.ObjectMeta{Name: "seed-resources", Namespace: ns}, Spec: resourcesv1alpha1.ManagedResourceSpec{Class: &seed}},
+					{ObjectMeta: metav1.ObjectMeta{Name: "ignored", Namespace: ns, Annotations: map[string]string{resourcesv1alpha1.ResourceManagerIgnoreAnnotation: "true"}}},
+				}
+				return nil
+			})
+			shootClient.EXPECT().List(ctx, &corev1.ServiceList{}).DoAndReturn(func(_ context.Context, list runtime.Object, _ ...client.ListOption) error {
+				list.(*corev1.ServiceList).Items = []corev1.Service{lbService, clusterIPService}
+				return nil
+			})
+			c.EXPECT().Create(ctx, gomock.AssignableToTypeOf(&corev1.Secret{})).DoAndReturn(func(_ context.Context, obj runtime.Object, _ ...client.CreateOption) error {
+				backup = obj.(*corev1.Secret).DeepCopy()
+				return nil
+			})
+			shootClient.EXPECT().Delete(ctx, gomock.AssignableToTypeOf(&corev1.Service{})).DoAndReturn(func(_ context.Context, obj runtime.Object, _ ...client.DeleteOption) error {
+				Expect(obj.(*corev1.Service).Name).To(Equal(lbService.Name))
+				return nil
+			})
+			shootClient.EXPECT().Delete(ctx, gomock.AssignableToTypeOf(&corev1.Service{})).Return(apierrors.NewNotFound(corev1.Resource("services"), lbService.Name))
+
+			_, err := a.Reconcile(ctx, cp, cluster)
+			Expect(err).To(BeAssignableToTypeOf(&controllererrors.RequeueAfterError{}))
+			Expect(statusWriter.states).To(Equal([]v1alpha1.LoadBalancerMigrationState{
+				v1alpha1.LoadBalancerMigrationStateManagedResourcesIgnored,
+				v1alpha1.LoadBalancerMigrationStateServicesBackedUp,
+				v1alpha1.LoadBalancerMigrationStateServicesDeleted,
+			}))
+			Expect(statusWriter.status.LoadBalancerMigration.IgnoredManagedResources).To(ConsistOf("addons"))
+			Expect(ignoreUpdates).To(Equal([]bool{true}))
+
+			var services []corev1.Service
+			Expect(json.Unmarshal(backup.Data[azure.LoadBalancerMigrationBackupKey], &services)).To(Succeed())
+			Expect(services).To(HaveLen(1))
+			Expect(services[0].Name).To(Equal(lbService.Name))
+			Expect(services[0].Spec.ClusterIP).To(BeEmpty())
+			Expect(services[0].Spec.Ports).To(Equal(lbService.Spec.Ports))
+		})
+
+		It("should resume the migration by switching the SKU and restoring the services", func() {
+			cp.Status.ProviderStatus = &runtime.RawExtension{
+				Raw: encode(&v1alpha1.ControlPlaneStatus{
+					TypeMeta: metav1.TypeMeta{APIVersion: v1alpha1.SchemeGroupVersion.String(), Kind: "ControlPlaneStatus"},
+					LoadBalancerMigration: &v1alpha1.LoadBalancerMigrationStatus{
+						State:                   v1alpha1.LoadBalancerMigrationStateServicesDeleted,
+						IgnoredManagedResources: []string{"addons"},
+					},
+				}),
+			}
+			backup = &corev1.Secret{
+				Data: map[string][]byte{azure.LoadBalancerMigrationBackupKey: encodeServices(lbService)},
+			}
+
+			// The service has been recreated by the gardener-resource-manager after a reconciliation of the shoot has
+			// removed the mark of the ManagedResource.
+			shootClient.EXPECT().Delete(ctx, gomock.AssignableToTypeOf(&corev1.Service{})).Return(nil)
+
+			c.EXPECT().Get(ctx, kutil.Key(ns, "cloud-controller-manager"), &appsv1.Deployment{}).DoAndReturn(getDeployment(&appsv1.Deployment{
+				Spec: appsv1.DeploymentSpec{Replicas: pointer.Int32Ptr(1)},
+				Status: appsv1.DeploymentStatus{
+					Replicas:        1,
+					UpdatedReplicas: 1,
+					Conditions:      []appsv1.DeploymentCondition{{Type: appsv1.DeploymentAvailable, Status: corev1.ConditionTrue}},
+				},
+			}))
+			shootClient.EXPECT().Create(ctx, gomock.AssignableToTypeOf(&corev1.Service{})).DoAndReturn(func(_ context.Context, obj runtime.Object, _ ...client.CreateOption) error {
+				Expect(obj.(*corev1.Service).Name).To(Equal(lbService.Name))
+				return apierrors.NewAlreadyExists(corev1.Resource("services"), lbService.Name)
+			})
+			c.EXPECT().Delete(ctx, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: azure.LoadBalancerMigrationBackupName, Namespace: ns}})
+
+			_, err := a.Reconcile(ctx, cp, cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(statusWriter.states).To(Equal([]v1alpha1.LoadBalancerMigrationState{
+				v1alpha1.LoadBalancerMigrationStateLoadBalancersDeleted,
+				v1alpha1.LoadBalancerMigrationStateSKUSwitched,
+				v1alpha1.LoadBalancerMigrationStateServicesRestored,
+				v1alpha1.LoadBalancerMigrationStateSucceeded,
+			}))
+			Expect(statusWriter.status.LoadBalancerMigration.IgnoredManagedResources).To(BeEmpty())
+			Expect(ignoreUpdates).To(Equal([]bool{true, false}))
+		})
+
+		It("should continue a started migration although the annotation has been removed", func() {
+			cluster.Shoot.Annotations = nil
+			cp.Status.ProviderStatus = &runtime.RawExtension{
+				Raw: encode(&v1alpha1.ControlPlaneStatus{
+					TypeMeta: metav1.TypeMeta{APIVersion: v1alpha1.SchemeGroupVersion.String(), Kind: "ControlPlaneStatus"},
+					LoadBalancerMigration: &v1alpha1.LoadBalancerMigrationStatus{
+						State:                   v1alpha1.LoadBalancerMigrationStateServicesDeleted,
+						IgnoredManagedResources: []string{"addons"},
+					},
+				}),
+			}
+			backup = &corev1.Secret{
+				Data: map[string][]byte{azure.LoadBalancerMigrationBackupKey: encodeServices(lbService)},
+			}
+			networkClient.loadBalancers = map[string]bool{ns + "/" + ns: true}
+
+			shootClient.EXPECT().Delete(ctx, gomock.AssignableToTypeOf(&corev1.Service{})).Return(apierrors.NewNotFound(corev1.Resource("services"), lbService.Name))
+
+			_, err := a.Reconcile(ctx, cp, cluster)
+			Expect(err).To(BeAssignableToTypeOf(&controllererrors.RequeueAfterError{}))
+			Expect(ignoreUpdates).To(Equal([]bool{true}))
+		})
+	})
+
+	Describe("#getPublicIPParameters", func() {
+		It("should return a static public IP with the default SKU", func() {
+			publicIP := getPublicIPParameters(kubeAPIServerExposure.PublicIP, "westeurope", nil)
+
+			Expect(*publicIP.Location).To(Equal("westeurope"))
+			Expect(publicIP.Sku.Name).To(Equal(network.PublicIPAddressSkuNameStandard))
+			Expect(publicIP.PublicIPAllocationMethod).To(Equal(network.Static))
+			Expect(publicIP.DNSSettings).To(BeNil())
+		})
+
+		It("should return a static public IP with the configured SKU and DNS label", func() {
+			kubeAPIServerExposure.PublicIP.SKU = pointer.StringPtr("Basic")
+
+			publicIP := getPublicIPParameters(kubeAPIServerExposure.PublicIP, "westeurope", pointer.StringPtr("my-api"))
+
+			Expect(publicIP.Sku.Name).To(Equal(network.PublicIPAddressSkuNameBasic))
+			Expect(publicIP.DNSSettings).To(Equal(&network.PublicIPAddressDNSSettings{DomainNameLabel: pointer.StringPtr("my-api")}))
+		})
+	})
+})
+
+func encodeServices(services ...corev1.Service) []byte {
+	data, _ := json.Marshal(services)
+	return data
+}
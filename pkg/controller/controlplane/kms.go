@@ -0,0 +1,102 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"context"
+
+	apisazure "github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure"
+	"github.com/gardener/gardener-extension-provider-azure/pkg/azure"
+
+	"github.com/gardener/gardener/pkg/operation/common"
+	etcdencryption "github.com/gardener/gardener/pkg/operation/etcdencryption"
+	kutil "github.com/gardener/gardener/pkg/utils/kubernetes"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apiserverconfigv1 "k8s.io/apiserver/pkg/apis/config/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// kmsPluginCacheSize is the number of data encryption keys which are cached by the kube-apiserver.
+const kmsPluginCacheSize = 1000
+
+// getKMSChartValues determines the values of the KMS plugin secret. The encryption configuration of Gardener is extended
+// by the KMS providers, so that the secrets which are still encrypted with the keys of Gardener can be decrypted.
+func (vp *valuesProvider) getKMSChartValues(ctx context.Context, kms *apisazure.KMS, namespace string) (map[string]interface{}, error) {
+	secret := &corev1.Secret{}
+	if err := vp.Client().Get(ctx, kutil.Key(namespace, common.EtcdEncryptionSecretName), secret); err != nil {
+		return nil, errors.Wrapf(err, "could not get etcd encryption secret '%s/%s'", namespace, common.EtcdEncryptionSecretName)
+	}
+
+	encryptionConfiguration, err := etcdencryption.ReadSecret(secret)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read etcd encryption configuration from secret '%s/%s'", namespace, common.EtcdEncryptionSecretName)
+	}
+
+	data, err := etcdencryption.Write(getKMSEncryptionConfiguration(kms, encryptionConfiguration))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not write kms encryption configuration")
+	}
+
+	values := map[string]interface{}{
+		"encryptionConfiguration": string(data),
+	}
+	if vp.kms != nil && vp.kms.ManagedIdentityClientID != nil {
+		values["managedIdentityClientId"] = *vp.kms.ManagedIdentityClientID
+	}
+
+	return values, nil
+}
+
+// getKMSEncryptionConfiguration prepends a KMS provider for the current and each previous key version to the providers
+// for secrets of the given encryption configuration. New secrets are encrypted with the current key version.
+func getKMSEncryptionConfiguration(kms *apisazure.KMS, encryptionConfiguration *apiserverconfigv1.EncryptionConfiguration) *apiserverconfigv1.EncryptionConfiguration {
+	var (
+		config       = encryptionConfiguration.DeepCopy()
+		kmsProviders []apiserverconfigv1.ProviderConfiguration
+	)
+
+	for _, keyVersion := range append([]string{kms.KeyVersion}, kms.PreviousKeyVersions...) {
+		kmsProviders = append(kmsProviders, apiserverconfigv1.ProviderConfiguration{
+			KMS: &apiserverconfigv1.KMSConfiguration{
+				Name:      azure.KMSPluginProviderName(keyVersion),
+				Endpoint:  azure.KMSPluginEndpoint(keyVersion),
+				CacheSize: kmsPluginCacheSize,
+			},
+		})
+	}
+
+	for i, resource := range config.Resources {
+		for _, name := range resource.Resources {
+			if name == common.EtcdEncryptionEncryptedResourceSecrets {
+				config.Resources[i].Providers = append(append([]apiserverconfigv1.ProviderConfiguration{}, kmsProviders...), resource.Providers...)
+				return config
+			}
+		}
+	}
+
+	config.Resources = append(config.Resources, apiserverconfigv1.ResourceConfiguration{
+		Resources: []string{common.EtcdEncryptionEncryptedResourceSecrets},
+		Providers: append(kmsProviders, apiserverconfigv1.ProviderConfiguration{Identity: &apiserverconfigv1.IdentityConfiguration{}}),
+	})
+	return config
+}
+
+func (vp *valuesProvider) removeKMSPluginSecret(ctx context.Context, namespace string) error {
+	secret := corev1.Secret{}
+	secret.SetName(azure.KMSPluginSecretName)
+	secret.SetNamespace(namespace)
+	return client.IgnoreNotFound(vp.Client().Delete(ctx, &secret))
+}
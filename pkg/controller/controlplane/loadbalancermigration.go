@@ -0,0 +1,373 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	apisazure "github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure"
+	azureapihelper "github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure/helper"
+	"github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure/v1alpha1"
+	"github.com/gardener/gardener-extension-provider-azure/pkg/azure"
+	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
+	controllererrors "github.com/gardener/gardener-extensions/pkg/controller/error"
+	"github.com/gardener/gardener-extensions/pkg/util"
+
+	resourcesv1alpha1 "github.com/gardener/gardener-resource-manager/pkg/apis/resources/v1alpha1"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	kutil "github.com/gardener/gardener/pkg/utils/kubernetes"
+	"github.com/gardener/gardener/pkg/utils/kubernetes/health"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// loadBalancerMigrationRequeueAfter is the duration after which the migration of the load balancers is continued if
+// it waits for the deletion of the Azure load balancers or the rollout of the cloud-controller-manager.
+const loadBalancerMigrationRequeueAfter = 30 * time.Second
+
+// newShootClient creates a client for the shoot whose control plane runs in the given namespace. It is a variable to be
+// able to replace it in the tests.
+var newShootClient = func(ctx context.Context, c client.Client, namespace string) (client.Client, error) {
+	_, shootClient, err := util.NewClientForShoot(ctx, c, namespace, client.Options{})
+	return shootClient, err
+}
+
+// migrateLoadBalancers migrates the load balancers of the shoot from the basic to the standard SKU if the shoot is
+// annotated accordingly. The services of type LoadBalancer are backed up and deleted, and once the Azure load balancers
+// are gone the cloud provider config is switched to the standard SKU and the services are restored. Every completed
+// step is recorded in the status of the controlplane, so that a failed migration is resumed with the step that failed.
+// A migration which has been started is completed even if the annotation is removed, otherwise the services would stay
+// deleted.
+func (a *actuator) migrateLoadBalancers(ctx context.Context, cp *extensionsv1alpha1.ControlPlane, cluster *extensionscontroller.Cluster) error {
+	if cluster.Shoot == nil || extensionscontroller.IsHibernated(cluster) {
+		return nil
+	}
+
+	// The load balancers of zoned shoots always have the standard SKU.
+	infraStatus, err := azureapihelper.InfrastructureStatusFromControlPlane(cp)
+	if err != nil {
+		return errors.Wrapf(err, "could not decode infrastructureProviderStatus of controlplane '%s'", util.ObjectName(cp))
+	}
+	if infraStatus == nil || infraStatus.Zoned {
+		return nil
+	}
+
+	status, err := azureapihelper.ControlPlaneStatusFromControlPlane(cp)
+	if err != nil {
+		return errors.Wrapf(err, "could not decode providerStatus of controlplane '%s'", util.ObjectName(cp))
+	}
+	migration := &apisazure.LoadBalancerMigrationStatus{}
+	if status != nil && status.LoadBalancerMigration != nil {
+		migration = status.LoadBalancerMigration.DeepCopy()
+	}
+	if migration.State == apisazure.LoadBalancerMigrationStateSucceeded ||
+		migration.State == "" && cluster.Shoot.Annotations[azure.MigrateLoadBalancerSKUAnnotation] != "true" {
+		return nil
+	}
+
+	shootClient, err := newShootClient(ctx, a.client, cp.Namespace)
+	if err != nil {
+		return errors.Wrap(err, "could not create shoot client")
+	}
+
+	for migration.State != apisazure.LoadBalancerMigrationStateSucceeded {
+		// A reconciliation of the shoot overwrites the annotations of the ManagedResources, hence they are marked as
+		// ignored again before every step.
+		if migration.State != "" {
+			if err := a.setManagedResourcesIgnored(ctx, cp.Namespace, migration.IgnoredManagedResources, true); err != nil {
+				return err
+			}
+		}
+
+		if err := a.migrateLoadBalancersStep(ctx, shootClient, cp, cluster, infraStatus, migration); err != nil {
+			return err
+		}
+
+		a.logger.Info("Migrating load balancers to the standard SKU", "controlplane", cp.Name, "namespace", cp.Namespace, "state", migration.State)
+		if err := a.updateLoadBalancerMigrationStatus(ctx, cp, migration); err != nil {
+			return errors.Wrapf(err, "could not update load balancer migration state of controlplane '%s'", util.ObjectName(cp))
+		}
+	}
+	return nil
+}
+
+// migrateLoadBalancersStep executes the step of the migration which follows the current state and advances the state.
+func (a *actuator) migrateLoadBalancersStep(
+	ctx context.Context,
+	shootClient client.Client,
+	cp *extensionsv1alpha1.ControlPlane,
+	cluster *extensionscontroller.Cluster,
+	infraStatus *apisazure.InfrastructureStatus,
+	migration *apisazure.LoadBalancerMigrationStatus,
+) error {
+	switch migration.State {
+	case "":
+		// The gardener-resource-manager would recreate the deleted services which it manages. The ManagedResources are
+		// recorded before they are marked as ignored, so that only those are unmarked after the migration.
+		names, err := a.getManagedResourcesToIgnore(ctx, cp.Namespace)
+		if err != nil {
+			return err
+		}
+		migration.IgnoredManagedResources = names
+		migration.State = apisazure.LoadBalancerMigrationStateManagedResourcesIgnored
+
+	case apisazure.LoadBalancerMigrationStateManagedResourcesIgnored:
+		if err := a.backupLoadBalancerServices(ctx, shootClient, cp.Namespace); err != nil {
+			return err
+		}
+		migration.State = apisazure.LoadBalancerMigrationStateServicesBackedUp
+
+	case apisazure.LoadBalancerMigrationStateServicesBackedUp:
+		if err := a.deleteLoadBalancerServices(ctx, shootClient, cp.Namespace); err != nil {
+			return err
+		}
+		migration.State = apisazure.LoadBalancerMigrationStateServicesDeleted
+
+	case apisazure.LoadBalancerMigrationStateServicesDeleted:
+		// Services which have been recreated in the meantime would keep the load balancers with the basic SKU.
+		if err := a.deleteLoadBalancerServices(ctx, shootClient, cp.Namespace); err != nil {
+			return err
+		}
+		if err := a.waitForLoadBalancersDeleted(ctx, cp, infraStatus); err != nil {
+			return err
+		}
+		migration.State = apisazure.LoadBalancerMigrationStateLoadBalancersDeleted
+
+	case apisazure.LoadBalancerMigrationStateLoadBalancersDeleted:
+		// The state has already been recorded, hence the cloud provider config is rendered with the standard SKU.
+		if _, err := a.Actuator.Reconcile(ctx, cp, cluster); err != nil {
+			return err
+		}
+		if err := a.waitForCloudControllerManager(ctx, cp.Namespace); err != nil {
+			return err
+		}
+		migration.State = apisazure.LoadBalancerMigrationStateSKUSwitched
+
+	case apisazure.LoadBalancerMigrationStateSKUSwitched:
+		services, err := a.getLoadBalancerServicesBackup(ctx, cp.Namespace)
+		if err != nil {
+			return err
+		}
+		for _, svc := range services {
+			if err := shootClient.Create(ctx, svc.DeepCopy()); err != nil && !apierrors.IsAlreadyExists(err) {
+				return errors.Wrapf(err, "could not restore service '%s'", util.ObjectName(&svc))
+			}
+		}
+		migration.State = apisazure.LoadBalancerMigrationStateServicesRestored
+
+	case apisazure.LoadBalancerMigrationStateServicesRestored:
+		if err := a.setManagedResourcesIgnored(ctx, cp.Namespace, migration.IgnoredManagedResources, false); err != nil {
+			return err
+		}
+
+		backup := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: azure.LoadBalancerMigrationBackupName, Namespace: cp.Namespace}}
+		if err := client.IgnoreNotFound(a.client.Delete(ctx, backup)); err != nil {
+			return errors.Wrapf(err, "could not delete secret '%s'", util.ObjectName(backup))
+		}
+		migration.IgnoredManagedResources = nil
+		migration.State = apisazure.LoadBalancerMigrationStateSucceeded
+
+	default:
+		return errors.Errorf("unknown load balancer migration state '%s'", migration.State)
+	}
+	return nil
+}
+
+// getManagedResourcesToIgnore returns the names of the ManagedResources in the given namespace which are reconciled
+// into the shoot and which are not ignored yet.
+func (a *actuator) getManagedResourcesToIgnore(ctx context.Context, namespace string) ([]string, error) {
+	managedResourceList := &resourcesv1alpha1.ManagedResourceList{}
+	if err := a.client.List(ctx, managedResourceList, client.InNamespace(namespace)); err != nil {
+		return nil, errors.Wrapf(err, "could not list managed resources in namespace '%s'", namespace)
+	}
+
+	var names []string
+	for _, managedResource := range managedResourceList.Items {
+		// ManagedResources with a class are reconciled into the seed.
+		if managedResource.Spec.Class != nil || managedResource.Annotations[resourcesv1alpha1.ResourceManagerIgnoreAnnotation] == "true" {
+			continue
+		}
+		names = append(names, managedResource.Name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// setManagedResourcesIgnored marks the ManagedResources with the given names in the given namespace as ignored by the
+// gardener-resource-manager or removes the mark.
+func (a *actuator) setManagedResourcesIgnored(ctx context.Context, namespace string, names []string, ignored bool) error {
+	for _, name := range names {
+		managedResource := &resourcesv1alpha1.ManagedResource{}
+		if err := a.client.Get(ctx, kutil.Key(namespace, name), managedResource); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return errors.Wrapf(err, "could not get managed resource '%s/%s'", namespace, name)
+		}
+
+		if (managedResource.Annotations[resourcesv1alpha1.ResourceManagerIgnoreAnnotation] == "true") == ignored {
+			continue
+		}
+		if ignored {
+			metav1.SetMetaDataAnnotation(&managedResource.ObjectMeta, resourcesv1alpha1.ResourceManagerIgnoreAnnotation, "true")
+		} else {
+			delete(managedResource.Annotations, resourcesv1alpha1.ResourceManagerIgnoreAnnotation)
+		}
+		if err := a.client.Update(ctx, managedResource); err != nil {
+			return errors.Wrapf(err, "could not update managed resource '%s/%s'", namespace, name)
+		}
+	}
+	return nil
+}
+
+// deleteLoadBalancerServices deletes the services of the shoot which are stored in the load balancer migration backup
+// secret in the given namespace.
+func (a *actuator) deleteLoadBalancerServices(ctx context.Context, shootClient client.Client, namespace string) error {
+	services, err := a.getLoadBalancerServicesBackup(ctx, namespace)
+	if err != nil {
+		return err
+	}
+	for _, svc := range services {
+		if err := client.IgnoreNotFound(shootClient.Delete(ctx, svc.DeepCopy())); err != nil {
+			return errors.Wrapf(err, "could not delete service '%s'", util.ObjectName(&svc))
+		}
+	}
+	return nil
+}
+
+// backupLoadBalancerServices stores the services of type LoadBalancer of the shoot in the load balancer migration
+// backup secret in the given namespace. The fields which are set by Kubernetes are not stored.
+func (a *actuator) backupLoadBalancerServices(ctx context.Context, shootClient client.Client, namespace string) error {
+	serviceList := &corev1.ServiceList{}
+	if err := shootClient.List(ctx, serviceList); err != nil {
+		return errors.Wrap(err, "could not list services of shoot")
+	}
+
+	services := []corev1.Service{}
+	for _, svc := range serviceList.Items {
+		if svc.Spec.Type != corev1.ServiceTypeLoadBalancer {
+			continue
+		}
+
+		spec := svc.Spec.DeepCopy()
+		spec.ClusterIP = ""
+		services = append(services, corev1.Service{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        svc.Name,
+				Namespace:   svc.Namespace,
+				Labels:      svc.Labels,
+				Annotations: svc.Annotations,
+			},
+			Spec: *spec,
+		})
+	}
+
+	data, err := json.Marshal(services)
+	if err != nil {
+		return err
+	}
+
+	backup := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: azure.LoadBalancerMigrationBackupName, Namespace: namespace}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, a.client, backup, func() error {
+		backup.Data = map[string][]byte{
+			azure.LoadBalancerMigrationBackupKey: data,
+		}
+		return nil
+	}); err != nil {
+		return errors.Wrapf(err, "could not store backup of services in secret '%s'", util.ObjectName(backup))
+	}
+	return nil
+}
+
+// getLoadBalancerServicesBackup returns the services stored in the load balancer migration backup secret in the given
+// namespace.
+func (a *actuator) getLoadBalancerServicesBackup(ctx context.Context, namespace string) ([]corev1.Service, error) {
+	backup := &corev1.Secret{}
+	if err := a.client.Get(ctx, kutil.Key(namespace, azure.LoadBalancerMigrationBackupName), backup); err != nil {
+		return nil, errors.Wrapf(err, "could not get secret '%s/%s'", namespace, azure.LoadBalancerMigrationBackupName)
+	}
+
+	var services []corev1.Service
+	if err := json.Unmarshal(backup.Data[azure.LoadBalancerMigrationBackupKey], &services); err != nil {
+		return nil, errors.Wrapf(err, "could not decode backup of services in secret '%s/%s'", namespace, azure.LoadBalancerMigrationBackupName)
+	}
+	return services, nil
+}
+
+// waitForLoadBalancersDeleted returns an error which requeues the reconciliation as long as the public or the internal
+// Azure load balancer of the shoot exists. Both are named after the cluster name of the cloud-controller-manager.
+func (a *actuator) waitForLoadBalancersDeleted(ctx context.Context, cp *extensionsv1alpha1.ControlPlane, infraStatus *apisazure.InfrastructureStatus) error {
+	networkClient, err := newNetworkClient(ctx, a.client, &cp.Spec.SecretRef)
+	if err != nil {
+		return errors.Wrap(err, "could not create network client")
+	}
+
+	for _, name := range []string{cp.Namespace, fmt.Sprintf("%s-internal", cp.Namespace)} {
+		loadBalancer, err := networkClient.GetLoadBalancer(ctx, infraStatus.ResourceGroup.Name, name)
+		if err != nil {
+			return errors.Wrapf(err, "could not get load balancer '%s/%s'", infraStatus.ResourceGroup.Name, name)
+		}
+		if loadBalancer != nil {
+			return &controllererrors.RequeueAfterError{
+				Cause:        errors.Errorf("waiting until load balancer '%s/%s' is deleted", infraStatus.ResourceGroup.Name, name),
+				RequeueAfter: loadBalancerMigrationRequeueAfter,
+			}
+		}
+	}
+	return nil
+}
+
+// waitForCloudControllerManager returns an error which requeues the reconciliation as long as the
+// cloud-controller-manager in the given namespace has not been rolled out.
+func (a *actuator) waitForCloudControllerManager(ctx context.Context, namespace string) error {
+	deployment := &appsv1.Deployment{}
+	if err := a.client.Get(ctx, kutil.Key(namespace, azure.CloudControllerManagerName), deployment); err != nil {
+		return errors.Wrapf(err, "could not get deployment '%s/%s'", namespace, azure.CloudControllerManagerName)
+	}
+
+	// Replicas with the former cloud provider config would still create load balancers with the basic SKU.
+	err := health.CheckDeployment(deployment)
+	if err == nil && (deployment.Status.UpdatedReplicas != deployment.Status.Replicas ||
+		deployment.Spec.Replicas != nil && deployment.Status.UpdatedReplicas < *deployment.Spec.Replicas) {
+		err = errors.Errorf("%d of %d replicas updated", deployment.Status.UpdatedReplicas, deployment.Status.Replicas)
+	}
+	if err != nil {
+		return &controllererrors.RequeueAfterError{
+			Cause:        errors.Wrapf(err, "waiting until deployment '%s/%s' is rolled out", namespace, azure.CloudControllerManagerName),
+			RequeueAfter: loadBalancerMigrationRequeueAfter,
+		}
+	}
+	return nil
+}
+
+func (a *actuator) updateLoadBalancerMigrationStatus(ctx context.Context, cp *extensionsv1alpha1.ControlPlane, migration *apisazure.LoadBalancerMigrationStatus) error {
+	return a.updateStatus(ctx, cp, func(status *v1alpha1.ControlPlaneStatus) {
+		status.LoadBalancerMigration = &v1alpha1.LoadBalancerMigrationStatus{
+			State:                   v1alpha1.LoadBalancerMigrationState(migration.State),
+			LastUpdateTime:          metav1.Now(),
+			IgnoredManagedResources: migration.IgnoredManagedResources,
+		}
+	})
+}
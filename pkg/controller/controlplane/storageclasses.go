@@ -0,0 +1,353 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	apisazure "github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure"
+	"github.com/gardener/gardener-extensions/pkg/util"
+
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	kutil "github.com/gardener/gardener/pkg/utils/kubernetes"
+	versionutils "github.com/gardener/gardener/pkg/utils/version"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	diskCSIProvisioner    = "disk.csi.azure.com"
+	fileCSIProvisioner    = "file.csi.azure.com"
+	diskInTreeProvisioner = "kubernetes.io/azure-disk"
+	fileInTreeProvisioner = "kubernetes.io/azure-file"
+
+	diskCSIZoneTopologyKey    = "topology.disk.csi.azure.com/zone"
+	diskInTreeZoneTopologyKey = "failure-domain.beta.kubernetes.io/zone"
+
+	annotationDefaultStorageClass = "storageclass.kubernetes.io/is-default-class"
+
+	// defaultStorageClassName is the name of the storage class provided by Gardener which is the default storage class
+	// of the cluster unless another one is configured.
+	defaultStorageClassName = "default"
+	// defaultVolumeSnapshotClassName is the name of the default volume snapshot class for Azure managed disks.
+	defaultVolumeSnapshotClassName = "default"
+)
+
+// managedDiskStorageClass is a storage class for Azure managed disks which is provided by Gardener.
+type managedDiskStorageClass struct {
+	name              string
+	skuName           string
+	minimumK8sVersion string
+}
+
+var managedDiskStorageClasses = []managedDiskStorageClass{
+	{name: defaultStorageClassName, skuName: "Standard_LRS"},
+	{name: "managed-standard-hdd", skuName: "Standard_LRS"},
+	{name: "managed-standard-ssd", skuName: "StandardSSD_LRS", minimumK8sVersion: "1.13"},
+	{name: "managed-premium-ssd", skuName: "Premium_LRS"},
+}
+
+// getStorageClasses determines the storage classes of the cluster, i.e. the storage classes provided by Gardener unless
+// they are disabled and the additional storage classes of the ControlPlaneConfig.
+func getStorageClasses(
+	cpConfig *apisazure.ControlPlaneConfig,
+	infraConfig *apisazure.InfrastructureConfig,
+	region string,
+	kubernetesVersion string,
+	useCSI bool,
+) ([]*storagev1.StorageClass, error) {
+	var (
+		storage             = cpConfig.Storage
+		zoned               = infraConfig != nil && infraConfig.Zoned
+		diskEncryptionSetID *string
+		storageClasses      []*storagev1.StorageClass
+	)
+
+	if storage == nil {
+		storage = &apisazure.Storage{}
+	}
+	if infraConfig != nil && infraConfig.DiskEncryption != nil {
+		diskEncryptionSetID = infraConfig.DiskEncryption.DiskEncryptionSetID
+	}
+
+	// The storage classes provided by Gardener keep the binding mode they had before the storage classes became
+	// configurable, as changing it would recreate them in existing clusters. Volumes of additional storage classes of
+	// zoned clusters must be provisioned in the zone of the node of the pod which uses them.
+	managedVolumeBindingMode := storagev1.VolumeBindingImmediate
+	if useCSI {
+		managedVolumeBindingMode = storagev1.VolumeBindingWaitForFirstConsumer
+	}
+	defaultVolumeBindingMode := managedVolumeBindingMode
+	if zoned {
+		defaultVolumeBindingMode = storagev1.VolumeBindingWaitForFirstConsumer
+	}
+
+	if storage.ManagedStorageClasses == nil || *storage.ManagedStorageClasses {
+		for _, sc := range managedDiskStorageClasses {
+			if sc.minimumK8sVersion != "" {
+				ok, err := versionutils.CompareVersions(kubernetesVersion, ">=", sc.minimumK8sVersion)
+				if err != nil {
+					return nil, err
+				}
+				if !ok {
+					continue
+				}
+			}
+
+			storageClasses = append(storageClasses, newDiskStorageClass(apisazure.StorageClass{
+				Name:                sc.name,
+				SkuName:             sc.skuName,
+				VolumeBindingMode:   &managedVolumeBindingMode,
+				DiskEncryptionSetID: diskEncryptionSetID,
+			}, region, useCSI))
+		}
+
+		storageClasses = append(storageClasses, newFileStorageClass(useCSI))
+	}
+
+	for _, sc := range storage.StorageClasses {
+		if sc.VolumeBindingMode == nil {
+			sc.VolumeBindingMode = &defaultVolumeBindingMode
+		}
+		if sc.DiskEncryptionSetID == nil {
+			sc.DiskEncryptionSetID = diskEncryptionSetID
+		}
+		storageClasses = append(storageClasses, newDiskStorageClass(sc, region, useCSI))
+	}
+
+	defaultStorageClass := defaultStorageClassName
+	if storage.DefaultStorageClass != nil {
+		defaultStorageClass = *storage.DefaultStorageClass
+	}
+	for _, sc := range storageClasses {
+		if sc.Name == defaultStorageClass {
+			metav1.SetMetaDataAnnotation(&sc.ObjectMeta, annotationDefaultStorageClass, "true")
+		}
+	}
+
+	return storageClasses, nil
+}
+
+func newDiskStorageClass(sc apisazure.StorageClass, region string, useCSI bool) *storagev1.StorageClass {
+	var (
+		provisioner          = diskInTreeProvisioner
+		zoneTopologyKey      = diskInTreeZoneTopologyKey
+		reclaimPolicy        = corev1.PersistentVolumeReclaimDelete
+		allowedTopologies    []corev1.TopologySelectorTerm
+		allowVolumeExpansion *bool
+		parameters           = map[string]string{
+			"storageaccounttype": sc.SkuName,
+			"kind":               "managed",
+		}
+	)
+
+	if useCSI {
+		provisioner = diskCSIProvisioner
+		zoneTopologyKey = diskCSIZoneTopologyKey
+		allowVolumeExpansion = util.BoolPtr(true)
+	}
+	if sc.ReclaimPolicy != nil {
+		reclaimPolicy = *sc.ReclaimPolicy
+	}
+	if sc.CachingMode != nil {
+		parameters["cachingmode"] = *sc.CachingMode
+	}
+	if sc.DiskEncryptionSetID != nil {
+		parameters["diskEncryptionSetID"] = *sc.DiskEncryptionSetID
+	}
+
+	if len(sc.Zones) > 0 {
+		var zones []string
+		for _, zone := range sc.Zones {
+			zones = append(zones, fmt.Sprintf("%s-%s", region, zone))
+		}
+		allowedTopologies = []corev1.TopologySelectorTerm{{
+			MatchLabelExpressions: []corev1.TopologySelectorLabelRequirement{{
+				Key:    zoneTopologyKey,
+				Values: zones,
+			}},
+		}}
+	}
+
+	return &storagev1.StorageClass{
+		ObjectMeta:           metav1.ObjectMeta{Name: sc.Name},
+		Provisioner:          provisioner,
+		Parameters:           parameters,
+		ReclaimPolicy:        &reclaimPolicy,
+		VolumeBindingMode:    sc.VolumeBindingMode,
+		AllowVolumeExpansion: allowVolumeExpansion,
+		AllowedTopologies:    allowedTopologies,
+	}
+}
+
+func newFileStorageClass(useCSI bool) *storagev1.StorageClass {
+	var (
+		provisioner          = fileInTreeProvisioner
+		reclaimPolicy        = corev1.PersistentVolumeReclaimDelete
+		volumeBindingMode    = storagev1.VolumeBindingImmediate
+		allowVolumeExpansion *bool
+	)
+
+	if useCSI {
+		provisioner = fileCSIProvisioner
+		allowVolumeExpansion = util.BoolPtr(true)
+	}
+
+	return &storagev1.StorageClass{
+		ObjectMeta:           metav1.ObjectMeta{Name: "files"},
+		Provisioner:          provisioner,
+		Parameters:           map[string]string{"skuName": "Standard_LRS"},
+		ReclaimPolicy:        &reclaimPolicy,
+		VolumeBindingMode:    &volumeBindingMode,
+		AllowVolumeExpansion: allowVolumeExpansion,
+	}
+}
+
+// getStorageClassesChartValues collects and returns the storage classes chart values.
+func getStorageClassesChartValues(storageClasses []*storagev1.StorageClass) map[string]interface{} {
+	var values []interface{}
+
+	for _, sc := range storageClasses {
+		parameters := map[string]interface{}{}
+		for key, value := range sc.Parameters {
+			parameters[key] = value
+		}
+
+		storageClass := map[string]interface{}{
+			"name":              sc.Name,
+			"default":           sc.Annotations[annotationDefaultStorageClass] == "true",
+			"provisioner":       sc.Provisioner,
+			"parameters":        parameters,
+			"reclaimPolicy":     string(*sc.ReclaimPolicy),
+			"volumeBindingMode": string(*sc.VolumeBindingMode),
+		}
+		if sc.AllowVolumeExpansion != nil {
+			storageClass["allowVolumeExpansion"] = *sc.AllowVolumeExpansion
+		}
+		if len(sc.AllowedTopologies) > 0 {
+			var allowedTopologies []interface{}
+			for _, term := range sc.AllowedTopologies {
+				var expressions []interface{}
+				for _, requirement := range term.MatchLabelExpressions {
+					var values []interface{}
+					for _, value := range requirement.Values {
+						values = append(values, value)
+					}
+					expressions = append(expressions, map[string]interface{}{
+						"key":    requirement.Key,
+						"values": values,
+					})
+				}
+				allowedTopologies = append(allowedTopologies, map[string]interface{}{
+					"matchLabelExpressions": expressions,
+				})
+			}
+			storageClass["allowedTopologies"] = allowedTopologies
+		}
+
+		values = append(values, storageClass)
+	}
+
+	return map[string]interface{}{
+		"storageClasses": values,
+	}
+}
+
+// getVolumeSnapshotClassChartValues returns the chart values of the default volume snapshot class for Azure managed
+// disks. Snapshots are incremental unless configured otherwise in the ControlPlaneConfig.
+func getVolumeSnapshotClassChartValues(cpConfig *apisazure.ControlPlaneConfig) map[string]interface{} {
+	var (
+		incremental = true
+		parameters  = map[string]interface{}{}
+	)
+
+	if cpConfig.Storage != nil && cpConfig.Storage.VolumeSnapshotClass != nil {
+		volumeSnapshotClass := cpConfig.Storage.VolumeSnapshotClass
+		if volumeSnapshotClass.Incremental != nil {
+			incremental = *volumeSnapshotClass.Incremental
+		}
+		if volumeSnapshotClass.ResourceGroup != nil {
+			parameters["resourceGroup"] = *volumeSnapshotClass.ResourceGroup
+		}
+	}
+	parameters["incremental"] = strconv.FormatBool(incremental)
+
+	return map[string]interface{}{
+		"name":       defaultVolumeSnapshotClassName,
+		"driver":     diskCSIProvisioner,
+		"parameters": parameters,
+	}
+}
+
+// removeChangedStorageClasses deletes the storage classes of the shoot whose immutable settings differ from the given
+// ones. Nothing is done if the kube-apiserver of the shoot is not available, e.g. while the shoot is created or
+// hibernated.
+func (vp *valuesProvider) removeChangedStorageClasses(ctx context.Context, namespace string, storageClasses []*storagev1.StorageClass) error {
+	kubeAPIServer := &appsv1.Deployment{}
+	if err := vp.Client().Get(ctx, kutil.Key(namespace, v1beta1constants.DeploymentNameKubeAPIServer), kubeAPIServer); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "could not get deployment '%s/%s'", namespace, v1beta1constants.DeploymentNameKubeAPIServer)
+	}
+	if kubeAPIServer.Status.AvailableReplicas == 0 {
+		return nil
+	}
+
+	_, shootClient, err := util.NewClientForShoot(ctx, vp.Client(), namespace, client.Options{})
+	if err != nil {
+		return errors.Wrap(err, "could not create shoot client")
+	}
+
+	existingStorageClasses := &storagev1.StorageClassList{}
+	if err := shootClient.List(ctx, existingStorageClasses); err != nil {
+		return err
+	}
+
+	desiredStorageClasses := make(map[string]*storagev1.StorageClass, len(storageClasses))
+	for _, sc := range storageClasses {
+		desiredStorageClasses[sc.Name] = sc
+	}
+
+	for _, existing := range existingStorageClasses.Items {
+		desired, ok := desiredStorageClasses[existing.Name]
+		if !ok || !storageClassChanged(&existing, desired) {
+			continue
+		}
+		if err := client.IgnoreNotFound(shootClient.Delete(ctx, existing.DeepCopy())); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// storageClassChanged checks whether the immutable settings of the given existing storage class differ from those of
+// the desired one.
+func storageClassChanged(existing, desired *storagev1.StorageClass) bool {
+	return existing.Provisioner != desired.Provisioner ||
+		!equality.Semantic.DeepEqual(existing.Parameters, desired.Parameters) ||
+		!equality.Semantic.DeepEqual(existing.ReclaimPolicy, desired.ReclaimPolicy) ||
+		!equality.Semantic.DeepEqual(existing.VolumeBindingMode, desired.VolumeBindingMode) ||
+		!equality.Semantic.DeepEqual(existing.AllowedTopologies, desired.AllowedTopologies)
+}
@@ -16,10 +16,13 @@ package controlplane
 
 import (
 	"context"
+	"math"
 	"path/filepath"
+	"strings"
 
 	apisazure "github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure"
 	azureapihelper "github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure/helper"
+	"github.com/gardener/gardener-extension-provider-azure/pkg/apis/config"
 	"github.com/gardener/gardener-extension-provider-azure/pkg/azure"
 	"github.com/gardener/gardener-extension-provider-azure/pkg/internal"
 	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
@@ -31,6 +34,7 @@ import (
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 	"github.com/gardener/gardener/pkg/utils/chart"
 	"github.com/gardener/gardener/pkg/utils/secrets"
+	versionutils "github.com/gardener/gardener/pkg/utils/version"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	appsv1 "k8s.io/api/apps/v1"
@@ -44,6 +48,25 @@ import (
 const (
 	cloudControllerManagerDeploymentName = "cloud-controller-manager"
 	cloudControllerManagerServerName     = "cloud-controller-manager-server"
+	csiDriverControllerName              = "csi-driver-controller"
+	csiSnapshotControllerName            = "csi-snapshot-controller"
+)
+
+// Defaults for the rate limiting and the backoff of the requests of the cloud provider to the Azure API which are used
+// if neither the ControlPlaneConfig nor the controller configuration specifies them.
+const (
+	defaultRateLimitMinQPS        = 10
+	defaultRateLimitBucket        = 100
+	defaultBackoffRetries         = 6
+	defaultBackoffExponent        = 1.5
+	defaultBackoffDurationSeconds = 5
+	defaultBackoffJitter          = 1.0
+)
+
+// SKUs of the load balancers of the services of type LoadBalancer in the cloud provider config.
+const (
+	loadBalancerSKUBasic    = "basic"
+	loadBalancerSKUStandard = "standard"
 )
 
 var controlPlaneSecrets = &secrets.Secrets{
@@ -78,6 +101,30 @@ var controlPlaneSecrets = &secrets.Secrets{
 					SigningCA:  cas[v1beta1constants.SecretNameCACluster],
 				},
 			},
+			&secrets.ControlPlaneSecretConfig{
+				CertificateSecretConfig: &secrets.CertificateSecretConfig{
+					Name:       csiDriverControllerName,
+					CommonName: "system:" + csiDriverControllerName,
+					CertType:   secrets.ClientCert,
+					SigningCA:  cas[v1beta1constants.SecretNameCACluster],
+				},
+				KubeConfigRequest: &secrets.KubeConfigRequest{
+					ClusterName:  clusterName,
+					APIServerURL: v1beta1constants.DeploymentNameKubeAPIServer,
+				},
+			},
+			&secrets.ControlPlaneSecretConfig{
+				CertificateSecretConfig: &secrets.CertificateSecretConfig{
+					Name:       csiSnapshotControllerName,
+					CommonName: "system:" + csiSnapshotControllerName,
+					CertType:   secrets.ClientCert,
+					SigningCA:  cas[v1beta1constants.SecretNameCACluster],
+				},
+				KubeConfigRequest: &secrets.KubeConfigRequest{
+					ClusterName:  clusterName,
+					APIServerURL: v1beta1constants.DeploymentNameKubeAPIServer,
+				},
+			},
 		}
 	},
 }
@@ -94,12 +141,16 @@ var configChart = &chart.Chart{
 			Type: &corev1.ConfigMap{},
 			Name: azure.CloudProviderKubeletConfigName,
 		},
+		{
+			Type: &corev1.Secret{},
+			Name: azure.KMSPluginSecretName,
+		},
 	},
 }
 
 var ccmChart = &chart.Chart{
 	Name:   "cloud-controller-manager",
-	Path:   filepath.Join(azure.InternalChartsPath, "cloud-controller-manager"),
+	Path:   filepath.Join(azure.InternalChartsPath, "seed-controlplane", "charts", "cloud-controller-manager"),
 	Images: []string{azure.CloudControllerManagerImageName},
 	Objects: []*chart.Object{
 		{Type: &corev1.Service{}, Name: "cloud-controller-manager"},
@@ -108,6 +159,32 @@ var ccmChart = &chart.Chart{
 	},
 }
 
+var csiDriverControllerChart = &chart.Chart{
+	Name: "csi-driver-controller",
+	Path: filepath.Join(azure.InternalChartsPath, "seed-controlplane", "charts", "csi-driver-controller"),
+	Images: []string{
+		azure.CSIDriverDiskImageName,
+		azure.CSIDriverFileImageName,
+		azure.CSIProvisionerImageName,
+		azure.CSIAttacherImageName,
+		azure.CSIResizerImageName,
+		azure.CSISnapshotterImageName,
+		azure.CSISnapshotControllerImageName,
+		azure.CSILivenessProbeImageName,
+	},
+	Objects: []*chart.Object{
+		{Type: &appsv1.Deployment{}, Name: azure.CSIControllerDiskName},
+		{Type: &appsv1.Deployment{}, Name: azure.CSIControllerFileName},
+		{Type: &appsv1.Deployment{}, Name: azure.CSISnapshotControllerName},
+	},
+}
+
+var controlPlaneChart = &chart.Chart{
+	Name:      "seed-controlplane",
+	Path:      filepath.Join(azure.InternalChartsPath, "seed-controlplane"),
+	SubCharts: []*chart.Chart{ccmChart, csiDriverControllerChart},
+}
+
 var ccmShootChart = &chart.Chart{
 	Name: "cloud-controller-manager-shoot",
 	Path: filepath.Join(azure.InternalChartsPath, "controlplane-shoot", "cloud-controller-manager-shoot"),
@@ -122,10 +199,36 @@ var allowUDPEgressChart = &chart.Chart{
 	Path: filepath.Join(azure.InternalChartsPath, "controlplane-shoot", "allow-udp-egress"),
 }
 
+var csiDriverShootChart = &chart.Chart{
+	Name: "csi-driver",
+	Path: filepath.Join(azure.InternalChartsPath, "controlplane-shoot", "csi-driver"),
+	Images: []string{
+		azure.CSIDriverDiskImageName,
+		azure.CSIDriverFileImageName,
+		azure.CSINodeDriverRegistrarImageName,
+		azure.CSILivenessProbeImageName,
+	},
+}
+
 var controlPlaneShootChart = &chart.Chart{
 	Name:      "controlplane-shoot",
 	Path:      filepath.Join(azure.InternalChartsPath, "controlplane-shoot"),
-	SubCharts: []*chart.Chart{ccmShootChart, allowUDPEgressChart},
+	SubCharts: []*chart.Chart{ccmShootChart, cloudNodeManagerChart, allowUDPEgressChart, csiDriverShootChart, scheduledEventsHandlerChart},
+}
+
+var cloudNodeManagerChart = &chart.Chart{
+	Name:   "cloud-node-manager",
+	Path:   filepath.Join(azure.InternalChartsPath, "controlplane-shoot", "cloud-node-manager"),
+	Images: []string{azure.CloudNodeManagerImageName},
+	Objects: []*chart.Object{
+		{Type: &appsv1.DaemonSet{}, Name: azure.CloudNodeManagerName},
+	},
+}
+
+var scheduledEventsHandlerChart = &chart.Chart{
+	Name:   "scheduled-events-handler",
+	Path:   filepath.Join(azure.InternalChartsPath, "controlplane-shoot", "scheduled-events-handler"),
+	Images: []string{azure.ScheduledEventsHandlerImageName},
 }
 
 var storageClassChart = &chart.Chart{
@@ -134,16 +237,20 @@ var storageClassChart = &chart.Chart{
 }
 
 // NewValuesProvider creates a new ValuesProvider for the generic actuator.
-func NewValuesProvider(logger logr.Logger) genericactuator.ValuesProvider {
+func NewValuesProvider(cloudControllerManager *config.CloudControllerManagerConfig, kms *config.KMSConfig, logger logr.Logger) genericactuator.ValuesProvider {
 	return &valuesProvider{
-		logger: logger.WithName("azure-values-provider"),
+		cloudControllerManager: cloudControllerManager,
+		kms:                    kms,
+		logger:                 logger.WithName("azure-values-provider"),
 	}
 }
 
 // valuesProvider is a ValuesProvider that provides azure-specific values for the 2 charts applied by the generic actuator.
 type valuesProvider struct {
 	genericactuator.NoopValuesProvider
-	logger logr.Logger
+	cloudControllerManager *config.CloudControllerManagerConfig
+	kms                    *config.KMSConfig
+	logger                 logr.Logger
 }
 
 // GetConfigChartValues returns the values for the config chart applied by the generic actuator.
@@ -180,7 +287,26 @@ func (vp *valuesProvider) GetConfigChartValues(
 	}
 
 	// Get config chart values
-	return getConfigChartValues(infraStatus, cp, cluster, auth)
+	values, err := getConfigChartValues(cpConfig, vp.cloudControllerManager, infraStatus, cp, cluster, auth)
+	if err != nil {
+		return nil, err
+	}
+
+	// The KMS plugin secret is only needed if the secrets in etcd are encrypted with a key of an Azure Key Vault.
+	if cpConfig.KMS == nil {
+		if err := vp.removeKMSPluginSecret(ctx, cp.Namespace); err != nil {
+			return nil, errors.Wrap(err, "could not remove kms plugin secret")
+		}
+		return values, nil
+	}
+
+	kms, err := vp.getKMSChartValues(ctx, cpConfig.KMS, cp.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	values["kms"] = kms
+
+	return values, nil
 }
 
 // GetControlPlaneChartValues returns the values for the control plane chart applied by the generic actuator.
@@ -199,8 +325,8 @@ func (vp *valuesProvider) GetControlPlaneChartValues(
 		}
 	}
 
-	// Get CCM chart values
-	return getCCMChartValues(cpConfig, cp, cluster, checksums, scaledDown)
+	// Get control plane chart values
+	return getControlPlaneChartValues(cpConfig, cp, cluster, checksums, scaledDown)
 }
 
 // GetControlPlaneShootChartValues returns the values for the control plane shoot chart applied by the generic actuator.
@@ -210,24 +336,78 @@ func (vp *valuesProvider) GetControlPlaneShootChartValues(
 	cluster *extensionscontroller.Cluster,
 	checksums map[string]string,
 ) (map[string]interface{}, error) {
+	// Decode providerConfig
+	cpConfig := &apisazure.ControlPlaneConfig{}
+	if cp.Spec.ProviderConfig != nil {
+		if _, _, err := vp.Decoder().Decode(cp.Spec.ProviderConfig.Raw, nil, cpConfig); err != nil {
+			return nil, errors.Wrapf(err, "could not decode providerConfig of controlplane '%s'", util.ObjectName(cp))
+		}
+	}
+
 	// Decode infrastructureProviderStatus
 	infraStatus := &apisazure.InfrastructureStatus{}
 	if _, _, err := vp.Decoder().Decode(cp.Spec.InfrastructureProviderStatus.Raw, nil, infraStatus); err != nil {
 		return nil, errors.Wrapf(err, "could not decode infrastructureProviderStatus of controlplane '%s'", util.ObjectName(cp))
 	}
 
-	return getControlPlaneShootChartValues(infraStatus)
+	return getControlPlaneShootChartValues(cpConfig, cluster, infraStatus)
 }
 
+// GetStorageClassesChartValues returns the values for the storage classes chart applied by the generic actuator.
+func (vp *valuesProvider) GetStorageClassesChartValues(
+	ctx context.Context,
+	cp *extensionsv1alpha1.ControlPlane,
+	cluster *extensionscontroller.Cluster,
+) (map[string]interface{}, error) {
+	// Decode providerConfig
+	cpConfig := &apisazure.ControlPlaneConfig{}
+	if cp.Spec.ProviderConfig != nil {
+		if _, _, err := vp.Decoder().Decode(cp.Spec.ProviderConfig.Raw, nil, cpConfig); err != nil {
+			return nil, errors.Wrapf(err, "could not decode providerConfig of controlplane '%s'", util.ObjectName(cp))
+		}
+	}
+
+	infraConfig, err := azureapihelper.InfrastructureConfigFromCluster(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	useCSI, err := isCSIEnabled(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	storageClasses, err := getStorageClasses(cpConfig, infraConfig, cp.Spec.Region, cluster.Shoot.Spec.Kubernetes.Version, useCSI)
+	if err != nil {
+		return nil, err
+	}
+
+	// The provisioner, the parameters and the binding mode of a storage class are immutable, hence existing storage
+	// classes whose settings have changed must be deleted before they can be recreated.
+	if err := vp.removeChangedStorageClasses(ctx, cp.Namespace, storageClasses); err != nil {
+		return nil, errors.Wrap(err, "could not remove changed storage classes")
+	}
+
+	return getStorageClassesChartValues(storageClasses), nil
+}
+
+// removeAcrConfig removes the configmaps which configure the access of the kubelet to the acr.
 func (vp *valuesProvider) removeAcrConfig(ctx context.Context, namespace string) error {
-	cm := corev1.ConfigMap{}
-	cm.SetName(azure.CloudProviderAcrConfigName)
-	cm.SetNamespace(namespace)
-	return client.IgnoreNotFound(vp.Client().Delete(ctx, &cm))
+	for _, name := range []string{azure.CloudProviderAcrConfigName, azure.CloudProviderAcrCredentialProviderConfigName} {
+		cm := corev1.ConfigMap{}
+		cm.SetName(name)
+		cm.SetNamespace(namespace)
+		if err := client.IgnoreNotFound(vp.Client().Delete(ctx, &cm)); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // getConfigChartValues collects and returns the configuration chart values.
 func getConfigChartValues(
+	cpConfig *apisazure.ControlPlaneConfig,
+	cloudControllerManager *config.CloudControllerManagerConfig,
 	infraStatus *apisazure.InfrastructureStatus,
 	cp *extensionsv1alpha1.ControlPlane,
 	cluster *extensionscontroller.Cluster,
@@ -256,7 +436,8 @@ func getConfigChartValues(
 		"routeTableName":    routeTableName,
 		"securityGroupName": securityGroupName,
 		"region":            cp.Spec.Region,
-		"maxNodes":          maxNodes,
+		"rateLimit":         getRateLimitChartValues(cpConfig, cloudControllerManager, maxNodes),
+		"backoff":           getBackoffChartValues(cpConfig, cloudControllerManager),
 	}
 
 	if infraStatus.Networks.VNet.ResourceGroup != nil {
@@ -272,13 +453,185 @@ func getConfigChartValues(
 		values["availabilitySetName"] = nodesAvailabilitySet.Name
 	}
 
+	loadBalancerSKU, err := getLoadBalancerSKU(cp, infraStatus)
+	if err != nil {
+		return nil, err
+	}
+	values["loadBalancerSku"] = loadBalancerSKU
+
+	// Kubelets older than v1.15 do not use the Instance Metadata Service for everything, hence they authenticate with
+	// the managed identity of the nodes (if there is one) instead of the credentials of the service principal.
+	if infraStatus.Identity != nil {
+		values["nodeIdentityClientId"] = infraStatus.Identity.ClientID
+	}
+
 	if infraStatus.Identity != nil && infraStatus.Identity.ACRAccess {
 		values["acrIdentityClientId"] = infraStatus.Identity.ClientID
+
+		useAcrCredentialProvider, err := versionutils.CompareVersions(cluster.Shoot.Spec.Kubernetes.Version, ">=", azure.AcrCredentialProviderKubernetesVersion)
+		if err != nil {
+			return nil, err
+		}
+		values["acrCredentialProvider"] = useAcrCredentialProvider
+	}
+
+	if cpConfig.LoadBalancer != nil {
+		values["loadBalancer"] = getLoadBalancerConfigChartValues(cpConfig.LoadBalancer)
 	}
 
 	return values, nil
 }
 
+// getLoadBalancerSKU determines the SKU of the load balancers of the shoot. Zoned shoots always use the standard SKU,
+// non-zoned shoots use the basic SKU until their load balancers have been migrated to the standard SKU.
+func getLoadBalancerSKU(cp *extensionsv1alpha1.ControlPlane, infraStatus *apisazure.InfrastructureStatus) (string, error) {
+	if infraStatus.Zoned {
+		return loadBalancerSKUStandard, nil
+	}
+
+	status, err := azureapihelper.ControlPlaneStatusFromControlPlane(cp)
+	if err != nil {
+		return "", errors.Wrapf(err, "could not decode providerStatus of controlplane '%s'", util.ObjectName(cp))
+	}
+	if status == nil || status.LoadBalancerMigration == nil {
+		return loadBalancerSKUBasic, nil
+	}
+
+	switch status.LoadBalancerMigration.State {
+	case apisazure.LoadBalancerMigrationStateLoadBalancersDeleted,
+		apisazure.LoadBalancerMigrationStateSKUSwitched,
+		apisazure.LoadBalancerMigrationStateServicesRestored,
+		apisazure.LoadBalancerMigrationStateSucceeded:
+		return loadBalancerSKUStandard, nil
+	}
+	return loadBalancerSKUBasic, nil
+}
+
+// getRateLimitChartValues determines the rate limiting of the requests to the Azure API. The settings of the
+// ControlPlaneConfig take precedence over the defaults of the controller configuration. The number of read requests
+// per second defaults to the maximum number of nodes of the cluster, but at least to 10. The settings for the write
+// requests default to those for the read requests.
+func getRateLimitChartValues(cpConfig *apisazure.ControlPlaneConfig, defaults *config.CloudControllerManagerConfig, maxNodes int32) map[string]interface{} {
+	var (
+		qps         = math.Max(float64(maxNodes), defaultRateLimitMinQPS)
+		bucket      = int32(defaultRateLimitBucket)
+		qpsWrite    *float64
+		bucketWrite *int32
+	)
+
+	if defaults != nil && defaults.RateLimit != nil {
+		qps = float64OrDefault(defaults.RateLimit.QPS, qps)
+		bucket = int32OrDefault(defaults.RateLimit.Bucket, bucket)
+		qpsWrite, bucketWrite = defaults.RateLimit.QPSWrite, defaults.RateLimit.BucketWrite
+	}
+
+	if cpConfig.CloudControllerManager != nil && cpConfig.CloudControllerManager.RateLimit != nil {
+		rateLimit := cpConfig.CloudControllerManager.RateLimit
+		qps = float64OrDefault(rateLimit.QPS, qps)
+		bucket = int32OrDefault(rateLimit.Bucket, bucket)
+		if rateLimit.QPSWrite != nil {
+			qpsWrite = rateLimit.QPSWrite
+		}
+		if rateLimit.BucketWrite != nil {
+			bucketWrite = rateLimit.BucketWrite
+		}
+	}
+
+	return map[string]interface{}{
+		"qps":         qps,
+		"bucket":      bucket,
+		"qpsWrite":    float64OrDefault(qpsWrite, qps),
+		"bucketWrite": int32OrDefault(bucketWrite, bucket),
+	}
+}
+
+// getBackoffChartValues determines the backoff of failed requests to the Azure API. The settings of the
+// ControlPlaneConfig take precedence over the defaults of the controller configuration.
+func getBackoffChartValues(cpConfig *apisazure.ControlPlaneConfig, defaults *config.CloudControllerManagerConfig) map[string]interface{} {
+	var (
+		retries         = int32(defaultBackoffRetries)
+		exponent        = defaultBackoffExponent
+		durationSeconds = int32(defaultBackoffDurationSeconds)
+		jitter          = defaultBackoffJitter
+	)
+
+	if defaults != nil && defaults.Backoff != nil {
+		retries = int32OrDefault(defaults.Backoff.Retries, retries)
+		exponent = float64OrDefault(defaults.Backoff.Exponent, exponent)
+		durationSeconds = int32OrDefault(defaults.Backoff.DurationSeconds, durationSeconds)
+		jitter = float64OrDefault(defaults.Backoff.Jitter, jitter)
+	}
+
+	if cpConfig.CloudControllerManager != nil && cpConfig.CloudControllerManager.Backoff != nil {
+		backoff := cpConfig.CloudControllerManager.Backoff
+		retries = int32OrDefault(backoff.Retries, retries)
+		exponent = float64OrDefault(backoff.Exponent, exponent)
+		durationSeconds = int32OrDefault(backoff.DurationSeconds, durationSeconds)
+		jitter = float64OrDefault(backoff.Jitter, jitter)
+	}
+
+	return map[string]interface{}{
+		"retries":         retries,
+		"exponent":        exponent,
+		"durationSeconds": durationSeconds,
+		"jitter":          jitter,
+	}
+}
+
+func float64OrDefault(value *float64, defaultValue float64) float64 {
+	if value == nil {
+		return defaultValue
+	}
+	return *value
+}
+
+func int32OrDefault(value *int32, defaultValue int32) int32 {
+	if value == nil {
+		return defaultValue
+	}
+	return *value
+}
+
+// getLoadBalancerConfigChartValues collects and returns the load balancer settings of the cloud provider config.
+func getLoadBalancerConfigChartValues(config *apisazure.LoadBalancerConfig) map[string]interface{} {
+	values := map[string]interface{}{}
+
+	// The outbound connectivity is provided by the outbound rule of the load balancer if one is configured, hence the
+	// load balancing rules must not enable the outbound source network address translation in this case.
+	if config.OutboundRule != nil || (config.DisableOutboundSNAT != nil && *config.DisableOutboundSNAT) {
+		values["disableOutboundSNAT"] = true
+	}
+	if config.PreConfiguredBackendPoolType != nil {
+		values["preConfiguredBackendPoolType"] = strings.ToLower(string(*config.PreConfiguredBackendPoolType))
+	}
+
+	return values
+}
+
+// getControlPlaneChartValues collects and returns the control plane chart values.
+func getControlPlaneChartValues(
+	cpConfig *apisazure.ControlPlaneConfig,
+	cp *extensionsv1alpha1.ControlPlane,
+	cluster *extensionscontroller.Cluster,
+	checksums map[string]string,
+	scaledDown bool,
+) (map[string]interface{}, error) {
+	ccm, err := getCCMChartValues(cpConfig, cp, cluster, checksums, scaledDown)
+	if err != nil {
+		return nil, err
+	}
+
+	csi, err := getCSIControllerChartValues(cluster, checksums, scaledDown)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"cloud-controller-manager": ccm,
+		"csi-driver-controller":    csi,
+	}, nil
+}
+
 // getCCMChartValues collects and returns the CCM chart values.
 func getCCMChartValues(
 	cpConfig *apisazure.ControlPlaneConfig,
@@ -287,11 +640,26 @@ func getCCMChartValues(
 	checksums map[string]string,
 	scaledDown bool,
 ) (map[string]interface{}, error) {
+	infraConfig, err := azureapihelper.InfrastructureConfigFromCluster(cluster)
+	if err != nil {
+		return nil, err
+	}
+	// Routes for the pod network are only required if the pod traffic is not encapsulated in an overlay network.
+	configureCloudRoutes := azureapihelper.PodNetworkModeOrDefault(infraConfig) == apisazure.PodNetworkModeRoutes
+
+	// The nodes are initialized by the cloud-node-manager in the shoot if the kubelet uses the external cloud provider.
+	useCloudNodeManager, err := isExternalCloudProviderEnabled(cluster)
+	if err != nil {
+		return nil, err
+	}
+
 	values := map[string]interface{}{
-		"replicas":          extensionscontroller.GetControlPlaneReplicas(cluster, scaledDown, 1),
-		"clusterName":       cp.Namespace,
-		"kubernetesVersion": cluster.Shoot.Spec.Kubernetes.Version,
-		"podNetwork":        extensionscontroller.GetPodNetwork(cluster),
+		"replicas":             extensionscontroller.GetControlPlaneReplicas(cluster, scaledDown, 1),
+		"clusterName":          cp.Namespace,
+		"kubernetesVersion":    cluster.Shoot.Spec.Kubernetes.Version,
+		"podNetwork":           extensionscontroller.GetPodNetwork(cluster),
+		"configureCloudRoutes": configureCloudRoutes,
+		"cloudNodeManager":     useCloudNodeManager,
 		"podAnnotations": map[string]interface{}{
 			"checksum/secret-cloud-controller-manager":        checksums[cloudControllerManagerDeploymentName],
 			"checksum/secret-cloud-controller-manager-server": checksums[cloudControllerManagerServerName],
@@ -310,19 +678,114 @@ func getCCMChartValues(
 	return values, nil
 }
 
+// getCSIControllerChartValues collects and returns the CSI controller chart values.
+func getCSIControllerChartValues(
+	cluster *extensionscontroller.Cluster,
+	checksums map[string]string,
+	scaledDown bool,
+) (map[string]interface{}, error) {
+	useCSI, err := isCSIEnabled(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	if !useCSI {
+		return map[string]interface{}{"enabled": false}, nil
+	}
+
+	return map[string]interface{}{
+		"enabled":  true,
+		"replicas": extensionscontroller.GetControlPlaneReplicas(cluster, scaledDown, 1),
+		"podAnnotations": map[string]interface{}{
+			"checksum/secret-" + csiDriverControllerName: checksums[csiDriverControllerName],
+			"checksum/configmap-cloud-provider-config":   checksums[azure.CloudProviderConfigName],
+		},
+		"snapshotController": map[string]interface{}{
+			"replicas": extensionscontroller.GetControlPlaneReplicas(cluster, scaledDown, 1),
+			"podAnnotations": map[string]interface{}{
+				"checksum/secret-" + csiSnapshotControllerName: checksums[csiSnapshotControllerName],
+			},
+		},
+	}, nil
+}
+
 // getControlPlaneShootChartValues collects and returns the control plane shoot chart values.
 func getControlPlaneShootChartValues(
+	cpConfig *apisazure.ControlPlaneConfig,
+	cluster *extensionscontroller.Cluster,
 	infraStatus *apisazure.InfrastructureStatus,
 ) (map[string]interface{}, error) {
+	useCSI, err := isCSIEnabled(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	useCloudNodeManager, err := isExternalCloudProviderEnabled(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	csiDriver := map[string]interface{}{
+		"enabled": useCSI,
+	}
+	// The volume snapshot class is deployed together with the CRDs of the snapshots, so that both are part of the same
+	// managed resource.
+	if useCSI {
+		csiDriver["volumeSnapshotClass"] = getVolumeSnapshotClassChartValues(cpConfig)
+	}
+
 	values := map[string]interface{}{
+		"cloud-node-manager": map[string]interface{}{
+			"enabled": useCloudNodeManager,
+		},
 		"allow-udp-egress": map[string]interface{}{
 			"enabled": infraStatus.Zoned,
 		},
+		"csi-driver":               csiDriver,
+		"scheduled-events-handler": getScheduledEventsHandlerChartValues(cpConfig.ScheduledEventsHandler),
 	}
 
 	return values, nil
 }
 
+// getScheduledEventsHandlerChartValues collects and returns the values of the handler of the Azure scheduled events.
+// Settings which are not configured fall back to the defaults of the chart.
+func getScheduledEventsHandlerChartValues(handler *apisazure.ScheduledEventsHandler) map[string]interface{} {
+	if handler == nil || !handler.Enabled {
+		return map[string]interface{}{
+			"enabled": false,
+		}
+	}
+
+	values := map[string]interface{}{
+		"enabled": true,
+	}
+	if len(handler.EventTypes) > 0 {
+		eventTypes := make([]string, 0, len(handler.EventTypes))
+		for _, eventType := range handler.EventTypes {
+			eventTypes = append(eventTypes, string(eventType))
+		}
+		values["eventTypes"] = eventTypes
+	}
+	if handler.DrainTimeout != nil {
+		values["drainTimeout"] = handler.DrainTimeout.Duration.String()
+	}
+	return values
+}
+
+// isCSIEnabled checks whether the Azure Disk and Azure File CSI drivers are used for the Kubernetes version of the
+// given cluster.
+func isCSIEnabled(cluster *extensionscontroller.Cluster) (bool, error) {
+	return versionutils.CompareVersions(cluster.Shoot.Spec.Kubernetes.Version, ">=", azure.CSIMigrationKubernetesVersion)
+}
+
+// isExternalCloudProviderEnabled checks whether the kubelet uses the external cloud provider for the Kubernetes
+// version of the given cluster. The in-tree volume plugins of the kubelet require the in-tree cloud provider, hence the
+// external cloud provider can only be used once they are migrated to the CSI drivers.
+func isExternalCloudProviderEnabled(cluster *extensionscontroller.Cluster) (bool, error) {
+	return isCSIEnabled(cluster)
+}
+
 // getInfraNames determines the subnet, availability set, route table and security group names from the given infrastructure status.
 func getInfraNames(infraStatus *apisazure.InfrastructureStatus) (string, string, string, error) {
 	nodesSubnet, err := azureapihelper.FindSubnetByPurpose(infraStatus.Networks.Subnets, apisazure.PurposeNodes)
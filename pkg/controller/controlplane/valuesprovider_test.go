@@ -17,8 +17,11 @@ package controlplane
 import (
 	"context"
 	"encoding/json"
+	"time"
 
 	apisazure "github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure"
+	"github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure/v1alpha1"
+	"github.com/gardener/gardener-extension-provider-azure/pkg/apis/config"
 	"github.com/gardener/gardener-extension-provider-azure/pkg/azure"
 	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
 	mockclient "github.com/gardener/gardener-extensions/pkg/mock/controller-runtime/client"
@@ -29,20 +32,21 @@ import (
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
 )
 
-const (
-	namespace       = "test"
-	maxNodes  int32 = 0
-)
+const namespace = "test"
 
 var _ = Describe("ValuesProvider", func() {
 	var (
@@ -510,11 +514,37 @@ var _ = Describe("ValuesProvider", func() {
 		}
 		errorAcrConfigMapNotFound = errors.NewNotFound(schema.GroupResource{}, azure.CloudProviderAcrConfigName)
 
+		acrCredentialProviderConfigMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: azure.CloudProviderAcrCredentialProviderConfigName, Namespace: namespace},
+		}
+		errorAcrCredentialProviderConfigMapNotFound = errors.NewNotFound(schema.GroupResource{}, azure.CloudProviderAcrCredentialProviderConfigName)
+
+		kmsPluginSecret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: azure.KMSPluginSecretName, Namespace: namespace},
+		}
+		errorKMSPluginSecretNotFound = errors.NewNotFound(schema.GroupResource{}, azure.KMSPluginSecretName)
+
 		checksums = map[string]string{
 			v1beta1constants.SecretNameCloudProvider: "8bafb35ff1ac60275d62e1cbd495aceb511fb354f74a20f7d06ecb48b3a68432",
 			azure.CloudProviderConfigName:            "08a7bc7fe8f59b055f173145e211760a83f02cf89635cef26ebb351378635606",
 			"cloud-controller-manager":               "3d791b164a808638da9a8df03924be2a41e34cd664e42231c00fe369e3588272",
 			"cloud-controller-manager-server":        "6dff2a2e6f14444b66d8e4a351c049f7e89ee24ba3eaab95dbec40ba6bdebb52",
+			"csi-driver-controller":                  "1fd3ad0e7e1e4b5a5cbd4c0e5a58fd8bd56cc7d56e2bfe2f3bb56b7d4c2c1d8a",
+			"csi-snapshot-controller":                "7b0c36dd36e1a1c5e3a0b4e4d8a6e0f35e4d9b2f4c0a9c2b6e1f3a7d5c8b2e91",
+		}
+
+		defaultRateLimitValues = map[string]interface{}{
+			"qps":         float64(10),
+			"bucket":      int32(100),
+			"qpsWrite":    float64(10),
+			"bucketWrite": int32(100),
+		}
+
+		defaultBackoffValues = map[string]interface{}{
+			"retries":         int32(6),
+			"exponent":        1.5,
+			"durationSeconds": int32(5),
+			"jitter":          1.0,
 		}
 
 		configNonZonedClusterChartValues = map[string]interface{}{
@@ -527,10 +557,12 @@ var _ = Describe("ValuesProvider", func() {
 			"subnetName":          "subnet-abcd1234-nodes",
 			"region":              "eu-west-1a",
 			"availabilitySetName": "availability-set-name",
+			"loadBalancerSku":     "basic",
 			"routeTableName":      "route-table-name",
 			"securityGroupName":   "security-group-name-workers",
 			"kubernetesVersion":   "1.13.4",
-			"maxNodes":            maxNodes,
+			"rateLimit":           defaultRateLimitValues,
+			"backoff":             defaultBackoffValues,
 		}
 
 		configZonedClusterChartValues = map[string]interface{}{
@@ -542,33 +574,41 @@ var _ = Describe("ValuesProvider", func() {
 			"vnetName":          "vnet-abcd1234",
 			"subnetName":        "subnet-abcd1234-nodes",
 			"region":            "eu-west-1a",
+			"loadBalancerSku":   "standard",
 			"routeTableName":    "route-table-name",
 			"securityGroupName": "security-group-name-workers",
 			"kubernetesVersion": "1.13.4",
-			"maxNodes":          maxNodes,
+			"rateLimit":         defaultRateLimitValues,
+			"backoff":           defaultBackoffValues,
 		}
 
 		configIdentityClusterChartValues = map[string]interface{}{
-			"tenantId":            "TenantID",
-			"subscriptionId":      "SubscriptionID",
-			"aadClientId":         "ClientID",
-			"aadClientSecret":     "ClientSecret",
-			"resourceGroup":       "rg-abcd1234",
-			"vnetName":            "vnet-abcd1234",
-			"subnetName":          "subnet-abcd1234-nodes",
-			"region":              "eu-west-1a",
-			"routeTableName":      "route-table-name",
-			"securityGroupName":   "security-group-name-workers",
-			"kubernetesVersion":   "1.13.4",
-			"acrIdentityClientId": "identity-client-id",
-			"maxNodes":            maxNodes,
+			"tenantId":              "TenantID",
+			"subscriptionId":        "SubscriptionID",
+			"aadClientId":           "ClientID",
+			"aadClientSecret":       "ClientSecret",
+			"resourceGroup":         "rg-abcd1234",
+			"vnetName":              "vnet-abcd1234",
+			"subnetName":            "subnet-abcd1234-nodes",
+			"region":                "eu-west-1a",
+			"loadBalancerSku":       "standard",
+			"routeTableName":        "route-table-name",
+			"securityGroupName":     "security-group-name-workers",
+			"kubernetesVersion":     "1.13.4",
+			"nodeIdentityClientId":  "identity-client-id",
+			"acrIdentityClientId":   "identity-client-id",
+			"acrCredentialProvider": false,
+			"rateLimit":             defaultRateLimitValues,
+			"backoff":               defaultBackoffValues,
 		}
 
 		ccmChartValues = map[string]interface{}{
-			"replicas":          1,
-			"clusterName":       namespace,
-			"kubernetesVersion": "1.13.4",
-			"podNetwork":        cidr,
+			"replicas":             1,
+			"clusterName":          namespace,
+			"kubernetesVersion":    "1.13.4",
+			"podNetwork":           cidr,
+			"configureCloudRoutes": true,
+			"cloudNodeManager":     false,
 			"podAnnotations": map[string]interface{}{
 				"checksum/secret-cloud-controller-manager":        "3d791b164a808638da9a8df03924be2a41e34cd664e42231c00fe369e3588272",
 				"checksum/secret-cloud-controller-manager-server": "6dff2a2e6f14444b66d8e4a351c049f7e89ee24ba3eaab95dbec40ba6bdebb52",
@@ -583,16 +623,41 @@ var _ = Describe("ValuesProvider", func() {
 			},
 		}
 
+		controlPlaneChartValues = map[string]interface{}{
+			"cloud-controller-manager": ccmChartValues,
+			"csi-driver-controller": map[string]interface{}{
+				"enabled": false,
+			},
+		}
+
 		controlPlaneShootNonZonedClusterChartValues = map[string]interface{}{
+			"cloud-node-manager": map[string]interface{}{
+				"enabled": false,
+			},
 			"allow-udp-egress": map[string]interface{}{
 				"enabled": false,
 			},
+			"csi-driver": map[string]interface{}{
+				"enabled": false,
+			},
+			"scheduled-events-handler": map[string]interface{}{
+				"enabled": false,
+			},
 		}
 
 		controlPlaneShootZonedClusterChartValues = map[string]interface{}{
+			"cloud-node-manager": map[string]interface{}{
+				"enabled": false,
+			},
 			"allow-udp-egress": map[string]interface{}{
 				"enabled": true,
 			},
+			"csi-driver": map[string]interface{}{
+				"enabled": false,
+			},
+			"scheduled-events-handler": map[string]interface{}{
+				"enabled": false,
+			},
 		}
 
 		logger = log.Log.WithName("test")
@@ -611,10 +676,12 @@ var _ = Describe("ValuesProvider", func() {
 			// Create mock client
 			client := mockclient.NewMockClient(ctrl)
 			client.EXPECT().Get(context.TODO(), cpSecretKey, &corev1.Secret{}).DoAndReturn(clientGet(cpSecret))
+			client.EXPECT().Delete(context.TODO(), kmsPluginSecret).Return(errorKMSPluginSecretNotFound)
 			client.EXPECT().Delete(context.TODO(), acrConfigMap).Return(errorAcrConfigMapNotFound)
+			client.EXPECT().Delete(context.TODO(), acrCredentialProviderConfigMap).Return(errorAcrCredentialProviderConfigMapNotFound)
 
 			// Create valuesProvider
-			vp := NewValuesProvider(logger)
+			vp := NewValuesProvider(nil, nil, logger)
 			err := vp.(inject.Scheme).InjectScheme(scheme)
 			Expect(err).NotTo(HaveOccurred())
 			err = vp.(inject.Client).InjectClient(client)
@@ -627,14 +694,49 @@ var _ = Describe("ValuesProvider", func() {
 			Expect(values).To(Equal(configNonZonedClusterChartValues))
 		})
 
+		It("should return the standard load balancer SKU for non zoned cluster whose load balancers have been migrated", func() {
+			// Create mock client
+			client := mockclient.NewMockClient(ctrl)
+			client.EXPECT().Get(context.TODO(), cpSecretKey, &corev1.Secret{}).DoAndReturn(clientGet(cpSecret))
+			client.EXPECT().Delete(context.TODO(), kmsPluginSecret).Return(errorKMSPluginSecretNotFound)
+			client.EXPECT().Delete(context.TODO(), acrConfigMap).Return(errorAcrConfigMapNotFound)
+			client.EXPECT().Delete(context.TODO(), acrCredentialProviderConfigMap).Return(errorAcrCredentialProviderConfigMapNotFound)
+
+			// Create valuesProvider
+			vp := NewValuesProvider(nil, nil, logger)
+			err := vp.(inject.Scheme).InjectScheme(scheme)
+			Expect(err).NotTo(HaveOccurred())
+			err = vp.(inject.Client).InjectClient(client)
+			Expect(err).NotTo(HaveOccurred())
+
+			cpMigrated := cp.DeepCopy()
+			cpMigrated.Status.ProviderStatus = &runtime.RawExtension{
+				Raw: encode(&v1alpha1.ControlPlaneStatus{
+					TypeMeta: metav1.TypeMeta{APIVersion: v1alpha1.SchemeGroupVersion.String(), Kind: "ControlPlaneStatus"},
+					LoadBalancerMigration: &v1alpha1.LoadBalancerMigrationStatus{
+						State: v1alpha1.LoadBalancerMigrationStateLoadBalancersDeleted,
+					},
+				}),
+			}
+
+			// Call GetConfigChartValues method and check the result
+			values, err := vp.GetConfigChartValues(context.TODO(), cpMigrated, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(HaveKeyWithValue("availabilitySetName", "availability-set-name"))
+			Expect(values).To(HaveKeyWithValue("loadBalancerSku", "standard"))
+		})
+
 		It("should return correct config chart values for zoned cluster", func() {
 			// Create mock client
 			client := mockclient.NewMockClient(ctrl)
 			client.EXPECT().Get(context.TODO(), cpSecretKey, &corev1.Secret{}).DoAndReturn(clientGet(cpSecret))
+			client.EXPECT().Delete(context.TODO(), kmsPluginSecret).Return(errorKMSPluginSecretNotFound)
 			client.EXPECT().Delete(context.TODO(), acrConfigMap).Return(errorAcrConfigMapNotFound)
+			client.EXPECT().Delete(context.TODO(), acrCredentialProviderConfigMap).Return(errorAcrCredentialProviderConfigMapNotFound)
 
 			// Create valuesProvider
-			vp := NewValuesProvider(logger)
+			vp := NewValuesProvider(nil, nil, logger)
 			err := vp.(inject.Scheme).InjectScheme(scheme)
 			Expect(err).NotTo(HaveOccurred())
 			err = vp.(inject.Client).InjectClient(client)
@@ -647,13 +749,156 @@ var _ = Describe("ValuesProvider", func() {
 			Expect(values).To(Equal(configZonedClusterChartValues))
 		})
 
+		It("should return correct config chart values for zoned cluster with load balancer config", func() {
+			// Create mock client
+			client := mockclient.NewMockClient(ctrl)
+			client.EXPECT().Get(context.TODO(), cpSecretKey, &corev1.Secret{}).DoAndReturn(clientGet(cpSecret))
+			client.EXPECT().Delete(context.TODO(), kmsPluginSecret).Return(errorKMSPluginSecretNotFound)
+			client.EXPECT().Delete(context.TODO(), acrConfigMap).Return(errorAcrConfigMapNotFound)
+			client.EXPECT().Delete(context.TODO(), acrCredentialProviderConfigMap).Return(errorAcrCredentialProviderConfigMapNotFound)
+
+			// Create valuesProvider
+			vp := NewValuesProvider(nil, nil, logger)
+			err := vp.(inject.Scheme).InjectScheme(scheme)
+			Expect(err).NotTo(HaveOccurred())
+			err = vp.(inject.Client).InjectClient(client)
+			Expect(err).NotTo(HaveOccurred())
+
+			var (
+				backendPoolType = apisazure.PreConfiguredBackendPoolTypeAll
+				cpLoadBalancer  = cpZoned.DeepCopy()
+				expectedValues  = map[string]interface{}{}
+			)
+			cpLoadBalancer.Spec.ProviderConfig.Raw = encode(&apisazure.ControlPlaneConfig{
+				LoadBalancer: &apisazure.LoadBalancerConfig{
+					OutboundRule:                 &apisazure.OutboundRule{},
+					PreConfiguredBackendPoolType: &backendPoolType,
+				},
+			})
+			for k, v := range configZonedClusterChartValues {
+				expectedValues[k] = v
+			}
+			expectedValues["loadBalancer"] = map[string]interface{}{
+				"disableOutboundSNAT":          true,
+				"preConfiguredBackendPoolType": "all",
+			}
+
+			// Call GetConfigChartValues method and check the result
+			values, err := vp.GetConfigChartValues(context.TODO(), cpLoadBalancer, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(Equal(expectedValues))
+		})
+
+		It("should return correct config chart values with the rate limit and backoff defaults of the seed overridden by the shoot", func() {
+			// Create mock client
+			client := mockclient.NewMockClient(ctrl)
+			client.EXPECT().Get(context.TODO(), cpSecretKey, &corev1.Secret{}).DoAndReturn(clientGet(cpSecret))
+			client.EXPECT().Delete(context.TODO(), kmsPluginSecret).Return(errorKMSPluginSecretNotFound)
+			client.EXPECT().Delete(context.TODO(), acrConfigMap).Return(errorAcrConfigMapNotFound)
+			client.EXPECT().Delete(context.TODO(), acrCredentialProviderConfigMap).Return(errorAcrCredentialProviderConfigMapNotFound)
+
+			// Create valuesProvider
+			vp := NewValuesProvider(&config.CloudControllerManagerConfig{
+				RateLimit: &config.CloudProviderRateLimit{
+					QPS:         pointer.Float64Ptr(20),
+					BucketWrite: pointer.Int32Ptr(50),
+				},
+				Backoff: &config.CloudProviderBackoff{
+					Retries: pointer.Int32Ptr(3),
+					Jitter:  pointer.Float64Ptr(0.5),
+				},
+			}, nil, logger)
+			err := vp.(inject.Scheme).InjectScheme(scheme)
+			Expect(err).NotTo(HaveOccurred())
+			err = vp.(inject.Client).InjectClient(client)
+			Expect(err).NotTo(HaveOccurred())
+
+			var (
+				cpRateLimit    = cpZoned.DeepCopy()
+				expectedValues = map[string]interface{}{}
+			)
+			cpRateLimit.Spec.ProviderConfig.Raw = encode(&apisazure.ControlPlaneConfig{
+				CloudControllerManager: &apisazure.CloudControllerManagerConfig{
+					RateLimit: &apisazure.CloudProviderRateLimit{
+						QPS: pointer.Float64Ptr(30),
+					},
+					Backoff: &apisazure.CloudProviderBackoff{
+						Jitter: pointer.Float64Ptr(0.25),
+					},
+				},
+			})
+			for k, v := range configZonedClusterChartValues {
+				expectedValues[k] = v
+			}
+			expectedValues["rateLimit"] = map[string]interface{}{
+				"qps":         float64(30),
+				"bucket":      int32(100),
+				"qpsWrite":    float64(30),
+				"bucketWrite": int32(50),
+			}
+			expectedValues["backoff"] = map[string]interface{}{
+				"retries":         int32(3),
+				"exponent":        1.5,
+				"durationSeconds": int32(5),
+				"jitter":          0.25,
+			}
+
+			// Call GetConfigChartValues method and check the result
+			values, err := vp.GetConfigChartValues(context.TODO(), cpRateLimit, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(Equal(expectedValues))
+		})
+
+		It("should return correct config chart values with the rate limit depending on the maximum number of nodes", func() {
+			// Create mock client
+			client := mockclient.NewMockClient(ctrl)
+			client.EXPECT().Get(context.TODO(), cpSecretKey, &corev1.Secret{}).DoAndReturn(clientGet(cpSecret))
+			client.EXPECT().Delete(context.TODO(), kmsPluginSecret).Return(errorKMSPluginSecretNotFound)
+			client.EXPECT().Delete(context.TODO(), acrConfigMap).Return(errorAcrConfigMapNotFound)
+			client.EXPECT().Delete(context.TODO(), acrCredentialProviderConfigMap).Return(errorAcrCredentialProviderConfigMapNotFound)
+
+			// Create valuesProvider
+			vp := NewValuesProvider(nil, nil, logger)
+			err := vp.(inject.Scheme).InjectScheme(scheme)
+			Expect(err).NotTo(HaveOccurred())
+			err = vp.(inject.Client).InjectClient(client)
+			Expect(err).NotTo(HaveOccurred())
+
+			var (
+				clusterWithWorkers = &extensionscontroller.Cluster{Shoot: cluster.Shoot.DeepCopy()}
+				expectedValues     = map[string]interface{}{}
+			)
+			clusterWithWorkers.Shoot.Spec.Provider.Workers = []gardencorev1beta1.Worker{
+				{Name: "worker1", Maximum: 20},
+				{Name: "worker2", Maximum: 25},
+			}
+			for k, v := range configZonedClusterChartValues {
+				expectedValues[k] = v
+			}
+			expectedValues["rateLimit"] = map[string]interface{}{
+				"qps":         float64(45),
+				"bucket":      int32(100),
+				"qpsWrite":    float64(45),
+				"bucketWrite": int32(100),
+			}
+
+			// Call GetConfigChartValues method and check the result
+			values, err := vp.GetConfigChartValues(context.TODO(), cpZoned, clusterWithWorkers)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(Equal(expectedValues))
+		})
+
 		It("should return correct control plane chart values with identiy", func() {
 			// Create mock client
 			client := mockclient.NewMockClient(ctrl)
 			client.EXPECT().Get(context.TODO(), cpSecretKey, &corev1.Secret{}).DoAndReturn(clientGet(cpSecret))
+			client.EXPECT().Delete(context.TODO(), kmsPluginSecret).Return(errorKMSPluginSecretNotFound)
 
 			// Create valuesProvider
-			vp := NewValuesProvider(logger)
+			vp := NewValuesProvider(nil, nil, logger)
 			err := vp.(inject.Scheme).InjectScheme(scheme)
 			Expect(err).NotTo(HaveOccurred())
 			err = vp.(inject.Client).InjectClient(client)
@@ -664,6 +909,116 @@ var _ = Describe("ValuesProvider", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(values).To(Equal(configIdentityClusterChartValues))
 		})
+
+		It("should return correct config chart values with identity and the acr credential provider (k8s >= 1.20)", func() {
+			// Create mock client
+			client := mockclient.NewMockClient(ctrl)
+			client.EXPECT().Get(context.TODO(), cpSecretKey, &corev1.Secret{}).DoAndReturn(clientGet(cpSecret))
+			client.EXPECT().Delete(context.TODO(), kmsPluginSecret).Return(errorKMSPluginSecretNotFound)
+
+			// Create valuesProvider
+			vp := NewValuesProvider(nil, nil, logger)
+			err := vp.(inject.Scheme).InjectScheme(scheme)
+			Expect(err).NotTo(HaveOccurred())
+			err = vp.(inject.Client).InjectClient(client)
+			Expect(err).NotTo(HaveOccurred())
+
+			var (
+				clusterK8s120  = &extensionscontroller.Cluster{Shoot: cluster.Shoot.DeepCopy()}
+				expectedValues = map[string]interface{}{}
+			)
+			clusterK8s120.Shoot.Spec.Kubernetes.Version = "1.20.2"
+			for k, v := range configIdentityClusterChartValues {
+				expectedValues[k] = v
+			}
+			expectedValues["kubernetesVersion"] = "1.20.2"
+			expectedValues["acrCredentialProvider"] = true
+
+			// Call GetConfigChartValues method and check the result
+			values, err := vp.GetConfigChartValues(context.TODO(), cpIdentity, clusterK8s120)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(Equal(expectedValues))
+		})
+
+		It("should return correct config chart values with kms", func() {
+			var (
+				cpKMS                       = cp.DeepCopy()
+				etcdEncryptionSecretKey     = client.ObjectKey{Namespace: namespace, Name: "etcd-encryption-secret"}
+				etcdEncryptionConfiguration = `apiVersion: apiserver.config.k8s.io/v1
+kind: EncryptionConfiguration
+resources:
+- providers:
+  - aescbc:
+      keys:
+      - name: key1590000000
+        secret: c2VjcmV0
+  - identity: {}
+  resources:
+  - secrets
+`
+				etcdEncryptionSecret = &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "etcd-encryption-secret", Namespace: namespace},
+					Data: map[string][]byte{
+						"encryption-configuration.yaml": []byte(etcdEncryptionConfiguration),
+					},
+				}
+				expectedValues = map[string]interface{}{}
+			)
+			cpKMS.Spec.ProviderConfig.Raw = encode(&apisazure.ControlPlaneConfig{
+				KMS: &apisazure.KMS{
+					KeyVaultName:        "vault",
+					KeyName:             "key",
+					KeyVersion:          "00000000000000000000000000000002",
+					PreviousKeyVersions: []string{"00000000000000000000000000000001"},
+				},
+			})
+
+			// Create mock client
+			client := mockclient.NewMockClient(ctrl)
+			client.EXPECT().Get(context.TODO(), cpSecretKey, &corev1.Secret{}).DoAndReturn(clientGet(cpSecret))
+			client.EXPECT().Delete(context.TODO(), acrConfigMap).Return(errorAcrConfigMapNotFound)
+			client.EXPECT().Delete(context.TODO(), acrCredentialProviderConfigMap).Return(errorAcrCredentialProviderConfigMapNotFound)
+			client.EXPECT().Get(context.TODO(), etcdEncryptionSecretKey, &corev1.Secret{}).DoAndReturn(clientGet(etcdEncryptionSecret))
+
+			// Create valuesProvider
+			vp := NewValuesProvider(nil, &config.KMSConfig{ManagedIdentityClientID: pointer.StringPtr("identity-client-id")}, logger)
+			err := vp.(inject.Scheme).InjectScheme(scheme)
+			Expect(err).NotTo(HaveOccurred())
+			err = vp.(inject.Client).InjectClient(client)
+			Expect(err).NotTo(HaveOccurred())
+
+			for k, v := range configNonZonedClusterChartValues {
+				expectedValues[k] = v
+			}
+			expectedValues["kms"] = map[string]interface{}{
+				"managedIdentityClientId": "identity-client-id",
+				"encryptionConfiguration": `apiVersion: apiserver.config.k8s.io/v1
+kind: EncryptionConfiguration
+resources:
+- providers:
+  - kms:
+      cachesize: 1000
+      endpoint: unix:///var/run/kmsplugin/00000000000000000000000000000002.sock
+      name: azurekms-00000000000000000000000000000002
+  - kms:
+      cachesize: 1000
+      endpoint: unix:///var/run/kmsplugin/00000000000000000000000000000001.sock
+      name: azurekms-00000000000000000000000000000001
+  - aescbc:
+      keys:
+      - name: key1590000000
+        secret: c2VjcmV0
+  - identity: {}
+  resources:
+  - secrets
+`,
+			}
+
+			// Call GetConfigChartValues method and check the result
+			values, err := vp.GetConfigChartValues(context.TODO(), cpKMS, cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(Equal(expectedValues))
+		})
 	})
 
 	Describe("#GetConfigChartValuesNoSubnet", func() {
@@ -672,9 +1027,10 @@ var _ = Describe("ValuesProvider", func() {
 			client := mockclient.NewMockClient(ctrl)
 			client.EXPECT().Get(context.TODO(), cpSecretKey, &corev1.Secret{}).DoAndReturn(clientGet(cpSecret))
 			client.EXPECT().Delete(context.TODO(), acrConfigMap).Return(errorAcrConfigMapNotFound)
+			client.EXPECT().Delete(context.TODO(), acrCredentialProviderConfigMap).Return(errorAcrCredentialProviderConfigMapNotFound)
 
 			// Create valuesProvider
-			vp := NewValuesProvider(logger)
+			vp := NewValuesProvider(nil, nil, logger)
 			err := vp.(inject.Scheme).InjectScheme(scheme)
 			Expect(err).NotTo(HaveOccurred())
 			err = vp.(inject.Client).InjectClient(client)
@@ -693,9 +1049,10 @@ var _ = Describe("ValuesProvider", func() {
 			client := mockclient.NewMockClient(ctrl)
 			client.EXPECT().Get(context.TODO(), cpSecretKey, &corev1.Secret{}).DoAndReturn(clientGet(cpSecret))
 			client.EXPECT().Delete(context.TODO(), acrConfigMap).Return(errorAcrConfigMapNotFound)
+			client.EXPECT().Delete(context.TODO(), acrCredentialProviderConfigMap).Return(errorAcrCredentialProviderConfigMapNotFound)
 
 			// Create valuesProvider
-			vp := NewValuesProvider(logger)
+			vp := NewValuesProvider(nil, nil, logger)
 			err := vp.(inject.Scheme).InjectScheme(scheme)
 			Expect(err).NotTo(HaveOccurred())
 			err = vp.(inject.Client).InjectClient(client)
@@ -714,9 +1071,10 @@ var _ = Describe("ValuesProvider", func() {
 			client := mockclient.NewMockClient(ctrl)
 			client.EXPECT().Get(context.TODO(), cpSecretKey, &corev1.Secret{}).DoAndReturn(clientGet(cpSecret))
 			client.EXPECT().Delete(context.TODO(), acrConfigMap).Return(errorAcrConfigMapNotFound)
+			client.EXPECT().Delete(context.TODO(), acrCredentialProviderConfigMap).Return(errorAcrCredentialProviderConfigMapNotFound)
 
 			// Create valuesProvider
-			vp := NewValuesProvider(logger)
+			vp := NewValuesProvider(nil, nil, logger)
 			err := vp.(inject.Scheme).InjectScheme(scheme)
 			Expect(err).NotTo(HaveOccurred())
 			err = vp.(inject.Client).InjectClient(client)
@@ -735,9 +1093,10 @@ var _ = Describe("ValuesProvider", func() {
 			client := mockclient.NewMockClient(ctrl)
 			client.EXPECT().Get(context.TODO(), cpSecretKey, &corev1.Secret{}).DoAndReturn(clientGet(cpSecret))
 			client.EXPECT().Delete(context.TODO(), acrConfigMap).Return(errorAcrConfigMapNotFound)
+			client.EXPECT().Delete(context.TODO(), acrCredentialProviderConfigMap).Return(errorAcrCredentialProviderConfigMapNotFound)
 
 			// Create valuesProvider
-			vp := NewValuesProvider(logger)
+			vp := NewValuesProvider(nil, nil, logger)
 			err := vp.(inject.Scheme).InjectScheme(scheme)
 			Expect(err).NotTo(HaveOccurred())
 			err = vp.(inject.Client).InjectClient(client)
@@ -753,21 +1112,86 @@ var _ = Describe("ValuesProvider", func() {
 	Describe("#GetControlPlaneChartValues", func() {
 		It("should return correct control plane chart values", func() {
 			// Create valuesProvider
-			vp := NewValuesProvider(logger)
+			vp := NewValuesProvider(nil, nil, logger)
 			err := vp.(inject.Scheme).InjectScheme(scheme)
 			Expect(err).NotTo(HaveOccurred())
 
 			// Call GetControlPlaneChartValues method and check the result
 			values, err := vp.GetControlPlaneChartValues(context.TODO(), cp, cluster, checksums, false)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(values).To(Equal(ccmChartValues))
+			Expect(values).To(Equal(controlPlaneChartValues))
+		})
+
+		It("should enable the CSI controllers as of Kubernetes 1.19", func() {
+			clusterK8s119 := &extensionscontroller.Cluster{Shoot: cluster.Shoot.DeepCopy()}
+			clusterK8s119.Shoot.Spec.Kubernetes.Version = "1.19.2"
+
+			// Create valuesProvider
+			vp := NewValuesProvider(nil, nil, logger)
+			err := vp.(inject.Scheme).InjectScheme(scheme)
+			Expect(err).NotTo(HaveOccurred())
+
+			// Call GetControlPlaneChartValues method and check the result
+			values, err := vp.GetControlPlaneChartValues(context.TODO(), cp, clusterK8s119, checksums, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(HaveKeyWithValue("csi-driver-controller", map[string]interface{}{
+				"enabled":  true,
+				"replicas": 1,
+				"podAnnotations": map[string]interface{}{
+					"checksum/secret-csi-driver-controller":    "1fd3ad0e7e1e4b5a5cbd4c0e5a58fd8bd56cc7d56e2bfe2f3bb56b7d4c2c1d8a",
+					"checksum/configmap-cloud-provider-config": "08a7bc7fe8f59b055f173145e211760a83f02cf89635cef26ebb351378635606",
+				},
+				"snapshotController": map[string]interface{}{
+					"replicas": 1,
+					"podAnnotations": map[string]interface{}{
+						"checksum/secret-csi-snapshot-controller": "7b0c36dd36e1a1c5e3a0b4e4d8a6e0f35e4d9b2f4c0a9c2b6e1f3a7d5c8b2e91",
+					},
+				},
+			}))
+		})
+
+		It("should disable the cloud node controller of the cloud-controller-manager as of Kubernetes 1.19", func() {
+			clusterK8s119 := &extensionscontroller.Cluster{Shoot: cluster.Shoot.DeepCopy()}
+			clusterK8s119.Shoot.Spec.Kubernetes.Version = "1.19.2"
+
+			// Create valuesProvider
+			vp := NewValuesProvider(nil, nil, logger)
+			err := vp.(inject.Scheme).InjectScheme(scheme)
+			Expect(err).NotTo(HaveOccurred())
+
+			// Call GetControlPlaneChartValues method and check the result
+			values, err := vp.GetControlPlaneChartValues(context.TODO(), cp, clusterK8s119, checksums, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values["cloud-controller-manager"]).To(HaveKeyWithValue("cloudNodeManager", true))
+		})
+
+		It("should not configure cloud routes for the overlay pod network mode", func() {
+			mode := apisazure.PodNetworkModeOverlay
+			clusterOverlay := &extensionscontroller.Cluster{Shoot: cluster.Shoot.DeepCopy()}
+			clusterOverlay.Shoot.Spec.Provider.InfrastructureConfig = &gardencorev1beta1.ProviderConfig{
+				RawExtension: runtime.RawExtension{
+					Raw: encode(&apisazure.InfrastructureConfig{
+						Networks: apisazure.NetworkConfig{PodNetworkMode: &mode},
+					}),
+				},
+			}
+
+			// Create valuesProvider
+			vp := NewValuesProvider(nil, nil, logger)
+			err := vp.(inject.Scheme).InjectScheme(scheme)
+			Expect(err).NotTo(HaveOccurred())
+
+			// Call GetControlPlaneChartValues method and check the result
+			values, err := vp.GetControlPlaneChartValues(context.TODO(), cp, clusterOverlay, checksums, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values["cloud-controller-manager"]).To(HaveKeyWithValue("configureCloudRoutes", false))
 		})
 	})
 
 	Describe("#GetControlPlaneShootChartValues", func() {
 		It("should return correct control plane shoot chart values for non zoned cluster", func() {
 			// Create valuesProvider
-			vp := NewValuesProvider(logger)
+			vp := NewValuesProvider(nil, nil, logger)
 			err := vp.(inject.Scheme).InjectScheme(scheme)
 			Expect(err).NotTo(HaveOccurred())
 
@@ -780,7 +1204,7 @@ var _ = Describe("ValuesProvider", func() {
 
 		It("should return correct control plane shoot chart values for zoned cluster", func() {
 			// Create valuesProvider
-			vp := NewValuesProvider(logger)
+			vp := NewValuesProvider(nil, nil, logger)
 			err := vp.(inject.Scheme).InjectScheme(scheme)
 			Expect(err).NotTo(HaveOccurred())
 
@@ -790,6 +1214,372 @@ var _ = Describe("ValuesProvider", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(values).To(Equal(controlPlaneShootZonedClusterChartValues))
 		})
+
+		It("should enable the CSI node plugins as of Kubernetes 1.19", func() {
+			clusterK8s119 := &extensionscontroller.Cluster{Shoot: cluster.Shoot.DeepCopy()}
+			clusterK8s119.Shoot.Spec.Kubernetes.Version = "1.19.2"
+
+			// Create valuesProvider
+			vp := NewValuesProvider(nil, nil, logger)
+			err := vp.(inject.Scheme).InjectScheme(scheme)
+			Expect(err).NotTo(HaveOccurred())
+
+			// Call GetControlPlaneShootChartValues method and check the result
+			values, err := vp.GetControlPlaneShootChartValues(context.TODO(), cp, clusterK8s119, checksums)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(HaveKeyWithValue("csi-driver", map[string]interface{}{
+				"enabled": true,
+				"volumeSnapshotClass": map[string]interface{}{
+					"name":   "default",
+					"driver": "disk.csi.azure.com",
+					"parameters": map[string]interface{}{
+						"incremental": "true",
+					},
+				},
+			}))
+		})
+
+		It("should return the volume snapshot class of the controlplane config", func() {
+			var (
+				incremental   = false
+				cpSnapshots   = cp.DeepCopy()
+				clusterK8s119 = &extensionscontroller.Cluster{Shoot: cluster.Shoot.DeepCopy()}
+			)
+			cpSnapshots.Spec.ProviderConfig.Raw = encode(&apisazure.ControlPlaneConfig{
+				Storage: &apisazure.Storage{
+					VolumeSnapshotClass: &apisazure.VolumeSnapshotClass{
+						Incremental:   &incremental,
+						ResourceGroup: pointer.StringPtr("snapshots"),
+					},
+				},
+			})
+			clusterK8s119.Shoot.Spec.Kubernetes.Version = "1.19.2"
+
+			// Create valuesProvider
+			vp := NewValuesProvider(nil, nil, logger)
+			err := vp.(inject.Scheme).InjectScheme(scheme)
+			Expect(err).NotTo(HaveOccurred())
+
+			// Call GetControlPlaneShootChartValues method and check the result
+			values, err := vp.GetControlPlaneShootChartValues(context.TODO(), cpSnapshots, clusterK8s119, checksums)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(HaveKeyWithValue("csi-driver", map[string]interface{}{
+				"enabled": true,
+				"volumeSnapshotClass": map[string]interface{}{
+					"name":   "default",
+					"driver": "disk.csi.azure.com",
+					"parameters": map[string]interface{}{
+						"incremental":   "false",
+						"resourceGroup": "snapshots",
+					},
+				},
+			}))
+		})
+
+		It("should enable the cloud-node-manager as of Kubernetes 1.19", func() {
+			clusterK8s119 := &extensionscontroller.Cluster{Shoot: cluster.Shoot.DeepCopy()}
+			clusterK8s119.Shoot.Spec.Kubernetes.Version = "1.19.2"
+
+			// Create valuesProvider
+			vp := NewValuesProvider(nil, nil, logger)
+			err := vp.(inject.Scheme).InjectScheme(scheme)
+			Expect(err).NotTo(HaveOccurred())
+
+			// Call GetControlPlaneShootChartValues method and check the result
+			values, err := vp.GetControlPlaneShootChartValues(context.TODO(), cp, clusterK8s119, checksums)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(HaveKeyWithValue("cloud-node-manager", map[string]interface{}{"enabled": true}))
+		})
+
+		It("should enable the scheduled events handler with the configured settings", func() {
+			cpScheduledEvents := cp.DeepCopy()
+			cpScheduledEvents.Spec.ProviderConfig = &runtime.RawExtension{
+				Raw: encode(&apisazure.ControlPlaneConfig{
+					ScheduledEventsHandler: &apisazure.ScheduledEventsHandler{
+						Enabled:      true,
+						EventTypes:   []apisazure.ScheduledEventType{apisazure.ScheduledEventTypePreempt},
+						DrainTimeout: &metav1.Duration{Duration: 2 * time.Minute},
+					},
+				}),
+			}
+
+			// Create valuesProvider
+			vp := NewValuesProvider(nil, nil, logger)
+			err := vp.(inject.Scheme).InjectScheme(scheme)
+			Expect(err).NotTo(HaveOccurred())
+
+			// Call GetControlPlaneShootChartValues method and check the result
+			values, err := vp.GetControlPlaneShootChartValues(context.TODO(), cpScheduledEvents, cluster, checksums)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(HaveKeyWithValue("scheduled-events-handler", map[string]interface{}{
+				"enabled":      true,
+				"eventTypes":   []string{"Preempt"},
+				"drainTimeout": "2m0s",
+			}))
+		})
+	})
+
+	Describe("#GetStorageClassesChartValues", func() {
+		var (
+			kubeAPIServerKey      = client.ObjectKey{Namespace: namespace, Name: v1beta1constants.DeploymentNameKubeAPIServer}
+			kubeAPIServerNotFound = errors.NewNotFound(schema.GroupResource{}, v1beta1constants.DeploymentNameKubeAPIServer)
+
+			diskStorageClassValues = func(name, skuName, volumeBindingMode string, isDefault bool) map[string]interface{} {
+				return map[string]interface{}{
+					"name":        name,
+					"default":     isDefault,
+					"provisioner": "kubernetes.io/azure-disk",
+					"parameters": map[string]interface{}{
+						"storageaccounttype": skuName,
+						"kind":               "managed",
+					},
+					"reclaimPolicy":     "Delete",
+					"volumeBindingMode": volumeBindingMode,
+				}
+			}
+			fileStorageClassValues = map[string]interface{}{
+				"name":        "files",
+				"default":     false,
+				"provisioner": "kubernetes.io/azure-file",
+				"parameters": map[string]interface{}{
+					"skuName": "Standard_LRS",
+				},
+				"reclaimPolicy":     "Delete",
+				"volumeBindingMode": "Immediate",
+			}
+		)
+
+		It("should return correct storage classes chart values", func() {
+			// Create mock client
+			client := mockclient.NewMockClient(ctrl)
+			client.EXPECT().Get(context.TODO(), kubeAPIServerKey, &appsv1.Deployment{}).Return(kubeAPIServerNotFound)
+
+			// Create valuesProvider
+			vp := NewValuesProvider(nil, nil, logger)
+			err := vp.(inject.Scheme).InjectScheme(scheme)
+			Expect(err).NotTo(HaveOccurred())
+			err = vp.(inject.Client).InjectClient(client)
+			Expect(err).NotTo(HaveOccurred())
+
+			// Call GetStorageClassesChartValues method and check the result
+			values, err := vp.GetStorageClassesChartValues(context.TODO(), cp, cluster)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(Equal(map[string]interface{}{
+				"storageClasses": []interface{}{
+					diskStorageClassValues("default", "Standard_LRS", "Immediate", true),
+					diskStorageClassValues("managed-standard-hdd", "Standard_LRS", "Immediate", false),
+					diskStorageClassValues("managed-standard-ssd", "StandardSSD_LRS", "Immediate", false),
+					diskStorageClassValues("managed-premium-ssd", "Premium_LRS", "Immediate", false),
+					fileStorageClassValues,
+				},
+			}))
+		})
+
+		It("should return storage classes chart values with a disk encryption set", func() {
+			diskEncryptionSetID := "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/diskEncryptionSets/des"
+			clusterDiskEncryption := &extensionscontroller.Cluster{
+				Shoot: &gardencorev1beta1.Shoot{
+					Spec: gardencorev1beta1.ShootSpec{
+						Kubernetes: gardencorev1beta1.Kubernetes{
+							Version: "1.13.4",
+						},
+						Provider: gardencorev1beta1.Provider{
+							InfrastructureConfig: &gardencorev1beta1.ProviderConfig{
+								RawExtension: runtime.RawExtension{
+									Raw: encode(&apisazure.InfrastructureConfig{
+										DiskEncryption: &apisazure.DiskEncryption{
+											DiskEncryptionSetID: &diskEncryptionSetID,
+										},
+									}),
+								},
+							},
+						},
+					},
+				},
+			}
+
+			// Create mock client
+			client := mockclient.NewMockClient(ctrl)
+			client.EXPECT().Get(context.TODO(), kubeAPIServerKey, &appsv1.Deployment{}).Return(kubeAPIServerNotFound)
+
+			// Create valuesProvider
+			vp := NewValuesProvider(nil, nil, logger)
+			err := vp.(inject.Scheme).InjectScheme(scheme)
+			Expect(err).NotTo(HaveOccurred())
+			err = vp.(inject.Client).InjectClient(client)
+			Expect(err).NotTo(HaveOccurred())
+
+			// Call GetStorageClassesChartValues method and check the result
+			values, err := vp.GetStorageClassesChartValues(context.TODO(), cp, clusterDiskEncryption)
+
+			Expect(err).NotTo(HaveOccurred())
+			defaultStorageClass := diskStorageClassValues("default", "Standard_LRS", "Immediate", true)
+			defaultStorageClass["parameters"].(map[string]interface{})["diskEncryptionSetID"] = diskEncryptionSetID
+			Expect(values["storageClasses"]).To(HaveLen(5))
+			Expect(values["storageClasses"]).To(ContainElement(defaultStorageClass))
+			Expect(values["storageClasses"]).To(ContainElement(fileStorageClassValues))
+		})
+
+		It("should return the storage classes of the controlplane config", func() {
+			var (
+				managedStorageClasses = false
+				cachingMode           = "ReadOnly"
+				reclaimPolicy         = corev1.PersistentVolumeReclaimRetain
+				cpStorage             = cp.DeepCopy()
+				clusterK8s119         = &extensionscontroller.Cluster{Shoot: cluster.Shoot.DeepCopy()}
+			)
+			cpStorage.Spec.ProviderConfig.Raw = encode(&apisazure.ControlPlaneConfig{
+				Storage: &apisazure.Storage{
+					ManagedStorageClasses: &managedStorageClasses,
+					DefaultStorageClass:   pointer.StringPtr("premium-zrs"),
+					StorageClasses: []apisazure.StorageClass{
+						{
+							Name:          "premium-zrs",
+							SkuName:       "Premium_ZRS",
+							CachingMode:   &cachingMode,
+							ReclaimPolicy: &reclaimPolicy,
+							Zones:         []string{"1", "2"},
+						},
+					},
+				},
+			})
+			clusterK8s119.Shoot.Spec.Kubernetes.Version = "1.19.2"
+			clusterK8s119.Shoot.Spec.Provider.InfrastructureConfig = &gardencorev1beta1.ProviderConfig{
+				RawExtension: runtime.RawExtension{
+					Raw: encode(&apisazure.InfrastructureConfig{Zoned: true}),
+				},
+			}
+
+			// Create mock client
+			client := mockclient.NewMockClient(ctrl)
+			client.EXPECT().Get(context.TODO(), kubeAPIServerKey, &appsv1.Deployment{}).Return(nil)
+
+			// Create valuesProvider
+			vp := NewValuesProvider(nil, nil, logger)
+			err := vp.(inject.Scheme).InjectScheme(scheme)
+			Expect(err).NotTo(HaveOccurred())
+			err = vp.(inject.Client).InjectClient(client)
+			Expect(err).NotTo(HaveOccurred())
+
+			// Call GetStorageClassesChartValues method and check the result
+			values, err := vp.GetStorageClassesChartValues(context.TODO(), cpStorage, clusterK8s119)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(Equal(map[string]interface{}{
+				"storageClasses": []interface{}{
+					map[string]interface{}{
+						"name":        "premium-zrs",
+						"default":     true,
+						"provisioner": "disk.csi.azure.com",
+						"parameters": map[string]interface{}{
+							"storageaccounttype": "Premium_ZRS",
+							"kind":               "managed",
+							"cachingmode":        "ReadOnly",
+						},
+						"reclaimPolicy":        "Retain",
+						"volumeBindingMode":    "WaitForFirstConsumer",
+						"allowVolumeExpansion": true,
+						"allowedTopologies": []interface{}{
+							map[string]interface{}{
+								"matchLabelExpressions": []interface{}{
+									map[string]interface{}{
+										"key":    "topology.disk.csi.azure.com/zone",
+										"values": []interface{}{"eu-west-1a-1", "eu-west-1a-2"},
+									},
+								},
+							},
+						},
+					},
+				},
+			}))
+		})
+	})
+
+	Describe("#getStorageClasses", func() {
+		It("should not change the storage classes provided by Gardener in existing zoned clusters", func() {
+			var (
+				reclaimPolicy     = corev1.PersistentVolumeReclaimDelete
+				volumeBindingMode = storagev1.VolumeBindingImmediate
+				existing          = func(name, skuName string) *storagev1.StorageClass {
+					return &storagev1.StorageClass{
+						ObjectMeta:        metav1.ObjectMeta{Name: name},
+						Provisioner:       "kubernetes.io/azure-disk",
+						Parameters:        map[string]string{"storageaccounttype": skuName, "kind": "managed"},
+						ReclaimPolicy:     &reclaimPolicy,
+						VolumeBindingMode: &volumeBindingMode,
+					}
+				}
+				existingStorageClasses = map[string]*storagev1.StorageClass{
+					"default":              existing("default", "Standard_LRS"),
+					"managed-standard-hdd": existing("managed-standard-hdd", "Standard_LRS"),
+					"managed-standard-ssd": existing("managed-standard-ssd", "StandardSSD_LRS"),
+					"managed-premium-ssd":  existing("managed-premium-ssd", "Premium_LRS"),
+				}
+			)
+
+			storageClasses, err := getStorageClasses(&apisazure.ControlPlaneConfig{}, &apisazure.InfrastructureConfig{Zoned: true}, "westeurope", "1.18.4", false)
+
+			Expect(err).NotTo(HaveOccurred())
+			for _, sc := range storageClasses {
+				if existingStorageClass, ok := existingStorageClasses[sc.Name]; ok {
+					Expect(storageClassChanged(existingStorageClass, sc)).To(BeFalse(), sc.Name)
+				}
+			}
+		})
+
+		It("should default the binding mode of additional storage classes of zoned clusters to WaitForFirstConsumer", func() {
+			cpConfig := &apisazure.ControlPlaneConfig{
+				Storage: &apisazure.Storage{
+					StorageClasses: []apisazure.StorageClass{{Name: "premium", SkuName: "Premium_LRS"}},
+				},
+			}
+
+			storageClasses, err := getStorageClasses(cpConfig, &apisazure.InfrastructureConfig{Zoned: true}, "westeurope", "1.18.4", false)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(storageClasses).To(ContainElement(PointTo(MatchFields(IgnoreExtras, Fields{
+				"ObjectMeta":        MatchFields(IgnoreExtras, Fields{"Name": Equal("premium")}),
+				"VolumeBindingMode": PointTo(Equal(storagev1.VolumeBindingWaitForFirstConsumer)),
+			}))))
+		})
+	})
+
+	Describe("#storageClassChanged", func() {
+		var (
+			reclaimPolicy     = corev1.PersistentVolumeReclaimDelete
+			volumeBindingMode = storagev1.VolumeBindingImmediate
+			storageClass      = &storagev1.StorageClass{
+				ObjectMeta:        metav1.ObjectMeta{Name: "default"},
+				Provisioner:       "kubernetes.io/azure-disk",
+				Parameters:        map[string]string{"storageaccounttype": "Standard_LRS", "kind": "managed"},
+				ReclaimPolicy:     &reclaimPolicy,
+				VolumeBindingMode: &volumeBindingMode,
+			}
+		)
+
+		It("should not report a change for equal immutable settings", func() {
+			desired := storageClass.DeepCopy()
+			desired.AllowVolumeExpansion = pointer.BoolPtr(true)
+			Expect(storageClassChanged(storageClass, desired)).To(BeFalse())
+		})
+
+		It("should report a change of the provisioner", func() {
+			desired := storageClass.DeepCopy()
+			desired.Provisioner = "disk.csi.azure.com"
+			Expect(storageClassChanged(storageClass, desired)).To(BeTrue())
+		})
+
+		It("should report a change of the volume binding mode", func() {
+			desired := storageClass.DeepCopy()
+			waitForFirstConsumer := storagev1.VolumeBindingWaitForFirstConsumer
+			desired.VolumeBindingMode = &waitForFirstConsumer
+			Expect(storageClassChanged(storageClass, desired)).To(BeTrue())
+		})
 	})
 })
 
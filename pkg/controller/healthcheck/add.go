@@ -17,8 +17,10 @@ package healthcheck
 import (
 	"time"
 
+	"github.com/gardener/gardener-extension-provider-azure/pkg/apis/config"
 	"github.com/gardener/gardener-extension-provider-azure/pkg/azure"
 
+	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
 	genericcontrolplaneactuator "github.com/gardener/gardener-extensions/pkg/controller/controlplane/genericactuator"
 	"github.com/gardener/gardener-extensions/pkg/controller/healthcheck"
 	healthcheckconfig "github.com/gardener/gardener-extensions/pkg/controller/healthcheck/config"
@@ -28,6 +30,7 @@ import (
 	extensionspredicate "github.com/gardener/gardener-extensions/pkg/predicate"
 	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	versionutils "github.com/gardener/gardener/pkg/utils/version"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -36,21 +39,31 @@ import (
 
 var (
 	defaultSyncPeriod = time.Second * 30
-	// DefaultAddOptions are the default DefaultAddArgs for AddToManager.
-	DefaultAddOptions = healthcheck.DefaultAddArgs{
-		HealthCheckConfig: healthcheckconfig.HealthCheckConfig{SyncPeriod: metav1.Duration{Duration: defaultSyncPeriod}},
+	// DefaultAddOptions are the default AddOptions for AddToManager.
+	DefaultAddOptions = AddOptions{
+		DefaultAddArgs: healthcheck.DefaultAddArgs{
+			HealthCheckConfig: healthcheckconfig.HealthCheckConfig{SyncPeriod: metav1.Duration{Duration: defaultSyncPeriod}},
+		},
 	}
 )
 
+// AddOptions are options to apply when adding the Azure health check controllers to the manager.
+type AddOptions struct {
+	healthcheck.DefaultAddArgs
+	// VirtualMachineHealthCheck contains the settings for the health check of the Azure virtual machines of the
+	// workers.
+	VirtualMachineHealthCheck config.VirtualMachineHealthCheckConfig
+}
+
 // RegisterHealthChecks registers health checks for each extension resource
 // HealthChecks are grouped by extension (e.g worker), extension.type (e.g azure) and  Health Check Type (e.g SystemComponentsHealthy)
-func RegisterHealthChecks(mgr manager.Manager, opts healthcheck.DefaultAddArgs) error {
+func RegisterHealthChecks(mgr manager.Manager, opts AddOptions) error {
 	if err := healthcheck.DefaultRegistration(
 		azure.Type,
 		extensionsv1alpha1.SchemeGroupVersion.WithKind(extensionsv1alpha1.ControlPlaneResource),
 		func() runtime.Object { return &extensionsv1alpha1.ControlPlane{} },
 		mgr,
-		opts,
+		opts.DefaultAddArgs,
 		[]predicate.Predicate{extensionspredicate.HasPurpose(extensionsv1alpha1.Normal)},
 		[]healthcheck.ConditionTypeToHealthCheck{
 			{
@@ -65,17 +78,52 @@ func RegisterHealthChecks(mgr manager.Manager, opts healthcheck.DefaultAddArgs)
 				ConditionType: string(gardencorev1beta1.ShootSystemComponentsHealthy),
 				HealthCheck:   general.CheckManagedResource(genericcontrolplaneactuator.StorageClassesChartResourceName),
 			},
+			{
+				ConditionType: string(gardencorev1beta1.ShootControlPlaneHealthy),
+				PreCheckFunc:  csiEnabledPreCheckFunc,
+				HealthCheck:   general.NewSeedDeploymentHealthChecker(azure.CSIControllerDiskName),
+			},
+			{
+				ConditionType: string(gardencorev1beta1.ShootControlPlaneHealthy),
+				PreCheckFunc:  csiEnabledPreCheckFunc,
+				HealthCheck:   general.NewSeedDeploymentHealthChecker(azure.CSIControllerFileName),
+			},
+			{
+				ConditionType: string(gardencorev1beta1.ShootControlPlaneHealthy),
+				PreCheckFunc:  csiEnabledPreCheckFunc,
+				HealthCheck:   general.NewSeedDeploymentHealthChecker(azure.CSISnapshotControllerName),
+			},
+			{
+				ConditionType: string(gardencorev1beta1.ShootSystemComponentsHealthy),
+				PreCheckFunc:  csiEnabledPreCheckFunc,
+				HealthCheck:   general.NewShootDaemonSetHealthChecker(azure.CSINodeDiskName),
+			},
+			{
+				ConditionType: string(gardencorev1beta1.ShootSystemComponentsHealthy),
+				PreCheckFunc:  csiEnabledPreCheckFunc,
+				HealthCheck:   general.NewShootDaemonSetHealthChecker(azure.CSINodeFileName),
+			},
+			{
+				ConditionType: string(gardencorev1beta1.ShootSystemComponentsHealthy),
+				PreCheckFunc:  cloudNodeManagerEnabledPreCheckFunc,
+				HealthCheck:   general.NewShootDaemonSetHealthChecker(azure.CloudNodeManagerName),
+			},
 		},
 	); err != nil {
 		return err
 	}
 
+	remediation := config.VirtualMachineRemediationNone
+	if opts.VirtualMachineHealthCheck.Remediation != nil {
+		remediation = *opts.VirtualMachineHealthCheck.Remediation
+	}
+
 	return healthcheck.DefaultRegistration(
 		azure.Type,
 		extensionsv1alpha1.SchemeGroupVersion.WithKind(extensionsv1alpha1.WorkerResource),
 		func() runtime.Object { return &extensionsv1alpha1.Worker{} },
 		mgr,
-		opts,
+		opts.DefaultAddArgs,
 		nil,
 		[]healthcheck.ConditionTypeToHealthCheck{
 			{
@@ -90,10 +138,29 @@ func RegisterHealthChecks(mgr manager.Manager, opts healthcheck.DefaultAddArgs)
 				ConditionType: string(gardencorev1beta1.ShootEveryNodeReady),
 				HealthCheck:   worker.NewSufficientNodesChecker(),
 			},
+			{
+				ConditionType: string(gardencorev1beta1.ShootEveryNodeReady),
+				HealthCheck:   NewVirtualMachinesHealthChecker(remediation),
+			},
 		},
 	)
 }
 
+// csiEnabledPreCheckFunc checks whether the Azure Disk and Azure File CSI drivers are deployed for the cluster.
+func csiEnabledPreCheckFunc(_ runtime.Object, cluster *extensionscontroller.Cluster) bool {
+	csiEnabled, err := versionutils.CompareVersions(cluster.Shoot.Spec.Kubernetes.Version, ">=", azure.CSIMigrationKubernetesVersion)
+	if err != nil {
+		return false
+	}
+	return csiEnabled
+}
+
+// cloudNodeManagerEnabledPreCheckFunc checks whether the cloud-node-manager is deployed for the cluster. It initializes
+// the nodes if the kubelet uses the external cloud provider, which is the case once the CSI drivers are used.
+func cloudNodeManagerEnabledPreCheckFunc(obj runtime.Object, cluster *extensionscontroller.Cluster) bool {
+	return csiEnabledPreCheckFunc(obj, cluster)
+}
+
 // AddToManager adds a controller with the default Options.
 func AddToManager(mgr manager.Manager) error {
 	return RegisterHealthChecks(mgr, DefaultAddOptions)
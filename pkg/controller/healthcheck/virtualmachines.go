@@ -0,0 +1,269 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package healthcheck
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	azureapihelper "github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure/helper"
+	"github.com/gardener/gardener-extension-provider-azure/pkg/apis/config"
+	azureclient "github.com/gardener/gardener-extension-provider-azure/pkg/azure/client"
+	"github.com/gardener/gardener-extensions/pkg/controller/healthcheck"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2019-07-01/compute"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	machinev1alpha1 "github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// provisioningStateFailed is the provisioning state of an Azure virtual machine whose last operation failed.
+	provisioningStateFailed = "Failed"
+	// reasonVirtualMachinesUnhealthy is the reason of the condition if a virtual machine has failed or has been deleted.
+	reasonVirtualMachinesUnhealthy = "VirtualMachinesUnhealthy"
+	// annotationLastRedeployTime is the annotation of a machine which records when the redeploy of its virtual machine
+	// has been issued the last time.
+	annotationLastRedeployTime = "azure.provider.extensions.gardener.cloud/last-redeploy-time"
+	// redeployBackoff is the duration after which a virtual machine which is still failed is redeployed again.
+	redeployBackoff = 30 * time.Minute
+)
+
+// newComputeClient creates the compute client for the subscription of the shoot. It is a variable to be able to
+// replace it in the tests.
+var newComputeClient = func(ctx context.Context, c client.Client, secretRef *corev1.SecretReference) (azureclient.Compute, error) {
+	return azureclient.NewComputeClientFromSecretRef(ctx, c, secretRef)
+}
+
+// VirtualMachinesHealthChecker contains all the information for the health check of the Azure virtual machines of a
+// worker. It compares the machines of the worker with the virtual machines in the resource group of the shoot.
+type VirtualMachinesHealthChecker struct {
+	logger      logr.Logger
+	remediation config.VirtualMachineRemediation
+	// Needs to be set by actuator before calling the Check function
+	seedClient client.Client
+	// make sure shoot client is instantiated
+	shootClient client.Client
+}
+
+// NewVirtualMachinesHealthChecker is a health check function which checks whether the Azure virtual machines of the
+// machines of a worker have failed or have been deleted. The given remediation is taken for the broken machines.
+func NewVirtualMachinesHealthChecker(remediation config.VirtualMachineRemediation) healthcheck.HealthCheck {
+	return &VirtualMachinesHealthChecker{
+		remediation: remediation,
+	}
+}
+
+// InjectSeedClient injects the seed client
+func (h *VirtualMachinesHealthChecker) InjectSeedClient(seedClient client.Client) {
+	h.seedClient = seedClient
+}
+
+// InjectShootClient injects the shoot client
+func (h *VirtualMachinesHealthChecker) InjectShootClient(shootClient client.Client) {
+	h.shootClient = shootClient
+}
+
+// SetLoggerSuffix injects the logger
+func (h *VirtualMachinesHealthChecker) SetLoggerSuffix(provider, extension string) {
+	h.logger = log.Log.WithName(fmt.Sprintf("%s-%s-healthcheck-virtual-machines", provider, extension))
+}
+
+// DeepCopy clones the healthCheck struct by making a copy and returning the pointer to that new copy
+func (h *VirtualMachinesHealthChecker) DeepCopy() healthcheck.HealthCheck {
+	copy := *h
+	return &copy
+}
+
+// Check executes the health check
+func (h *VirtualMachinesHealthChecker) Check(ctx context.Context, request types.NamespacedName) (*healthcheck.SingleCheckResult, error) {
+	worker := &extensionsv1alpha1.Worker{}
+	if err := h.seedClient.Get(ctx, request, worker); err != nil {
+		return nil, h.checkFailed(fmt.Errorf("failed to get worker %s: %v", request, err))
+	}
+
+	infraStatus, err := azureapihelper.InfrastructureStatusFromWorker(worker)
+	if err != nil {
+		return nil, h.checkFailed(fmt.Errorf("failed to decode infrastructureProviderStatus of worker %s: %v", request, err))
+	}
+	if infraStatus == nil {
+		return nil, h.checkFailed(fmt.Errorf("infrastructureProviderStatus of worker %s is not set", request))
+	}
+	resourceGroup := infraStatus.ResourceGroup.Name
+
+	machineList := &machinev1alpha1.MachineList{}
+	if err := h.seedClient.List(ctx, machineList, client.InNamespace(request.Namespace)); err != nil {
+		return nil, h.checkFailed(fmt.Errorf("failed to list machines in namespace %s: %v", request.Namespace, err))
+	}
+
+	computeClient, err := newComputeClient(ctx, h.seedClient, &worker.Spec.SecretRef)
+	if err != nil {
+		return nil, h.checkFailed(fmt.Errorf("failed to create compute client: %v", err))
+	}
+
+	virtualMachines, err := computeClient.ListVirtualMachines(ctx, resourceGroup)
+	if err != nil {
+		return nil, h.checkFailed(fmt.Errorf("failed to list virtual machines in resource group %s: %v", resourceGroup, err))
+	}
+	provisioningStates := make(map[string]string, len(virtualMachines))
+	for _, vm := range virtualMachines {
+		if vm.Name == nil {
+			continue
+		}
+		var provisioningState string
+		if vm.VirtualMachineProperties != nil && vm.ProvisioningState != nil {
+			provisioningState = *vm.ProvisioningState
+		}
+		provisioningStates[*vm.Name] = provisioningState
+	}
+
+	var problems []string
+	for i := range machineList.Items {
+		machine := &machineList.Items[i]
+		if !isMachineProvisioned(machine) {
+			continue
+		}
+
+		provisioningState, ok := provisioningStates[machine.Name]
+		switch {
+		case !ok:
+			problem := fmt.Sprintf("virtual machine of machine %s has been deleted", machine.Name)
+			problems = append(problems, problem)
+			// A deleted virtual machine cannot be redeployed.
+			if h.remediation == config.VirtualMachineRemediationReplace || h.remediation == config.VirtualMachineRemediationRedeploy {
+				h.replaceMachine(ctx, machine, problem)
+			}
+
+		case provisioningState == provisioningStateFailed:
+			problem := fmt.Sprintf("virtual machine of machine %s has failed", machine.Name)
+			if message := getInstanceViewErrorMessage(ctx, computeClient, resourceGroup, machine.Name); len(message) > 0 {
+				problem = fmt.Sprintf("%s: %s", problem, message)
+			}
+			problems = append(problems, problem)
+
+			switch h.remediation {
+			case config.VirtualMachineRemediationReplace:
+				h.replaceMachine(ctx, machine, problem)
+			case config.VirtualMachineRemediationRedeploy:
+				h.redeployVirtualMachine(ctx, computeClient, resourceGroup, machine)
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		err := fmt.Errorf("check for virtual machines failed: %s", strings.Join(problems, ", "))
+		h.logger.Error(err, "Health check failed")
+		return &healthcheck.SingleCheckResult{
+			IsHealthy: false,
+			Detail:    err.Error(),
+			Reason:    reasonVirtualMachinesUnhealthy,
+		}, nil
+	}
+	return &healthcheck.SingleCheckResult{
+		IsHealthy: true,
+	}, nil
+}
+
+func (h *VirtualMachinesHealthChecker) checkFailed(err error) error {
+	err = fmt.Errorf("check for virtual machines failed. %v", err)
+	h.logger.Error(err, "Health check failed")
+	return err
+}
+
+// replaceMachine marks the given machine as failed so that the machine-controller-manager replaces it.
+func (h *VirtualMachinesHealthChecker) replaceMachine(ctx context.Context, machine *machinev1alpha1.Machine, description string) {
+	h.logger.Info("Marking machine as failed", "machine", machine.Name, "namespace", machine.Namespace)
+
+	now := metav1.Now()
+	machine.Status.CurrentStatus = machinev1alpha1.CurrentStatus{
+		Phase:          machinev1alpha1.MachineFailed,
+		LastUpdateTime: now,
+	}
+	machine.Status.LastOperation = machinev1alpha1.LastOperation{
+		Description:    description,
+		State:          machinev1alpha1.MachineStateFailed,
+		Type:           machinev1alpha1.MachineOperationHealthCheck,
+		LastUpdateTime: now,
+	}
+	if err := h.seedClient.Status().Update(ctx, machine); err != nil {
+		h.logger.Error(err, "Could not mark machine as failed", "machine", machine.Name, "namespace", machine.Namespace)
+	}
+}
+
+// redeployVirtualMachine redeploys the virtual machine of the given machine unless its last redeploy has been issued
+// less than the redeploy backoff ago. The time of the redeploy is recorded in an annotation of the machine before it is
+// issued, so that a virtual machine whose redeploy fails is not redeployed by every check either.
+func (h *VirtualMachinesHealthChecker) redeployVirtualMachine(ctx context.Context, computeClient azureclient.Compute, resourceGroup string, machine *machinev1alpha1.Machine) {
+	now := time.Now()
+	if lastRedeployTime, err := time.Parse(time.RFC3339, machine.Annotations[annotationLastRedeployTime]); err == nil && now.Sub(lastRedeployTime) < redeployBackoff {
+		h.logger.Info("Skipping redeploy of virtual machine which has been redeployed recently", "machine", machine.Name, "namespace", machine.Namespace, "lastRedeployTime", lastRedeployTime)
+		return
+	}
+
+	patch := client.MergeFrom(machine.DeepCopy())
+	metav1.SetMetaDataAnnotation(&machine.ObjectMeta, annotationLastRedeployTime, now.UTC().Format(time.RFC3339))
+	if err := h.seedClient.Patch(ctx, machine, patch); err != nil {
+		h.logger.Error(err, "Could not record redeploy of virtual machine", "machine", machine.Name, "namespace", machine.Namespace)
+		return
+	}
+
+	h.logger.Info("Redeploying virtual machine", "machine", machine.Name, "namespace", machine.Namespace)
+	if err := computeClient.RedeployVirtualMachine(ctx, resourceGroup, machine.Name); err != nil {
+		h.logger.Error(err, "Could not redeploy virtual machine", "machine", machine.Name, "namespace", machine.Namespace)
+	}
+}
+
+// isMachineProvisioned checks whether the virtual machine of the given machine has been created and is not being
+// deleted, i.e. whether it is expected to exist.
+func isMachineProvisioned(machine *machinev1alpha1.Machine) bool {
+	if machine.DeletionTimestamp != nil {
+		return false
+	}
+
+	switch machine.Status.CurrentStatus.Phase {
+	case machinev1alpha1.MachineAvailable, machinev1alpha1.MachineRunning, machinev1alpha1.MachineUnknown:
+		return true
+	}
+	return false
+}
+
+// getInstanceViewErrorMessage returns the message of the first error status in the instance view of the given virtual
+// machine. It returns an empty string if the instance view cannot be retrieved or does not contain an error.
+func getInstanceViewErrorMessage(ctx context.Context, computeClient azureclient.Compute, resourceGroup, name string) string {
+	instanceView, err := computeClient.GetVirtualMachineInstanceView(ctx, resourceGroup, name)
+	if err != nil || instanceView == nil || instanceView.Statuses == nil {
+		return ""
+	}
+
+	for _, status := range *instanceView.Statuses {
+		if status.Level != compute.Error {
+			continue
+		}
+		if status.Message != nil {
+			return *status.Message
+		}
+		if status.DisplayStatus != nil {
+			return *status.DisplayStatus
+		}
+	}
+	return ""
+}
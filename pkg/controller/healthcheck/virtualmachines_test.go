@@ -0,0 +1,249 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package healthcheck
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure/v1alpha1"
+	"github.com/gardener/gardener-extension-provider-azure/pkg/apis/config"
+	azureclient "github.com/gardener/gardener-extension-provider-azure/pkg/azure/client"
+	mockclient "github.com/gardener/gardener-extensions/pkg/mock/controller-runtime/client"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2019-07-01/compute"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	machinev1alpha1 "github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type fakeCompute struct {
+	virtualMachines []compute.VirtualMachine
+	redeployed      []string
+}
+
+func (c *fakeCompute) ListVirtualMachines(context.Context, string) ([]compute.VirtualMachine, error) {
+	return c.virtualMachines, nil
+}
+
+func (c *fakeCompute) GetVirtualMachineInstanceView(context.Context, string, string) (*compute.VirtualMachineInstanceView, error) {
+	return &compute.VirtualMachineInstanceView{
+		Statuses: &[]compute.InstanceViewStatus{
+			{Level: compute.Info, DisplayStatus: pointer.StringPtr("VM running")},
+			{Level: compute.Error, Message: pointer.StringPtr("Allocation failed")},
+		},
+	}, nil
+}
+
+func (c *fakeCompute) RedeployVirtualMachine(_ context.Context, _, name string) error {
+	c.redeployed = append(c.redeployed, name)
+	return nil
+}
+
+type fakeStatusWriter struct {
+	failedMachines []string
+}
+
+func (w *fakeStatusWriter) Update(_ context.Context, obj runtime.Object, _ ...client.UpdateOption) error {
+	machine := obj.(*machinev1alpha1.Machine)
+	Expect(machine.Status.CurrentStatus.Phase).To(Equal(machinev1alpha1.MachineFailed))
+	w.failedMachines = append(w.failedMachines, machine.Name)
+	return nil
+}
+
+func (w *fakeStatusWriter) Patch(context.Context, runtime.Object, client.Patch, ...client.PatchOption) error {
+	return nil
+}
+
+var _ = Describe("VirtualMachinesHealthChecker", func() {
+	var (
+		ctx     = context.TODO()
+		request = types.NamespacedName{Namespace: "shoot--foo--bar", Name: "worker"}
+
+		ctrl                *gomock.Controller
+		c                   *mockclient.MockClient
+		statusWriter        *fakeStatusWriter
+		computeClient       *fakeCompute
+		oldNewComputeClient func(context.Context, client.Client, *corev1.SecretReference) (azureclient.Compute, error)
+
+		worker = &extensionsv1alpha1.Worker{
+			ObjectMeta: metav1.ObjectMeta{Namespace: request.Namespace, Name: request.Name},
+			Spec: extensionsv1alpha1.WorkerSpec{
+				SecretRef: corev1.SecretReference{Namespace: request.Namespace, Name: "cloudprovider"},
+				InfrastructureProviderStatus: &runtime.RawExtension{
+					Raw: encode(&v1alpha1.InfrastructureStatus{
+						TypeMeta:      metav1.TypeMeta{APIVersion: v1alpha1.SchemeGroupVersion.String(), Kind: "InfrastructureStatus"},
+						ResourceGroup: v1alpha1.ResourceGroup{Name: request.Namespace},
+					}),
+				},
+			},
+		}
+
+		newMachine = func(name string, phase machinev1alpha1.MachinePhase) machinev1alpha1.Machine {
+			return machinev1alpha1.Machine{
+				ObjectMeta: metav1.ObjectMeta{Namespace: request.Namespace, Name: name},
+				Status: machinev1alpha1.MachineStatus{
+					CurrentStatus: machinev1alpha1.CurrentStatus{Phase: phase},
+				},
+			}
+		}
+		newVirtualMachine = func(name, provisioningState string) compute.VirtualMachine {
+			return compute.VirtualMachine{
+				Name: pointer.StringPtr(name),
+				VirtualMachineProperties: &compute.VirtualMachineProperties{
+					ProvisioningState: pointer.StringPtr(provisioningState),
+				},
+			}
+		}
+
+		expectMachines = func(machines ...machinev1alpha1.Machine) {
+			c.EXPECT().Get(ctx, request, &extensionsv1alpha1.Worker{}).DoAndReturn(func(_ context.Context, _ client.ObjectKey, obj runtime.Object) error {
+				*obj.(*extensionsv1alpha1.Worker) = *worker
+				return nil
+			})
+			c.EXPECT().List(ctx, &machinev1alpha1.MachineList{}, client.InNamespace(request.Namespace)).DoAndReturn(func(_ context.Context, list runtime.Object, _ ...client.ListOption) error {
+				list.(*machinev1alpha1.MachineList).Items = machines
+				return nil
+			})
+		}
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		c = mockclient.NewMockClient(ctrl)
+		statusWriter = &fakeStatusWriter{}
+		c.EXPECT().Status().Return(statusWriter).AnyTimes()
+
+		computeClient = &fakeCompute{
+			virtualMachines: []compute.VirtualMachine{
+				newVirtualMachine("machine-running", "Succeeded"),
+				newVirtualMachine("machine-failed", provisioningStateFailed),
+			},
+		}
+		oldNewComputeClient = newComputeClient
+		newComputeClient = func(_ context.Context, _ client.Client, secretRef *corev1.SecretReference) (azureclient.Compute, error) {
+			Expect(secretRef).To(Equal(&worker.Spec.SecretRef))
+			return computeClient, nil
+		}
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+		newComputeClient = oldNewComputeClient
+	})
+
+	It("should report healthy virtual machines", func() {
+		expectMachines(
+			newMachine("machine-running", machinev1alpha1.MachineRunning),
+			newMachine("machine-pending", machinev1alpha1.MachinePending),
+		)
+		checker := NewVirtualMachinesHealthChecker(config.VirtualMachineRemediationReplace)
+		checker.InjectSeedClient(c)
+		checker.SetLoggerSuffix("azure", "worker")
+
+		result, err := checker.Check(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.IsHealthy).To(BeTrue())
+		Expect(statusWriter.failedMachines).To(BeEmpty())
+	})
+
+	It("should report failed and deleted virtual machines without remediation", func() {
+		expectMachines(
+			newMachine("machine-running", machinev1alpha1.MachineRunning),
+			newMachine("machine-failed", machinev1alpha1.MachineRunning),
+			newMachine("machine-deleted", machinev1alpha1.MachineUnknown),
+		)
+		checker := NewVirtualMachinesHealthChecker(config.VirtualMachineRemediationNone)
+		checker.InjectSeedClient(c)
+		checker.SetLoggerSuffix("azure", "worker")
+
+		result, err := checker.Check(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.IsHealthy).To(BeFalse())
+		Expect(result.Reason).To(Equal(reasonVirtualMachinesUnhealthy))
+		Expect(result.Detail).To(ContainSubstring("virtual machine of machine machine-failed has failed: Allocation failed"))
+		Expect(result.Detail).To(ContainSubstring("virtual machine of machine machine-deleted has been deleted"))
+		Expect(statusWriter.failedMachines).To(BeEmpty())
+		Expect(computeClient.redeployed).To(BeEmpty())
+	})
+
+	It("should mark the machines of failed and deleted virtual machines for replacement", func() {
+		expectMachines(
+			newMachine("machine-failed", machinev1alpha1.MachineRunning),
+			newMachine("machine-deleted", machinev1alpha1.MachineRunning),
+		)
+		checker := NewVirtualMachinesHealthChecker(config.VirtualMachineRemediationReplace)
+		checker.InjectSeedClient(c)
+		checker.SetLoggerSuffix("azure", "worker")
+
+		result, err := checker.Check(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.IsHealthy).To(BeFalse())
+		Expect(statusWriter.failedMachines).To(ConsistOf("machine-failed", "machine-deleted"))
+		Expect(computeClient.redeployed).To(BeEmpty())
+	})
+
+	It("should redeploy failed virtual machines and replace the machines of deleted ones", func() {
+		expectMachines(
+			newMachine("machine-failed", machinev1alpha1.MachineRunning),
+			newMachine("machine-deleted", machinev1alpha1.MachineRunning),
+		)
+		c.EXPECT().Patch(ctx, gomock.AssignableToTypeOf(&machinev1alpha1.Machine{}), gomock.Any()).DoAndReturn(func(_ context.Context, obj runtime.Object, _ client.Patch, _ ...client.PatchOption) error {
+			machine := obj.(*machinev1alpha1.Machine)
+			Expect(machine.Name).To(Equal("machine-failed"))
+			Expect(machine.Annotations).To(HaveKey(annotationLastRedeployTime))
+			return nil
+		})
+		checker := NewVirtualMachinesHealthChecker(config.VirtualMachineRemediationRedeploy)
+		checker.InjectSeedClient(c)
+		checker.SetLoggerSuffix("azure", "worker")
+
+		result, err := checker.Check(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.IsHealthy).To(BeFalse())
+		Expect(statusWriter.failedMachines).To(ConsistOf("machine-deleted"))
+		Expect(computeClient.redeployed).To(ConsistOf("machine-failed"))
+	})
+
+	It("should not redeploy a failed virtual machine again before the backoff has passed", func() {
+		recentlyRedeployed := newMachine("machine-failed", machinev1alpha1.MachineRunning)
+		recentlyRedeployed.Annotations = map[string]string{
+			annotationLastRedeployTime: time.Now().Add(-redeployBackoff / 2).UTC().Format(time.RFC3339),
+		}
+		expectMachines(recentlyRedeployed)
+		checker := NewVirtualMachinesHealthChecker(config.VirtualMachineRemediationRedeploy)
+		checker.InjectSeedClient(c)
+		checker.SetLoggerSuffix("azure", "worker")
+
+		result, err := checker.Check(ctx, request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.IsHealthy).To(BeFalse())
+		Expect(computeClient.redeployed).To(BeEmpty())
+	})
+})
+
+func encode(obj runtime.Object) []byte {
+	data, _ := json.Marshal(obj)
+	return data
+}
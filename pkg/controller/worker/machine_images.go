@@ -61,33 +61,37 @@ func (w *workerDelegate) GetMachineImages(ctx context.Context) (runtime.Object,
 	return workerStatusV1alpha1, nil
 }
 
-func (w *workerDelegate) findMachineImage(name, version string) (urn, id *string, err error) {
-	machineImage, err := helper.FindImageFromCloudProfile(w.cloudProfileConfig, name, version)
+func (w *workerDelegate) findMachineImage(name, version string, machineType *api.MachineType) (*api.MachineImage, error) {
+	machineImage, err := helper.FindImageFromCloudProfile(w.cloudProfileConfig, name, version, w.worker.Spec.Region, machineType)
 	if err == nil {
-		return machineImage.URN, machineImage.ID, nil
+		return machineImage, nil
 	}
 
 	// Try to look up machine image in worker provider status as it was not found in componentconfig.
 	if providerStatus := w.worker.Status.ProviderStatus; providerStatus != nil {
 		workerStatus := &api.WorkerStatus{}
 		if _, _, err := w.Decoder().Decode(providerStatus.Raw, nil, workerStatus); err != nil {
-			return nil, nil, errors.Wrapf(err, "could not decode worker status of worker '%s'", util.ObjectName(w.worker))
+			return nil, errors.Wrapf(err, "could not decode worker status of worker '%s'", util.ObjectName(w.worker))
 		}
 
-		machineImage, err := helper.FindMachineImage(workerStatus.MachineImages, name, version)
+		machineImage, err := helper.FindMachineImage(workerStatus.MachineImages, name, version, machineType)
 		if err != nil {
-			return nil, nil, worker.ErrorMachineImageNotFound(name, version)
+			return nil, worker.ErrorMachineImageNotFound(name, version)
 		}
 
-		return machineImage.URN, machineImage.ID, nil
+		return machineImage, nil
 	}
 
-	return nil, nil, worker.ErrorMachineImageNotFound(name, version)
+	return nil, worker.ErrorMachineImageNotFound(name, version)
 }
 
 func appendMachineImage(machineImages []api.MachineImage, machineImage api.MachineImage) []api.MachineImage {
-	if _, err := helper.FindMachineImage(machineImages, machineImage.Name, machineImage.Version); err != nil {
-		return append(machineImages, machineImage)
+	for _, image := range machineImages {
+		if image.Name == machineImage.Name && image.Version == machineImage.Version &&
+			helper.ArchitectureOrDefault(image.Architecture) == helper.ArchitectureOrDefault(machineImage.Architecture) &&
+			helper.HyperVGenerationOrDefault(image.HyperVGeneration) == helper.HyperVGenerationOrDefault(machineImage.HyperVGeneration) {
+			return machineImages
+		}
 	}
-	return machineImages
+	return append(machineImages, machineImage)
 }
@@ -29,10 +29,16 @@ import (
 	genericworkeractuator "github.com/gardener/gardener-extensions/pkg/controller/worker/genericactuator"
 	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
 	"github.com/gardener/gardener/pkg/client/kubernetes"
+	"github.com/gardener/gardener/pkg/utils"
 	machinev1alpha1 "github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
+// labelExcludeFromExternalLoadBalancers is the label which excludes nodes from the backend pools of the load
+// balancers which are managed by the cloud-controller-manager.
+const labelExcludeFromExternalLoadBalancers = "node.kubernetes.io/exclude-from-external-load-balancers"
+
 // MachineClassKind yields the name of the Azure machine class.
 func (w *workerDelegate) MachineClassKind() string {
 	return "AzureMachineClass"
@@ -106,6 +112,15 @@ func (w *workerDelegate) generateMachineConfig(ctx context.Context) error {
 		return err
 	}
 
+	controlPlaneConfig, err := azureapihelper.ControlPlaneConfigFromCluster(w.cluster)
+	if err != nil {
+		return err
+	}
+	excludedWorkerPools := sets.NewString()
+	if controlPlaneConfig != nil && controlPlaneConfig.LoadBalancer != nil {
+		excludedWorkerPools.Insert(controlPlaneConfig.LoadBalancer.ExcludedWorkerPools...)
+	}
+
 	// The AvailabilitySet will be only used for non zoned Shoots.
 	if !infrastructureStatus.Zoned {
 		nodesAvailabilitySet, err = azureapihelper.FindAvailabilitySetByPurpose(infrastructureStatus.AvailabilitySets, azureapi.PurposeNodes)
@@ -115,25 +130,26 @@ func (w *workerDelegate) generateMachineConfig(ctx context.Context) error {
 	}
 
 	for _, pool := range w.worker.Spec.Pools {
-		var additionalData []string
-		if infrastructureStatus.Identity != nil {
-			additionalData = append(additionalData, infrastructureStatus.Identity.ID)
+		// The capabilities of the machine type are optional, hence a machine type which is not listed is not an error.
+		var machineType *azureapi.MachineType
+		if w.cloudProfileConfig != nil {
+			machineType, _ = azureapihelper.FindMachineType(w.cloudProfileConfig.MachineTypes, pool.MachineType)
 		}
-		workerPoolHash, err := worker.WorkerPoolHash(pool, w.cluster, additionalData...)
+
+		machineImage, err := w.findMachineImage(pool.MachineImage.Name, pool.MachineImage.Version, machineType)
 		if err != nil {
 			return err
 		}
+		machineImages = appendMachineImage(machineImages, *machineImage)
 
-		urn, id, err := w.findMachineImage(pool.MachineImage.Name, pool.MachineImage.Version)
+		var additionalData []string
+		if infrastructureStatus.Identity != nil {
+			additionalData = append(additionalData, infrastructureStatus.Identity.ID)
+		}
+		workerPoolHash, err := worker.WorkerPoolHash(pool, w.cluster, additionalData...)
 		if err != nil {
 			return err
 		}
-		machineImages = appendMachineImage(machineImages, apisazure.MachineImage{
-			Name:    pool.MachineImage.Name,
-			Version: pool.MachineImage.Version,
-			URN:     urn,
-			ID:      id,
-		})
 
 		volumeSize, err := worker.DiskSize(pool.Volume.Size)
 		if err != nil {
@@ -157,10 +173,18 @@ func (w *workerDelegate) generateMachineConfig(ctx context.Context) error {
 		}
 
 		image := map[string]interface{}{}
-		if urn != nil {
-			image["urn"] = *urn
-		} else {
-			image["id"] = *id
+		switch {
+		case machineImage.URN != nil:
+			image["urn"] = *machineImage.URN
+		case machineImage.ID != nil:
+			image["id"] = *machineImage.ID
+		default:
+			return fmt.Errorf("machine image %q in version %q has no image reference", pool.MachineImage.Name, pool.MachineImage.Version)
+		}
+
+		labels := pool.Labels
+		if excludedWorkerPools.Has(pool.Name) {
+			labels = utils.MergeStringMaps(pool.Labels, map[string]string{labelExcludeFromExternalLoadBalancers: "true"})
 		}
 
 		generateMachineClassAndDeployment := func(zone *zoneInfo, availabilitySetID *string) (worker.MachineDeployment, map[string]interface{}) {
@@ -170,7 +194,7 @@ func (w *workerDelegate) generateMachineConfig(ctx context.Context) error {
 					Maximum:        pool.Maximum,
 					MaxSurge:       pool.MaxSurge,
 					MaxUnavailable: pool.MaxUnavailable,
-					Labels:         pool.Labels,
+					Labels:         labels,
 					Annotations:    pool.Annotations,
 					Taints:         pool.Taints,
 				}
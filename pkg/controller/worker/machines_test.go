@@ -126,6 +126,7 @@ var _ = Describe("Machines", func() {
 				decoder                runtime.Decoder
 				clusterWithoutImages   *extensionscontroller.Cluster
 				cluster                *extensionscontroller.Cluster
+				infrastructureStatus   *apisazure.InfrastructureStatus
 				w                      *extensionsv1alpha1.Worker
 			)
 
@@ -211,6 +212,32 @@ var _ = Describe("Machines", func() {
 					Shoot: clusterWithoutImages.Shoot,
 				}
 
+				infrastructureStatus = &apisazure.InfrastructureStatus{
+					ResourceGroup: apisazure.ResourceGroup{
+						Name: resourceGroupName,
+					},
+					Networks: apisazure.NetworkStatus{
+						VNet: apisazure.VNetStatus{
+							Name: vnetName,
+						},
+						Subnets: []apisazure.Subnet{
+							{
+								Purpose: apisazure.PurposeNodes,
+								Name:    subnetName,
+							},
+						},
+					},
+					AvailabilitySets: []apisazure.AvailabilitySet{
+						{
+							Purpose: apisazure.PurposeNodes,
+							ID:      availabilitySetID,
+						},
+					},
+					Identity: &apisazure.IdentityStatus{
+						ID: identityID,
+					},
+				}
+
 				w = &extensionsv1alpha1.Worker{
 					ObjectMeta: metav1.ObjectMeta{
 						Namespace: namespace,
@@ -223,31 +250,7 @@ var _ = Describe("Machines", func() {
 						Region:       region,
 						SSHPublicKey: []byte(sshKey),
 						InfrastructureProviderStatus: &runtime.RawExtension{
-							Raw: encode(&apisazure.InfrastructureStatus{
-								ResourceGroup: apisazure.ResourceGroup{
-									Name: resourceGroupName,
-								},
-								Networks: apisazure.NetworkStatus{
-									VNet: apisazure.VNetStatus{
-										Name: vnetName,
-									},
-									Subnets: []apisazure.Subnet{
-										{
-											Purpose: apisazure.PurposeNodes,
-											Name:    subnetName,
-										},
-									},
-								},
-								AvailabilitySets: []apisazure.AvailabilitySet{
-									{
-										Purpose: apisazure.PurposeNodes,
-										ID:      availabilitySetID,
-									},
-								},
-								Identity: &apisazure.IdentityStatus{
-									ID: identityID,
-								},
-							}),
+							Raw: encode(infrastructureStatus),
 						},
 						Pools: []extensionsv1alpha1.WorkerPool{
 							{
@@ -406,6 +409,204 @@ var _ = Describe("Machines", func() {
 					Expect(err).NotTo(HaveOccurred())
 					Expect(result).To(Equal(machineDeployments))
 				})
+
+				It("should return the expected machine classes for region specific images", func() {
+					regionImageID := "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/images/my-os-westeurope"
+					cloudProfileConfig := &apiv1alpha1.CloudProfileConfig{
+						TypeMeta: metav1.TypeMeta{
+							APIVersion: apiv1alpha1.SchemeGroupVersion.String(),
+							Kind:       "CloudProfileConfig",
+						},
+						MachineImages: []apiv1alpha1.MachineImages{
+							{
+								Name: machineImageName,
+								Versions: []apiv1alpha1.MachineImageVersion{
+									{
+										Version: machineImageVersion,
+										URN:     &machineImageURN,
+										Regions: []apiv1alpha1.RegionImageMapping{
+											{
+												Name: region,
+												ID:   &regionImageID,
+											},
+										},
+									},
+								},
+							},
+						},
+					}
+					cluster.CloudProfile.Spec.ProviderConfig.Raw = encode(cloudProfileConfig)
+
+					for _, machineClass := range machineClasses["machineClasses"].([]map[string]interface{}) {
+						machineClass["image"] = map[string]interface{}{
+							"id": regionImageID,
+						}
+					}
+
+					workerDelegate, _ = NewWorkerDelegate(common.NewClientContext(c, scheme, decoder), chartApplier, "", w, cluster)
+
+					expectGetSecretCallToWork(c, azureClientID, azureClientSecret, azureSubscriptionID, azureTenantID)
+					chartApplier.EXPECT().Apply(context.TODO(), filepath.Join(azure.InternalChartsPath, "machineclass"), namespace, "machineclass", kubernetes.Values(machineClasses)).Return(nil)
+
+					Expect(workerDelegate.DeployMachineClasses(context.TODO())).To(Succeed())
+
+					machineImages, err := workerDelegate.GetMachineImages(context.TODO())
+					Expect(err).NotTo(HaveOccurred())
+					Expect(machineImages).To(Equal(&apiv1alpha1.WorkerStatus{
+						TypeMeta: metav1.TypeMeta{
+							APIVersion: apiv1alpha1.SchemeGroupVersion.String(),
+							Kind:       "WorkerStatus",
+						},
+						MachineImages: []apiv1alpha1.MachineImage{
+							{
+								Name:    machineImageName,
+								Version: machineImageVersion,
+								ID:      &regionImageID,
+							},
+						},
+					}))
+				})
+
+				It("should return machine classes with the image variant matching the machine type", func() {
+					var (
+						imageID      = "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/images/my-os-gen2"
+						generationV2 = apiv1alpha1.HyperVGenerationV2
+					)
+					cloudProfileConfig := &apiv1alpha1.CloudProfileConfig{
+						TypeMeta: metav1.TypeMeta{
+							APIVersion: apiv1alpha1.SchemeGroupVersion.String(),
+							Kind:       "CloudProfileConfig",
+						},
+						MachineImages: []apiv1alpha1.MachineImages{
+							{
+								Name: machineImageName,
+								Versions: []apiv1alpha1.MachineImageVersion{
+									{Version: machineImageVersion, URN: &machineImageURN},
+									{Version: machineImageVersion, ID: &imageID, HyperVGeneration: &generationV2},
+								},
+							},
+						},
+						MachineTypes: []apiv1alpha1.MachineType{
+							{
+								Name:              machineType,
+								HyperVGenerations: []apiv1alpha1.HyperVGeneration{generationV2},
+							},
+						},
+					}
+					cluster.CloudProfile.Spec.ProviderConfig.Raw = encode(cloudProfileConfig)
+
+					for _, machineClass := range machineClasses["machineClasses"].([]map[string]interface{}) {
+						machineClass["image"] = map[string]interface{}{
+							"id": imageID,
+						}
+					}
+
+					workerDelegate, _ = NewWorkerDelegate(common.NewClientContext(c, scheme, decoder), chartApplier, "", w, cluster)
+
+					expectGetSecretCallToWork(c, azureClientID, azureClientSecret, azureSubscriptionID, azureTenantID)
+					chartApplier.EXPECT().Apply(context.TODO(), filepath.Join(azure.InternalChartsPath, "machineclass"), namespace, "machineclass", kubernetes.Values(machineClasses)).Return(nil)
+
+					Expect(workerDelegate.DeployMachineClasses(context.TODO())).To(Succeed())
+
+					machineImages, err := workerDelegate.GetMachineImages(context.TODO())
+					Expect(err).NotTo(HaveOccurred())
+					Expect(machineImages).To(Equal(&apiv1alpha1.WorkerStatus{
+						TypeMeta: metav1.TypeMeta{
+							APIVersion: apiv1alpha1.SchemeGroupVersion.String(),
+							Kind:       "WorkerStatus",
+						},
+						MachineImages: []apiv1alpha1.MachineImage{
+							{
+								Name:             machineImageName,
+								Version:          machineImageVersion,
+								ID:               &imageID,
+								HyperVGeneration: &generationV2,
+							},
+						},
+					}))
+				})
+
+				It("should record the image variants of all Hyper-V generations in the worker status", func() {
+					var (
+						imageID      = "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/images/my-os-gen2"
+						generationV1 = apiv1alpha1.HyperVGenerationV1
+						generationV2 = apiv1alpha1.HyperVGenerationV2
+					)
+					cloudProfileConfig := &apiv1alpha1.CloudProfileConfig{
+						TypeMeta: metav1.TypeMeta{
+							APIVersion: apiv1alpha1.SchemeGroupVersion.String(),
+							Kind:       "CloudProfileConfig",
+						},
+						MachineImages: []apiv1alpha1.MachineImages{
+							{
+								Name: machineImageName,
+								Versions: []apiv1alpha1.MachineImageVersion{
+									{Version: machineImageVersion, URN: &machineImageURN},
+									{Version: machineImageVersion, ID: &imageID, HyperVGeneration: &generationV2},
+								},
+							},
+						},
+						MachineTypes: []apiv1alpha1.MachineType{
+							{Name: machineType, HyperVGenerations: []apiv1alpha1.HyperVGeneration{generationV1}},
+							{Name: "large-v2", HyperVGenerations: []apiv1alpha1.HyperVGeneration{generationV2}},
+						},
+					}
+					cluster.CloudProfile.Spec.ProviderConfig.Raw = encode(cloudProfileConfig)
+					w.Spec.Pools[1].MachineType = "large-v2"
+
+					workerDelegate, _ = NewWorkerDelegate(common.NewClientContext(c, scheme, decoder), chartApplier, "", w, cluster)
+
+					expectGetSecretCallToWork(c, azureClientID, azureClientSecret, azureSubscriptionID, azureTenantID)
+					chartApplier.EXPECT().Apply(context.TODO(), filepath.Join(azure.InternalChartsPath, "machineclass"), namespace, "machineclass", gomock.Any()).Return(nil)
+
+					Expect(workerDelegate.DeployMachineClasses(context.TODO())).To(Succeed())
+
+					machineImages, err := workerDelegate.GetMachineImages(context.TODO())
+					Expect(err).NotTo(HaveOccurred())
+					Expect(machineImages.(*apiv1alpha1.WorkerStatus).MachineImages).To(Equal([]apiv1alpha1.MachineImage{
+						{
+							Name:    machineImageName,
+							Version: machineImageVersion,
+							URN:     &machineImageURN,
+						},
+						{
+							Name:             machineImageName,
+							Version:          machineImageVersion,
+							ID:               &imageID,
+							HyperVGeneration: &generationV2,
+						},
+					}))
+				})
+
+				It("should return machine deployments which are excluded from the load balancers", func() {
+					cluster.Shoot.Spec.Provider.ControlPlaneConfig = &gardencorev1beta1.ProviderConfig{
+						RawExtension: runtime.RawExtension{
+							Raw: encode(&apiv1alpha1.ControlPlaneConfig{
+								TypeMeta: metav1.TypeMeta{
+									APIVersion: apiv1alpha1.SchemeGroupVersion.String(),
+									Kind:       "ControlPlaneConfig",
+								},
+								LoadBalancer: &apiv1alpha1.LoadBalancerConfig{
+									ExcludedWorkerPools: []string{namePool2},
+								},
+							}),
+						},
+					}
+					machineDeployments[1].Labels = map[string]string{
+						"node.kubernetes.io/exclude-from-external-load-balancers": "true",
+					}
+
+					workerDelegate, _ = NewWorkerDelegate(common.NewClientContext(c, scheme, decoder), chartApplier, "", w, cluster)
+
+					expectGetSecretCallToWork(c, azureClientID, azureClientSecret, azureSubscriptionID, azureTenantID)
+					chartApplier.EXPECT().Apply(context.TODO(), filepath.Join(azure.InternalChartsPath, "machineclass"), namespace, "machineclass", kubernetes.Values(machineClasses)).Return(nil)
+
+					Expect(workerDelegate.DeployMachineClasses(context.TODO())).To(Succeed())
+
+					result, err := workerDelegate.GenerateMachineDeployments(context.TODO())
+					Expect(err).NotTo(HaveOccurred())
+					Expect(result).To(Equal(machineDeployments))
+				})
 			})
 
 			It("should fail because the secret cannot be read", func() {
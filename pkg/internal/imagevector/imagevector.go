@@ -52,3 +52,10 @@ func TerraformerImage() string {
 	runtime.Must(err)
 	return image.String()
 }
+
+// KMSPluginImage returns the Azure KMS plugin image.
+func KMSPluginImage() string {
+	image, err := imageVector.FindImage(azure.KMSPluginImageName)
+	runtime.Must(err)
+	return image.String()
+}
@@ -15,7 +15,11 @@
 package infrastructure
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	"path/filepath"
+	"strings"
 
 	api "github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure"
 	"github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure/helper"
@@ -70,10 +74,12 @@ func ComputeTerraformerChartValues(infra *extensionsv1alpha1.Infrastructure, cli
 		createVNet            = true
 		createAvailabilitySet = false
 		createNatGateway      = false
+		createIdentity        = false
 		resourceGroupName     = infra.Namespace
 
-		identityConfig map[string]interface{}
-		azure          = map[string]interface{}{
+		identityConfig     map[string]interface{}
+		outboundRuleConfig map[string]interface{}
+		azure              = map[string]interface{}{
 			"subscriptionID": clientAuth.SubscriptionID,
 			"tenantID":       clientAuth.TenantID,
 			"region":         infra.Spec.Region,
@@ -121,13 +127,13 @@ func ComputeTerraformerChartValues(infra *extensionsv1alpha1.Infrastructure, cli
 			return nil, err
 		}
 
-		updateDomainCount, err := helper.FindDomainCountByRegion(cloudProfileConfig.CountUpdateDomains, infra.Spec.Region)
+		updateDomainCount, err := helper.FindUpdateDomainCount(cloudProfileConfig, infra.Spec.Region)
 		if err != nil {
 			return nil, err
 		}
 		azure["countUpdateDomains"] = updateDomainCount
 
-		countFaultDomains, err := helper.FindDomainCountByRegion(cloudProfileConfig.CountFaultDomains, infra.Spec.Region)
+		countFaultDomains, err := helper.FindFaultDomainCount(cloudProfileConfig, infra.Spec.Region)
 		if err != nil {
 			return nil, err
 		}
@@ -138,15 +144,57 @@ func ComputeTerraformerChartValues(infra *extensionsv1alpha1.Infrastructure, cli
 		createNatGateway = true
 	}
 
-	if config.Identity != nil && config.Identity.Name != "" && config.Identity.ResourceGroup != "" {
+	// The outbound rule can only be configured on the standard load balancer which is only used by zoned clusters.
+	if config.Zoned {
+		controlPlaneConfig, err := helper.ControlPlaneConfigFromCluster(cluster)
+		if err != nil {
+			return nil, err
+		}
+		if controlPlaneConfig != nil && controlPlaneConfig.LoadBalancer != nil && controlPlaneConfig.LoadBalancer.OutboundRule != nil {
+			var (
+				outboundRule           = controlPlaneConfig.LoadBalancer.OutboundRule
+				allocatedOutboundPorts = int32(0)
+				idleTimeoutInMinutes   = int32(4)
+			)
+			if outboundRule.AllocatedOutboundPorts != nil {
+				allocatedOutboundPorts = *outboundRule.AllocatedOutboundPorts
+			}
+			if outboundRule.IdleTimeoutInMinutes != nil {
+				idleTimeoutInMinutes = *outboundRule.IdleTimeoutInMinutes
+			}
+			outboundRuleConfig = map[string]interface{}{
+				"allocatedOutboundPorts": allocatedOutboundPorts,
+				"idleTimeoutInMinutes":   idleTimeoutInMinutes,
+			}
+		}
+	}
+
+	if hasExistingIdentity(config) {
 		identityConfig = map[string]interface{}{
 			"name":          config.Identity.Name,
 			"resourceGroup": config.Identity.ResourceGroup,
 		}
+	} else if len(config.ContainerRegistries) > 0 {
+		// Create an identity for the nodes which is allowed to pull from the container registries.
+		createIdentity = true
+		identityConfig = map[string]interface{}{
+			"name":          fmt.Sprintf("%s-nodes", infra.Namespace),
+			"resourceGroup": resourceGroupName,
+		}
+	}
+	if identityConfig != nil {
 		outputKeys["identityID"] = TerraformerOutputKeyIdentityID
 		outputKeys["identityClientID"] = TerraformerOutputKeyIdentityClientID
 	}
 
+	containerRegistriesConfig := make([]map[string]interface{}, 0, len(config.ContainerRegistries))
+	for _, registry := range config.ContainerRegistries {
+		containerRegistriesConfig = append(containerRegistriesConfig, map[string]interface{}{
+			"id":  registry.ID,
+			"key": containerRegistryKey(registry.ID),
+		})
+	}
+
 	return map[string]interface{}{
 		"azure": azure,
 		"create": map[string]interface{}{
@@ -154,6 +202,7 @@ func ComputeTerraformerChartValues(infra *extensionsv1alpha1.Infrastructure, cli
 			"vnet":            createVNet,
 			"availabilitySet": createAvailabilitySet,
 			"natGateway":      createNatGateway,
+			"identity":        createIdentity,
 		},
 		"resourceGroup": map[string]interface{}{
 			"name": resourceGroupName,
@@ -166,8 +215,10 @@ func ComputeTerraformerChartValues(infra *extensionsv1alpha1.Infrastructure, cli
 		"networks": map[string]interface{}{
 			"worker": config.Networks.Workers,
 		},
-		"identity":   identityConfig,
-		"outputKeys": outputKeys,
+		"identity":            identityConfig,
+		"containerRegistries": containerRegistriesConfig,
+		"outboundRule":        outboundRuleConfig,
+		"outputKeys":          outputKeys,
 	}, nil
 }
 
@@ -240,7 +291,7 @@ func ExtractTerraformState(tf terraformer.Terraformer, config *api.Infrastructur
 		outputKeys = append(outputKeys, TerraformerOutputKeyAvailabilitySetID, TerraformerOutputKeyAvailabilitySetName)
 	}
 
-	if config.Identity != nil && config.Identity.Name != "" && config.Identity.ResourceGroup != "" {
+	if hasIdentity(config) {
 		outputKeys = append(outputKeys, TerraformerOutputKeyIdentityID, TerraformerOutputKeyIdentityClientID)
 	}
 
@@ -266,7 +317,7 @@ func ExtractTerraformState(tf terraformer.Terraformer, config *api.Infrastructur
 		tfState.AvailabilitySetName = vars[TerraformerOutputKeyAvailabilitySetName]
 	}
 
-	if config.Identity != nil && config.Identity.Name != "" && config.Identity.ResourceGroup != "" {
+	if hasIdentity(config) {
 		tfState.IdentityID = vars[TerraformerOutputKeyIdentityID]
 		tfState.IdentityClientID = vars[TerraformerOutputKeyIdentityClientID]
 	}
@@ -336,9 +387,27 @@ func ComputeStatus(tf terraformer.Terraformer, config *api.InfrastructureConfig)
 	status := StatusFromTerraformState(state)
 
 	// Check if ACR access should be configured.
-	if config.Identity != nil && config.Identity.ACRAccess != nil && *config.Identity.ACRAccess && status.Identity != nil {
+	if status.Identity != nil && (len(config.ContainerRegistries) > 0 || (config.Identity != nil && config.Identity.ACRAccess != nil && *config.Identity.ACRAccess)) {
 		status.Identity.ACRAccess = true
 	}
 
 	return status, nil
 }
+
+// hasExistingIdentity returns true if an existing managed identity for the nodes is configured.
+func hasExistingIdentity(config *api.InfrastructureConfig) bool {
+	return config.Identity != nil && config.Identity.Name != "" && config.Identity.ResourceGroup != ""
+}
+
+// hasIdentity returns true if the nodes get a managed identity, i.e. either an existing one is configured or one is
+// created for the access to the container registries.
+func hasIdentity(config *api.InfrastructureConfig) bool {
+	return hasExistingIdentity(config) || len(config.ContainerRegistries) > 0
+}
+
+// containerRegistryKey returns a key for the terraform resources of the container registry with the given resource id
+// which does not change if other registries are added or removed. Azure resource ids are case-insensitive.
+func containerRegistryKey(id string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(id)))
+	return hex.EncodeToString(sum[:])[:16]
+}
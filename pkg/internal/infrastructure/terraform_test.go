@@ -16,6 +16,7 @@ package infrastructure
 
 import (
 	"encoding/json"
+	"fmt"
 
 	api "github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure"
 	apiv1alpha1 "github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure/v1alpha1"
@@ -146,6 +147,7 @@ var _ = Describe("Terraform", func() {
 			expectedOutputKeysValues    map[string]interface{}
 			expectedResourceGroupValues map[string]interface{}
 			expectedIdentityValues      map[string]interface{}
+			expectedOutboundRuleValues  map[string]interface{}
 		)
 
 		BeforeEach(func() {
@@ -159,6 +161,7 @@ var _ = Describe("Terraform", func() {
 				"vnet":            true,
 				"availabilitySet": false,
 				"natGateway":      false,
+				"identity":        false,
 			}
 			expectedResourceGroupValues = map[string]interface{}{
 				"name": infra.Namespace,
@@ -179,11 +182,13 @@ var _ = Describe("Terraform", func() {
 			}
 
 			expectedValues = map[string]interface{}{
-				"azure":         expectedAzureValues,
-				"create":        expectedCreateValues,
-				"resourceGroup": expectedResourceGroupValues,
-				"identity":      expectedIdentityValues,
-				"clusterName":   infra.Namespace,
+				"azure":               expectedAzureValues,
+				"create":              expectedCreateValues,
+				"resourceGroup":       expectedResourceGroupValues,
+				"identity":            expectedIdentityValues,
+				"containerRegistries": []map[string]interface{}{},
+				"outboundRule":        expectedOutboundRuleValues,
+				"clusterName":         infra.Namespace,
 				"networks": map[string]interface{}{
 					"worker": config.Networks.Workers,
 				},
@@ -267,6 +272,49 @@ var _ = Describe("Terraform", func() {
 			Expect(values).To(BeEquivalentTo(expectedValues))
 		})
 
+		It("should correctly compute terraform chart values with container registries", func() {
+			registryID := "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.ContainerRegistry/registries/registry"
+			config.ContainerRegistries = []api.ContainerRegistry{{ID: registryID}}
+
+			expectedCreateValues["identity"] = true
+			expectedValues["identity"] = map[string]interface{}{
+				"name":          fmt.Sprintf("%s-nodes", infra.Namespace),
+				"resourceGroup": infra.Namespace,
+			}
+			expectedValues["containerRegistries"] = []map[string]interface{}{
+				{"id": registryID, "key": "f42e642765d0b1b5"},
+			}
+			expectedOutputKeysValues["identityID"] = TerraformerOutputKeyIdentityID
+			expectedOutputKeysValues["identityClientID"] = TerraformerOutputKeyIdentityClientID
+
+			values, err := ComputeTerraformerChartValues(infra, clientAuth, config, cluster)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(values).To(BeEquivalentTo(expectedValues))
+		})
+
+		It("should correctly compute terraform chart values with container registries and an existing identity", func() {
+			registryID := "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.ContainerRegistry/registries/registry"
+			config.ContainerRegistries = []api.ContainerRegistry{{ID: registryID}}
+			config.Identity = &api.IdentityConfig{
+				Name:          "identity-name",
+				ResourceGroup: "identity-rg",
+			}
+
+			expectedValues["identity"] = map[string]interface{}{
+				"name":          "identity-name",
+				"resourceGroup": "identity-rg",
+			}
+			expectedValues["containerRegistries"] = []map[string]interface{}{
+				{"id": registryID, "key": "f42e642765d0b1b5"},
+			}
+			expectedOutputKeysValues["identityID"] = TerraformerOutputKeyIdentityID
+			expectedOutputKeysValues["identityClientID"] = TerraformerOutputKeyIdentityClientID
+
+			values, err := ComputeTerraformerChartValues(infra, clientAuth, config, cluster)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(values).To(BeEquivalentTo(expectedValues))
+		})
+
 		Context("NatGateway", func() {
 			It("should correctly compute terraform chart values with NatGateway", func() {
 				config.Networks.NatGateway = &api.NatGatewayConfig{
@@ -278,6 +326,55 @@ var _ = Describe("Terraform", func() {
 				Expect(values).To(BeEquivalentTo(expectedValues))
 			})
 		})
+
+		Context("OutboundRule", func() {
+			var (
+				allocatedOutboundPorts = int32(1024)
+				idleTimeoutInMinutes   = int32(30)
+			)
+
+			setControlPlaneConfig := func(outboundRule *apiv1alpha1.OutboundRule) {
+				controlPlaneConfig, _ := json.Marshal(&apiv1alpha1.ControlPlaneConfig{
+					TypeMeta: metav1.TypeMeta{
+						APIVersion: apiv1alpha1.SchemeGroupVersion.String(),
+						Kind:       "ControlPlaneConfig",
+					},
+					LoadBalancer: &apiv1alpha1.LoadBalancerConfig{
+						OutboundRule: outboundRule,
+					},
+				})
+				cluster.Shoot.Spec.Provider.ControlPlaneConfig = &gardencorev1beta1.ProviderConfig{
+					RawExtension: runtime.RawExtension{Raw: controlPlaneConfig},
+				}
+			}
+
+			It("should correctly compute terraform chart values with an outbound rule", func() {
+				setControlPlaneConfig(&apiv1alpha1.OutboundRule{
+					AllocatedOutboundPorts: &allocatedOutboundPorts,
+					IdleTimeoutInMinutes:   &idleTimeoutInMinutes,
+				})
+				expectedValues["outboundRule"] = map[string]interface{}{
+					"allocatedOutboundPorts": allocatedOutboundPorts,
+					"idleTimeoutInMinutes":   idleTimeoutInMinutes,
+				}
+
+				values, err := ComputeTerraformerChartValues(infra, clientAuth, config, cluster)
+				Expect(err).To(Not(HaveOccurred()))
+				Expect(values).To(BeEquivalentTo(expectedValues))
+			})
+
+			It("should default the outbound rule settings", func() {
+				setControlPlaneConfig(&apiv1alpha1.OutboundRule{})
+				expectedValues["outboundRule"] = map[string]interface{}{
+					"allocatedOutboundPorts": int32(0),
+					"idleTimeoutInMinutes":   int32(4),
+				}
+
+				values, err := ComputeTerraformerChartValues(infra, clientAuth, config, cluster)
+				Expect(err).To(Not(HaveOccurred()))
+				Expect(values).To(BeEquivalentTo(expectedValues))
+			})
+		})
 	})
 
 	Describe("#StatusFromTerraformState", func() {
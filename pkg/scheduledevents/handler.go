@@ -0,0 +1,291 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduledevents
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// AnnotationScheduledEvents is the annotation of a node which has been cordoned by the handler. Its value are the
+	// ids of the scheduled events for which the node has been cordoned.
+	AnnotationScheduledEvents = "azure.provider.extensions.gardener.cloud/scheduled-events"
+
+	// DefaultDrainTimeout is the default maximum duration of the drain of a node.
+	DefaultDrainTimeout = 5 * time.Minute
+	// DefaultPollInterval is the default interval in which the scheduled events are polled. It must be considerably
+	// shorter than the notice of 30 seconds for the eviction of spot virtual machines.
+	DefaultPollInterval = 5 * time.Second
+
+	// drainInterval is the interval in which the pods of a node are evicted until the node has been drained.
+	drainInterval = 5 * time.Second
+
+	reasonScheduledEvent             = "ScheduledEvent"
+	reasonDrained                    = "Drained"
+	reasonDrainFailed                = "DrainFailed"
+	reasonScheduledEventAcknowledged = "ScheduledEventAcknowledged"
+	reasonUncordoned                 = "Uncordoned"
+)
+
+// DefaultEventTypes are the types of the scheduled events before which a node is drained by default. A freeze is not
+// included, because it pauses the virtual machine only for a few seconds.
+var DefaultEventTypes = []EventType{EventTypeReboot, EventTypeRedeploy, EventTypePreempt, EventTypeTerminate}
+
+// Options contains the settings of the Handler.
+type Options struct {
+	// NodeName is the name of the node on which the handler runs. It is the name of the virtual machine, too.
+	NodeName string
+	// EventTypes are the types of the scheduled events before which the node is drained.
+	EventTypes []EventType
+	// DrainTimeout is the maximum duration of the drain of the node.
+	DrainTimeout time.Duration
+	// PollInterval is the interval in which the scheduled events are polled.
+	PollInterval time.Duration
+}
+
+// Handler cordons and drains a node before the Azure scheduled events of its virtual machine and acknowledges the
+// events afterwards, so that the pods are shut down gracefully before a maintenance or an eviction.
+type Handler struct {
+	client   client.Client
+	pods     corev1client.PodsGetter
+	metadata MetadataClient
+	recorder record.EventRecorder
+	logger   logr.Logger
+
+	nodeName      string
+	eventTypes    sets.String
+	drainTimeout  time.Duration
+	pollInterval  time.Duration
+	drainInterval time.Duration
+
+	acknowledged sets.String
+}
+
+// NewHandler creates a new Handler. The pods are evicted with the given pods getter, because the eviction is a
+// subresource of the pods which cannot be created with the controller-runtime client.
+func NewHandler(c client.Client, pods corev1client.PodsGetter, metadata MetadataClient, recorder record.EventRecorder, logger logr.Logger, opts Options) *Handler {
+	eventTypes := sets.NewString()
+	for _, eventType := range opts.EventTypes {
+		eventTypes.Insert(string(eventType))
+	}
+
+	return &Handler{
+		client:        c,
+		pods:          pods,
+		metadata:      metadata,
+		recorder:      recorder,
+		logger:        logger.WithValues("node", opts.NodeName),
+		nodeName:      opts.NodeName,
+		eventTypes:    eventTypes,
+		drainTimeout:  opts.DrainTimeout,
+		pollInterval:  opts.PollInterval,
+		drainInterval: drainInterval,
+		acknowledged:  sets.NewString(),
+	}
+}
+
+// Start polls and handles the scheduled events until the given context is cancelled.
+func (h *Handler) Start(ctx context.Context) {
+	h.logger.Info("Watching scheduled events", "eventTypes", h.eventTypes.List())
+	wait.Until(func() {
+		if err := h.Handle(ctx); err != nil {
+			h.logger.Error(err, "Could not handle scheduled events")
+		}
+	}, h.pollInterval, ctx.Done())
+}
+
+// Handle handles the current scheduled events of the virtual machine of the node. If there are new events, the node
+// is cordoned and drained and the events are acknowledged afterwards. If all events are over, the node is uncordoned
+// again if it has been cordoned by the handler.
+func (h *Handler) Handle(ctx context.Context) error {
+	document, err := h.metadata.GetEvents(ctx)
+	if err != nil {
+		return fmt.Errorf("could not get scheduled events: %v", err)
+	}
+
+	node := &corev1.Node{}
+	if err := h.client.Get(ctx, client.ObjectKey{Name: h.nodeName}, node); err != nil {
+		return fmt.Errorf("could not get node %s: %v", h.nodeName, err)
+	}
+
+	var (
+		affected bool
+		events   []Event
+	)
+	for _, event := range document.Events {
+		if !h.eventTypes.Has(string(event.EventType)) || !sets.NewString(event.Resources...).Has(h.nodeName) {
+			continue
+		}
+		affected = true
+		if event.EventStatus == EventStatusScheduled && !h.acknowledged.Has(event.EventID) {
+			events = append(events, event)
+		}
+	}
+
+	if !affected {
+		return h.uncordon(ctx, node)
+	}
+	if len(events) == 0 {
+		return nil
+	}
+
+	var (
+		ids      []string
+		deadline = time.Now().Add(h.drainTimeout)
+	)
+	for _, event := range events {
+		h.logger.Info("Virtual machine is affected by scheduled event", "eventID", event.EventID, "eventType", event.EventType, "notBefore", event.NotBefore)
+		h.recorder.Eventf(node, corev1.EventTypeWarning, reasonScheduledEvent, "Virtual machine is affected by scheduled event %s of type %s not before %s: %s", event.EventID, event.EventType, event.NotBefore, event.Description)
+
+		// The event starts at the latest at its NotBefore time, hence there is no point in draining the node any longer.
+		if notBefore, err := http.ParseTime(event.NotBefore); err == nil && notBefore.Before(deadline) {
+			deadline = notBefore
+		}
+		ids = append(ids, event.EventID)
+	}
+
+	if err := h.cordon(ctx, node, ids); err != nil {
+		return fmt.Errorf("could not cordon node %s: %v", h.nodeName, err)
+	}
+
+	if err := h.drain(ctx, deadline); err != nil {
+		h.logger.Error(err, "Could not drain node")
+		h.recorder.Eventf(node, corev1.EventTypeWarning, reasonDrainFailed, "Node could not be drained before the scheduled events: %v", err)
+	} else {
+		h.logger.Info("Node has been drained")
+		h.recorder.Event(node, corev1.EventTypeNormal, reasonDrained, "Node has been drained before the scheduled events")
+	}
+
+	if err := h.metadata.Acknowledge(ctx, ids...); err != nil {
+		return fmt.Errorf("could not acknowledge scheduled events %s: %v", strings.Join(ids, ", "), err)
+	}
+	h.acknowledged.Insert(ids...)
+
+	h.logger.Info("Scheduled events have been acknowledged", "eventIDs", ids)
+	h.recorder.Eventf(node, corev1.EventTypeNormal, reasonScheduledEventAcknowledged, "Scheduled events %s have been acknowledged", strings.Join(ids, ", "))
+	return nil
+}
+
+// cordon marks the node as unschedulable. The node is annotated with the ids of the events, so that it can be
+// uncordoned again after the events. A node which has already been cordoned before is left as it is.
+func (h *Handler) cordon(ctx context.Context, node *corev1.Node, ids []string) error {
+	if node.Spec.Unschedulable {
+		return nil
+	}
+
+	patch := client.MergeFrom(node.DeepCopy())
+	node.Spec.Unschedulable = true
+	metav1.SetMetaDataAnnotation(&node.ObjectMeta, AnnotationScheduledEvents, strings.Join(ids, ","))
+	return h.client.Patch(ctx, node, patch)
+}
+
+// uncordon marks the node as schedulable again if it has been cordoned by the handler.
+func (h *Handler) uncordon(ctx context.Context, node *corev1.Node) error {
+	if _, ok := node.Annotations[AnnotationScheduledEvents]; !ok {
+		return nil
+	}
+
+	patch := client.MergeFrom(node.DeepCopy())
+	node.Spec.Unschedulable = false
+	delete(node.Annotations, AnnotationScheduledEvents)
+	if err := h.client.Patch(ctx, node, patch); err != nil {
+		return fmt.Errorf("could not uncordon node %s: %v", h.nodeName, err)
+	}
+
+	h.logger.Info("Node has been uncordoned after the scheduled events")
+	h.recorder.Event(node, corev1.EventTypeNormal, reasonUncordoned, "Node has been uncordoned after the scheduled events")
+	return nil
+}
+
+// drain evicts the pods of the node until all of them are gone or the given deadline has passed. Evictions which are
+// rejected because of a pod disruption budget are retried.
+func (h *Handler) drain(ctx context.Context, deadline time.Time) error {
+	ctx, cancel := context.WithDeadline(ctx, deadline)
+	defer cancel()
+
+	var remaining []string
+	if err := wait.PollImmediateUntil(h.drainInterval, func() (bool, error) {
+		pods, err := h.getPodsToEvict(ctx)
+		if err != nil {
+			return false, err
+		}
+
+		remaining = nil
+		for _, pod := range pods {
+			remaining = append(remaining, client.ObjectKey{Namespace: pod.Namespace, Name: pod.Name}.String())
+			if pod.DeletionTimestamp != nil {
+				continue
+			}
+
+			eviction := &policyv1beta1.Eviction{ObjectMeta: metav1.ObjectMeta{Namespace: pod.Namespace, Name: pod.Name}}
+			if err := h.pods.Pods(pod.Namespace).Evict(eviction); err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				if apierrors.IsTooManyRequests(err) {
+					h.logger.Info("Eviction of pod has been rejected, retrying", "pod", pod.Name, "namespace", pod.Namespace, "reason", err.Error())
+					continue
+				}
+				return false, fmt.Errorf("could not evict pod %s/%s: %v", pod.Namespace, pod.Name, err)
+			}
+		}
+		return len(pods) == 0, nil
+	}, ctx.Done()); err != nil {
+		if err == wait.ErrWaitTimeout {
+			return fmt.Errorf("timed out waiting for the eviction of pods %s", strings.Join(remaining, ", "))
+		}
+		return err
+	}
+	return nil
+}
+
+// getPodsToEvict returns the pods of the node which must be evicted. Mirror pods, pods of daemon sets and pods which
+// have already terminated are not evicted.
+func (h *Handler) getPodsToEvict(ctx context.Context) ([]corev1.Pod, error) {
+	podList := &corev1.PodList{}
+	if err := h.client.List(ctx, podList, client.MatchingFields{"spec.nodeName": h.nodeName}); err != nil {
+		return nil, fmt.Errorf("could not list pods of node %s: %v", h.nodeName, err)
+	}
+
+	var pods []corev1.Pod
+	for _, pod := range podList.Items {
+		if _, ok := pod.Annotations[corev1.MirrorPodAnnotationKey]; ok {
+			continue
+		}
+		if owner := metav1.GetControllerOf(&pod); owner != nil && owner.Kind == "DaemonSet" {
+			continue
+		}
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		pods = append(pods, pod)
+	}
+	return pods, nil
+}
@@ -0,0 +1,247 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduledevents
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	mockclient "github.com/gardener/gardener-extensions/pkg/mock/controller-runtime/client"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+type fakeMetadata struct {
+	document     *Document
+	acknowledged []string
+}
+
+func (m *fakeMetadata) GetEvents(context.Context) (*Document, error) {
+	return m.document, nil
+}
+
+func (m *fakeMetadata) Acknowledge(_ context.Context, eventIDs ...string) error {
+	m.acknowledged = append(m.acknowledged, eventIDs...)
+	return nil
+}
+
+type fakePods struct {
+	evicted []string
+	reject  bool
+}
+
+func (p *fakePods) Pods(namespace string) corev1client.PodInterface {
+	return &fakePodInterface{pods: p}
+}
+
+type fakePodInterface struct {
+	corev1client.PodInterface
+	pods *fakePods
+}
+
+func (i *fakePodInterface) Evict(eviction *policyv1beta1.Eviction) error {
+	if i.pods.reject {
+		return apierrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 10)
+	}
+	i.pods.evicted = append(i.pods.evicted, eviction.Namespace+"/"+eviction.Name)
+	return nil
+}
+
+var _ = Describe("Handler", func() {
+	var (
+		ctx      = context.TODO()
+		nodeName = "node-1"
+		nodeKey  = client.ObjectKey{Name: nodeName}
+
+		ctrl     *gomock.Controller
+		c        *mockclient.MockClient
+		metadata *fakeMetadata
+		pods     *fakePods
+		recorder *record.FakeRecorder
+		handler  *Handler
+
+		node *corev1.Node
+
+		appPod = corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "app"},
+		}
+		daemonSetPod = corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:       "kube-system",
+				Name:            "daemon",
+				OwnerReferences: []metav1.OwnerReference{{Kind: "DaemonSet", Name: "daemon", Controller: &[]bool{true}[0]}},
+			},
+		}
+		mirrorPod = corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "kube-system",
+				Name:        "mirror",
+				Annotations: map[string]string{corev1.MirrorPodAnnotationKey: "mirror"},
+			},
+		}
+		completedPod = corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "job"},
+			Status:     corev1.PodStatus{Phase: corev1.PodSucceeded},
+		}
+
+		newEvent = func(id string, eventType EventType, status EventStatus, resources ...string) Event {
+			return Event{
+				EventID:      id,
+				EventType:    eventType,
+				ResourceType: "VirtualMachine",
+				Resources:    resources,
+				EventStatus:  status,
+				NotBefore:    time.Now().Add(15 * time.Minute).UTC().Format(http.TimeFormat),
+			}
+		}
+
+		expectGetNode = func() {
+			c.EXPECT().Get(ctx, nodeKey, &corev1.Node{}).DoAndReturn(func(_ context.Context, _ client.ObjectKey, obj runtime.Object) error {
+				node.DeepCopyInto(obj.(*corev1.Node))
+				return nil
+			})
+		}
+		expectPatchNode = func(unschedulable bool, annotation string) {
+			c.EXPECT().Patch(ctx, gomock.AssignableToTypeOf(&corev1.Node{}), gomock.Any()).DoAndReturn(func(_ context.Context, obj runtime.Object, _ client.Patch, _ ...client.PatchOption) error {
+				patched := obj.(*corev1.Node)
+				Expect(patched.Spec.Unschedulable).To(Equal(unschedulable))
+				if len(annotation) > 0 {
+					Expect(patched.Annotations).To(HaveKeyWithValue(AnnotationScheduledEvents, annotation))
+				} else {
+					Expect(patched.Annotations).NotTo(HaveKey(AnnotationScheduledEvents))
+				}
+				return nil
+			})
+		}
+		listPods = func(items ...corev1.Pod) func(context.Context, runtime.Object, ...client.ListOption) error {
+			return func(_ context.Context, list runtime.Object, _ ...client.ListOption) error {
+				list.(*corev1.PodList).Items = items
+				return nil
+			}
+		}
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		c = mockclient.NewMockClient(ctrl)
+		metadata = &fakeMetadata{document: &Document{}}
+		pods = &fakePods{}
+		recorder = record.NewFakeRecorder(10)
+
+		node = &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: nodeName}}
+
+		handler = NewHandler(c, pods, metadata, recorder, log.Log.WithName("test"), Options{
+			NodeName:     nodeName,
+			EventTypes:   DefaultEventTypes,
+			DrainTimeout: time.Minute,
+			PollInterval: time.Second,
+		})
+		handler.drainInterval = 10 * time.Millisecond
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("should cordon and drain the node and acknowledge the scheduled event", func() {
+		metadata.document.Events = []Event{
+			newEvent("event-1", EventTypeReboot, EventStatusScheduled, nodeName),
+			newEvent("event-2", EventTypeReboot, EventStatusScheduled, "node-2"),
+			newEvent("event-3", EventTypeFreeze, EventStatusScheduled, nodeName),
+		}
+
+		expectGetNode()
+		expectPatchNode(true, "event-1")
+		gomock.InOrder(
+			c.EXPECT().List(gomock.Any(), &corev1.PodList{}, client.MatchingFields{"spec.nodeName": nodeName}).DoAndReturn(listPods(appPod, daemonSetPod, mirrorPod, completedPod)),
+			c.EXPECT().List(gomock.Any(), &corev1.PodList{}, client.MatchingFields{"spec.nodeName": nodeName}).DoAndReturn(listPods(daemonSetPod, mirrorPod)),
+		)
+
+		Expect(handler.Handle(ctx)).To(Succeed())
+		Expect(pods.evicted).To(Equal([]string{"default/app"}))
+		Expect(metadata.acknowledged).To(Equal([]string{"event-1"}))
+		Expect(recorder.Events).To(Receive(ContainSubstring(reasonScheduledEvent)))
+		Expect(recorder.Events).To(Receive(ContainSubstring(reasonDrained)))
+		Expect(recorder.Events).To(Receive(ContainSubstring(reasonScheduledEventAcknowledged)))
+
+		// The acknowledged event is not handled again.
+		expectGetNode()
+		Expect(handler.Handle(ctx)).To(Succeed())
+		Expect(metadata.acknowledged).To(Equal([]string{"event-1"}))
+	})
+
+	It("should acknowledge the scheduled event if the node cannot be drained in time", func() {
+		metadata.document.Events = []Event{
+			newEvent("event-1", EventTypePreempt, EventStatusScheduled, nodeName),
+		}
+		handler.drainTimeout = 50 * time.Millisecond
+		pods.reject = true
+
+		expectGetNode()
+		expectPatchNode(true, "event-1")
+		c.EXPECT().List(gomock.Any(), &corev1.PodList{}, client.MatchingFields{"spec.nodeName": nodeName}).DoAndReturn(listPods(appPod)).MinTimes(1)
+
+		Expect(handler.Handle(ctx)).To(Succeed())
+		Expect(pods.evicted).To(BeEmpty())
+		Expect(metadata.acknowledged).To(Equal([]string{"event-1"}))
+		Expect(recorder.Events).To(Receive(ContainSubstring(reasonScheduledEvent)))
+		Expect(recorder.Events).To(Receive(ContainSubstring(reasonDrainFailed)))
+	})
+
+	It("should not drain the node for a scheduled event which has already started", func() {
+		metadata.document.Events = []Event{
+			newEvent("event-1", EventTypeRedeploy, EventStatusStarted, nodeName),
+		}
+		node.Spec.Unschedulable = true
+		node.Annotations = map[string]string{AnnotationScheduledEvents: "event-1"}
+
+		expectGetNode()
+
+		Expect(handler.Handle(ctx)).To(Succeed())
+		Expect(metadata.acknowledged).To(BeEmpty())
+	})
+
+	It("should uncordon the node after the scheduled events", func() {
+		node.Spec.Unschedulable = true
+		node.Annotations = map[string]string{AnnotationScheduledEvents: "event-1"}
+
+		expectGetNode()
+		expectPatchNode(false, "")
+
+		Expect(handler.Handle(ctx)).To(Succeed())
+		Expect(recorder.Events).To(Receive(ContainSubstring(reasonUncordoned)))
+	})
+
+	It("should not uncordon a node which has been cordoned by someone else", func() {
+		node.Spec.Unschedulable = true
+
+		expectGetNode()
+
+		Expect(handler.Handle(ctx)).To(Succeed())
+		Expect(recorder.Events).NotTo(Receive())
+	})
+})
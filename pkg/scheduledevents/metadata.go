@@ -0,0 +1,176 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduledevents
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+const (
+	// DefaultMetadataEndpoint is the endpoint of the scheduled events in the Azure Instance Metadata Service.
+	DefaultMetadataEndpoint = "http://169.254.169.254/metadata/scheduledevents"
+	// metadataAPIVersion is the version of the Scheduled Events API.
+	metadataAPIVersion = "2019-08-01"
+	// metadataTimeout is the timeout of a request to the Azure Instance Metadata Service.
+	metadataTimeout = 10 * time.Second
+)
+
+// EventType is a type of an Azure scheduled event.
+type EventType string
+
+const (
+	// EventTypeFreeze means that the virtual machine is paused for a few seconds.
+	EventTypeFreeze EventType = "Freeze"
+	// EventTypeReboot means that the virtual machine is rebooted.
+	EventTypeReboot EventType = "Reboot"
+	// EventTypeRedeploy means that the virtual machine is moved to another host.
+	EventTypeRedeploy EventType = "Redeploy"
+	// EventTypePreempt means that the spot virtual machine is evicted.
+	EventTypePreempt EventType = "Preempt"
+	// EventTypeTerminate means that the virtual machine is deleted.
+	EventTypeTerminate EventType = "Terminate"
+)
+
+// EventStatus is the status of an Azure scheduled event.
+type EventStatus string
+
+const (
+	// EventStatusScheduled means that the event starts at the earliest at the time given by NotBefore.
+	EventStatusScheduled EventStatus = "Scheduled"
+	// EventStatusStarted means that the event has been acknowledged or its NotBefore time has passed.
+	EventStatusStarted EventStatus = "Started"
+)
+
+// Event is an Azure scheduled event.
+type Event struct {
+	// EventID is the globally unique identifier of the event.
+	EventID string `json:"EventId"`
+	// EventType is the impact of the event on the virtual machines.
+	EventType EventType `json:"EventType"`
+	// ResourceType is the type of the affected resources. It is always VirtualMachine.
+	ResourceType string `json:"ResourceType"`
+	// Resources are the names of the affected virtual machines.
+	Resources []string `json:"Resources"`
+	// EventStatus is the status of the event.
+	EventStatus EventStatus `json:"EventStatus"`
+	// NotBefore is the time after which the event may start, in the format of RFC 1123. It is empty once the event
+	// has started.
+	NotBefore string `json:"NotBefore"`
+	// Description is a description of the event.
+	Description string `json:"Description"`
+	// EventSource is the initiator of the event, i.e. Platform or User.
+	EventSource string `json:"EventSource"`
+}
+
+// Document is the response of the Azure Instance Metadata Service with the scheduled events of a virtual machine.
+type Document struct {
+	// DocumentIncarnation is incremented whenever the events change.
+	DocumentIncarnation int `json:"DocumentIncarnation"`
+	// Events are the pending and started events.
+	Events []Event `json:"Events"`
+}
+
+// startRequest is a request to start a scheduled event.
+type startRequest struct {
+	EventID string `json:"EventId"`
+}
+
+// acknowledgement is the request body to acknowledge scheduled events.
+type acknowledgement struct {
+	StartRequests []startRequest `json:"StartRequests"`
+}
+
+// MetadataClient is an interface which must be implemented by clients of the scheduled events in the Azure Instance
+// Metadata Service.
+type MetadataClient interface {
+	GetEvents(ctx context.Context) (*Document, error)
+	Acknowledge(ctx context.Context, eventIDs ...string) error
+}
+
+// metadataClient is a client of the scheduled events in the Azure Instance Metadata Service.
+type metadataClient struct {
+	endpoint   string
+	httpClient *http.Client
+}
+
+// NewMetadataClient creates a client of the scheduled events in the Azure Instance Metadata Service at the given endpoint.
+func NewMetadataClient(endpoint string) MetadataClient {
+	return &metadataClient{
+		endpoint:   endpoint,
+		httpClient: &http.Client{Timeout: metadataTimeout},
+	}
+}
+
+// GetEvents returns the scheduled events of the virtual machine.
+func (c *metadataClient) GetEvents(ctx context.Context) (*Document, error) {
+	data, err := c.do(ctx, http.MethodGet, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	document := &Document{}
+	if err := json.Unmarshal(data, document); err != nil {
+		return nil, fmt.Errorf("could not decode scheduled events: %v", err)
+	}
+	return document, nil
+}
+
+// Acknowledge acknowledges the scheduled events with the given ids, so that they start as soon as possible.
+func (c *metadataClient) Acknowledge(ctx context.Context, eventIDs ...string) error {
+	ack := &acknowledgement{}
+	for _, id := range eventIDs {
+		ack.StartRequests = append(ack.StartRequests, startRequest{EventID: id})
+	}
+
+	body, err := json.Marshal(ack)
+	if err != nil {
+		return err
+	}
+	_, err = c.do(ctx, http.MethodPost, body)
+	return err
+}
+
+func (c *metadataClient) do(ctx context.Context, method string, body []byte) ([]byte, error) {
+	req, err := http.NewRequest(method, fmt.Sprintf("%s?api-version=%s", c.endpoint, metadataAPIVersion), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Metadata", "true")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request to the instance metadata service failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return nil, fmt.Errorf("instance metadata service responded with status %d: %s", resp.StatusCode, string(data))
+	}
+	return data, nil
+}
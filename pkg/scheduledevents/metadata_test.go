@@ -0,0 +1,91 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scheduledevents
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MetadataClient", func() {
+	var (
+		ctx = context.TODO()
+
+		server       *httptest.Server
+		acknowledged []string
+	)
+
+	BeforeEach(func() {
+		acknowledged = nil
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Metadata") != "true" || r.URL.Query().Get("api-version") != metadataAPIVersion {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			if r.URL.Path == "/unavailable" {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+
+			switch r.Method {
+			case http.MethodGet:
+				_, _ = w.Write([]byte(`{"DocumentIncarnation":2,"Events":[{"EventId":"event-1","EventType":"Reboot","ResourceType":"VirtualMachine","Resources":["node-1"],"EventStatus":"Scheduled","NotBefore":"Mon, 19 Sep 2016 18:29:47 GMT","Description":"Host server is undergoing maintenance.","EventSource":"Platform"}]}`))
+			case http.MethodPost:
+				ack := &acknowledgement{}
+				Expect(json.NewDecoder(r.Body).Decode(ack)).To(Succeed())
+				for _, request := range ack.StartRequests {
+					acknowledged = append(acknowledged, request.EventID)
+				}
+			}
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should get the scheduled events", func() {
+		document, err := NewMetadataClient(server.URL).GetEvents(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(document).To(Equal(&Document{
+			DocumentIncarnation: 2,
+			Events: []Event{{
+				EventID:      "event-1",
+				EventType:    EventTypeReboot,
+				ResourceType: "VirtualMachine",
+				Resources:    []string{"node-1"},
+				EventStatus:  EventStatusScheduled,
+				NotBefore:    "Mon, 19 Sep 2016 18:29:47 GMT",
+				Description:  "Host server is undergoing maintenance.",
+				EventSource:  "Platform",
+			}},
+		}))
+	})
+
+	It("should acknowledge the scheduled events", func() {
+		Expect(NewMetadataClient(server.URL).Acknowledge(ctx, "event-1", "event-2")).To(Succeed())
+		Expect(acknowledged).To(Equal([]string{"event-1", "event-2"}))
+	})
+
+	It("should return an error if the instance metadata service is unavailable", func() {
+		_, err := NewMetadataClient(server.URL + "/unavailable").GetEvents(ctx)
+		Expect(err).To(MatchError(ContainSubstring("responded with status 503")))
+	})
+})
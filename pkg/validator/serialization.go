@@ -20,6 +20,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"github.com/gardener/gardener/pkg/apis/core"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -41,6 +42,15 @@ func decodeInfrastructureConfig(decoder runtime.Decoder, infra *core.ProviderCon
 	return infraConfig, nil
 }
 
+func decodeCloudProfileConfig(decoder runtime.Decoder, config *gardencorev1beta1.ProviderConfig) (*azure.CloudProfileConfig, error) {
+	cloudProfileConfig := &azure.CloudProfileConfig{}
+	if err := util.Decode(decoder, config.Raw, cloudProfileConfig); err != nil {
+		return nil, err
+	}
+
+	return cloudProfileConfig, nil
+}
+
 func checkAndDecodeInfrastructureConfig(decoder runtime.Decoder, config *core.ProviderConfig, fldPath *field.Path) (*azure.InfrastructureConfig, error) {
 	if config == nil {
 		return nil, field.Required(fldPath, "InfrastructureConfig must be set for Azure shoots")
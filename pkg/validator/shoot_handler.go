@@ -26,13 +26,15 @@ import (
 	admissionv1beta1 "k8s.io/api/admission/v1beta1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
 // Shoot validates shoots
 type Shoot struct {
-	decoder runtime.Decoder
-	Logger  logr.Logger
+	apiReader client.Reader
+	decoder   runtime.Decoder
+	Logger    logr.Logger
 }
 
 // Handle implements Handler.Handle
@@ -49,7 +51,7 @@ func (v *Shoot) Handle(ctx context.Context, req admission.Request) admission.Res
 
 	switch req.Operation {
 	case admissionv1beta1.Create:
-		if err := v.validateShootCreation(shoot); err != nil {
+		if err := v.validateShootCreation(ctx, shoot); err != nil {
 			v.Logger.Error(err, "denied request")
 			return admission.Errored(http.StatusBadRequest, err)
 		}
@@ -60,7 +62,7 @@ func (v *Shoot) Handle(ctx context.Context, req admission.Request) admission.Res
 			return admission.Errored(http.StatusBadRequest, err)
 		}
 
-		if err := v.validateShootUpdate(oldShoot, shoot); err != nil {
+		if err := v.validateShootUpdate(ctx, oldShoot, shoot); err != nil {
 			v.Logger.Error(err, "denied request")
 			return admission.Errored(http.StatusBadRequest, err)
 		}
@@ -71,6 +73,12 @@ func (v *Shoot) Handle(ctx context.Context, req admission.Request) admission.Res
 	return admission.Allowed("validations succeeded")
 }
 
+// InjectAPIReader injects the given reader into the validator.
+func (v *Shoot) InjectAPIReader(reader client.Reader) error {
+	v.apiReader = reader
+	return nil
+}
+
 // InjectScheme injects the scheme.
 func (v *Shoot) InjectScheme(s *runtime.Scheme) error {
 	v.decoder = serializer.NewCodecFactory(s).UniversalDecoder()
@@ -0,0 +1,182 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validator_test
+
+import (
+	"context"
+	"encoding/json"
+
+	azureinstall "github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure/install"
+	azurev1alpha1 "github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure/v1alpha1"
+	"github.com/gardener/gardener-extension-provider-azure/pkg/azure"
+	. "github.com/gardener/gardener-extension-provider-azure/pkg/validator"
+
+	mockclient "github.com/gardener/gardener-extensions/pkg/mock/controller-runtime/client"
+	"github.com/gardener/gardener/pkg/apis/core/install"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var _ = Describe("Shoot", func() {
+	var (
+		ctx = context.TODO()
+
+		ctrl *gomock.Controller
+		c    *mockclient.MockClient
+
+		validator *Shoot
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		c = mockclient.NewMockClient(ctrl)
+
+		scheme := runtime.NewScheme()
+		install.Install(scheme)
+		Expect(azureinstall.AddToScheme(scheme)).To(Succeed())
+
+		validator = &Shoot{Logger: log.Log.WithName("test")}
+		Expect(validator.InjectScheme(scheme)).To(Succeed())
+		Expect(validator.InjectAPIReader(c)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	Describe("#Handle", func() {
+		var (
+			diskEncryptionSetID = "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Compute/diskEncryptionSets/des"
+
+			newInfrastructureConfig = func() *azurev1alpha1.InfrastructureConfig {
+				return &azurev1alpha1.InfrastructureConfig{
+					TypeMeta: metav1.TypeMeta{
+						APIVersion: azurev1alpha1.SchemeGroupVersion.String(),
+						Kind:       "InfrastructureConfig",
+					},
+					Networks: azurev1alpha1.NetworkConfig{
+						Workers: "10.250.0.0/16",
+					},
+					Zoned: true,
+				}
+			}
+
+			newRequest = func(oldInfraConfig, infraConfig *azurev1alpha1.InfrastructureConfig) admission.Request {
+				return admission.Request{
+					AdmissionRequest: admissionv1beta1.AdmissionRequest{
+						Operation: admissionv1beta1.Update,
+						OldObject: runtime.RawExtension{Raw: encodeShoot(oldInfraConfig)},
+						Object:    runtime.RawExtension{Raw: encodeShoot(infraConfig)},
+					},
+				}
+			}
+		)
+
+		BeforeEach(func() {
+			c.EXPECT().Get(ctx, client.ObjectKey{Name: "azure"}, gomock.AssignableToTypeOf(&gardencorev1beta1.CloudProfile{})).Return(nil).AnyTimes()
+		})
+
+		It("should allow updates which do not change the infrastructure config", func() {
+			resp := validator.Handle(ctx, newRequest(newInfrastructureConfig(), newInfrastructureConfig()))
+
+			Expect(resp.Allowed).To(BeTrue())
+		})
+
+		It("should forbid changing the pod network mode", func() {
+			infraConfig := newInfrastructureConfig()
+			overlay := azurev1alpha1.PodNetworkModeOverlay
+			infraConfig.Networks.PodNetworkMode = &overlay
+
+			resp := validator.Handle(ctx, newRequest(newInfrastructureConfig(), infraConfig))
+
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Message).To(ContainSubstring("spec.provider.infrastructureConfig.networks.podNetworkMode"))
+		})
+
+		It("should forbid changing the disk encryption set", func() {
+			oldInfraConfig := newInfrastructureConfig()
+			oldInfraConfig.DiskEncryption = &azurev1alpha1.DiskEncryption{DiskEncryptionSetID: &diskEncryptionSetID}
+
+			resp := validator.Handle(ctx, newRequest(oldInfraConfig, newInfrastructureConfig()))
+
+			Expect(resp.Allowed).To(BeFalse())
+			Expect(resp.Result.Message).To(ContainSubstring("spec.provider.infrastructureConfig.diskEncryption.diskEncryptionSetID"))
+		})
+	})
+})
+
+func encodeShoot(infraConfig *azurev1alpha1.InfrastructureConfig) []byte {
+	volumeType := "standard"
+
+	shoot := &gardencorev1beta1.Shoot{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: gardencorev1beta1.SchemeGroupVersion.String(),
+			Kind:       "Shoot",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "shoot",
+			Namespace: "garden-dev",
+		},
+		Spec: gardencorev1beta1.ShootSpec{
+			CloudProfileName: "azure",
+			Region:           "westeurope",
+			Kubernetes: gardencorev1beta1.Kubernetes{
+				Version: "1.18.2",
+			},
+			Networking: gardencorev1beta1.Networking{
+				Nodes: stringPtr("10.250.0.0/16"),
+			},
+			Provider: gardencorev1beta1.Provider{
+				Type:                 azure.Type,
+				InfrastructureConfig: &gardencorev1beta1.ProviderConfig{RawExtension: runtime.RawExtension{Raw: encode(infraConfig)}},
+				Workers: []gardencorev1beta1.Worker{
+					{
+						Name: "worker",
+						Machine: gardencorev1beta1.Machine{
+							Type: "Standard_D2_v3",
+						},
+						Maximum: 1,
+						Minimum: 1,
+						Volume: &gardencorev1beta1.Volume{
+							Type: &volumeType,
+							Size: "50Gi",
+						},
+						Zones: []string{"1"},
+					},
+				},
+			},
+		},
+	}
+
+	return encode(shoot)
+}
+
+func encode(obj interface{}) []byte {
+	data, err := json.Marshal(obj)
+	Expect(err).NotTo(HaveOccurred())
+	return data
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
@@ -15,12 +15,16 @@
 package validator
 
 import (
+	"context"
 	"reflect"
 
 	"github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure"
 	azurevalidation "github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure/validation"
 
 	"github.com/gardener/gardener/pkg/apis/core"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	kutil "github.com/gardener/gardener/pkg/utils/kubernetes"
+	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
@@ -33,12 +37,15 @@ var (
 	workersPath     = providerPath.Child("workers")
 )
 
-func (v *Shoot) validateShoot(shoot *core.Shoot, infraConfig *azure.InfrastructureConfig) field.ErrorList {
+func (v *Shoot) validateShoot(oldShoot, shoot *core.Shoot, oldInfraConfig, infraConfig *azure.InfrastructureConfig, cloudProfileConfig *azure.CloudProfileConfig) field.ErrorList {
 	allErrs := field.ErrorList{}
 	// ControlPlaneConfig
 	if shoot.Spec.Provider.ControlPlaneConfig != nil {
-		if _, err := decodeControlPlaneConfig(v.decoder, shoot.Spec.Provider.ControlPlaneConfig); err != nil {
+		cpConfig, err := decodeControlPlaneConfig(v.decoder, shoot.Spec.Provider.ControlPlaneConfig)
+		if err != nil {
 			allErrs = append(allErrs, field.Forbidden(cpConfigPath, "not allowed to configure an unsupported controlPlaneConfig"))
+		} else {
+			allErrs = append(allErrs, azurevalidation.ValidateControlPlaneConfig(cpConfig, infraConfig, shoot.Spec.Kubernetes.Version, shoot.Spec.Provider.Workers, cpConfigPath)...)
 		}
 	}
 
@@ -47,21 +54,27 @@ func (v *Shoot) validateShoot(shoot *core.Shoot, infraConfig *azure.Infrastructu
 
 	// Provider validation
 	allErrs = append(allErrs, azurevalidation.ValidateInfrastructureConfig(infraConfig, shoot.Spec.Networking.Nodes, shoot.Spec.Networking.Pods, shoot.Spec.Networking.Services, infraConfigPath)...)
+	allErrs = append(allErrs, azurevalidation.ValidateInfrastructureConfigAgainstCloudProfile(oldInfraConfig, infraConfig, shoot.Spec.Region, cloudProfileConfig, infraConfigPath)...)
 
 	// Shoot workers
 	allErrs = append(allErrs, azurevalidation.ValidateWorkers(shoot.Spec.Provider.Workers, infraConfig.Zoned, workersPath)...)
+	var oldWorkers []core.Worker
+	if oldShoot != nil {
+		oldWorkers = oldShoot.Spec.Provider.Workers
+	}
+	allErrs = append(allErrs, azurevalidation.ValidateWorkersAgainstCloudProfile(oldWorkers, shoot.Spec.Provider.Workers, shoot.Spec.Region, cloudProfileConfig, workersPath)...)
 
 	return allErrs
 }
 
-func (v *Shoot) validateShootUpdate(oldShoot, shoot *core.Shoot) error {
+func (v *Shoot) validateShootUpdate(ctx context.Context, oldShoot, shoot *core.Shoot) error {
 	// InfrastructureConfig update
 	infraConfig, err := checkAndDecodeInfrastructureConfig(v.decoder, shoot.Spec.Provider.InfrastructureConfig, infraConfigPath)
 	if err != nil {
 		return err
 	}
 
-	oldInfraConfig, err := checkAndDecodeInfrastructureConfig(v.decoder, shoot.Spec.Provider.InfrastructureConfig, infraConfigPath)
+	oldInfraConfig, err := checkAndDecodeInfrastructureConfig(v.decoder, oldShoot.Spec.Provider.InfrastructureConfig, infraConfigPath)
 	if err != nil {
 		return err
 	}
@@ -72,18 +85,60 @@ func (v *Shoot) validateShootUpdate(oldShoot, shoot *core.Shoot) error {
 		allErrs = append(allErrs, azurevalidation.ValidateInfrastructureConfigUpdate(oldInfraConfig, infraConfig, infraConfigPath)...)
 	}
 
+	// ControlPlaneConfig update
+	oldCpConfig := &azure.ControlPlaneConfig{}
+	if oldShoot.Spec.Provider.ControlPlaneConfig != nil {
+		if oldCpConfig, err = decodeControlPlaneConfig(v.decoder, oldShoot.Spec.Provider.ControlPlaneConfig); err != nil {
+			return err
+		}
+	}
+	cpConfig := &azure.ControlPlaneConfig{}
+	if shoot.Spec.Provider.ControlPlaneConfig != nil {
+		if cpConfig, err = decodeControlPlaneConfig(v.decoder, shoot.Spec.Provider.ControlPlaneConfig); err != nil {
+			return err
+		}
+	}
+	allErrs = append(allErrs, azurevalidation.ValidateControlPlaneConfigUpdate(oldCpConfig, cpConfig, cpConfigPath)...)
+
 	allErrs = append(allErrs, azurevalidation.ValidateWorkersUpdate(oldShoot.Spec.Provider.Workers, shoot.Spec.Provider.Workers, workersPath)...)
 
-	allErrs = append(allErrs, v.validateShoot(shoot, infraConfig)...)
+	cloudProfileConfig, err := v.getCloudProfileConfig(ctx, shoot)
+	if err != nil {
+		return err
+	}
+
+	allErrs = append(allErrs, v.validateShoot(oldShoot, shoot, oldInfraConfig, infraConfig, cloudProfileConfig)...)
 
 	return allErrs.ToAggregate()
 }
 
-func (v *Shoot) validateShootCreation(shoot *core.Shoot) error {
+func (v *Shoot) validateShootCreation(ctx context.Context, shoot *core.Shoot) error {
 	infraConfig, err := checkAndDecodeInfrastructureConfig(v.decoder, shoot.Spec.Provider.InfrastructureConfig, infraConfigPath)
 	if err != nil {
 		return err
 	}
 
-	return v.validateShoot(shoot, infraConfig).ToAggregate()
+	cloudProfileConfig, err := v.getCloudProfileConfig(ctx, shoot)
+	if err != nil {
+		return err
+	}
+
+	return v.validateShoot(nil, shoot, nil, infraConfig, cloudProfileConfig).ToAggregate()
+}
+
+func (v *Shoot) getCloudProfileConfig(ctx context.Context, shoot *core.Shoot) (*azure.CloudProfileConfig, error) {
+	cloudProfile := &gardencorev1beta1.CloudProfile{}
+	if err := v.apiReader.Get(ctx, kutil.Key(shoot.Spec.CloudProfileName), cloudProfile); err != nil {
+		return nil, errors.Wrapf(err, "could not get cloud profile %q", shoot.Spec.CloudProfileName)
+	}
+
+	if cloudProfile.Spec.ProviderConfig == nil {
+		return nil, nil
+	}
+
+	cloudProfileConfig, err := decodeCloudProfileConfig(v.decoder, cloudProfile.Spec.ProviderConfig)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not decode providerConfig of cloud profile %q", shoot.Spec.CloudProfileName)
+	}
+	return cloudProfileConfig, nil
 }
@@ -16,8 +16,13 @@ package controlplane
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
+	azureapihelper "github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure/helper"
+	"github.com/gardener/gardener-extension-provider-azure/pkg/apis/config"
 	"github.com/gardener/gardener-extension-provider-azure/pkg/azure"
+	"github.com/gardener/gardener-extension-provider-azure/pkg/internal/imagevector"
 	"github.com/gardener/gardener-extensions/pkg/controller/operatingsystemconfig/oscommon/cloudinit"
 	"github.com/gardener/gardener-extensions/pkg/util"
 	extensionswebhook "github.com/gardener/gardener-extensions/pkg/webhook"
@@ -38,19 +43,42 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-const acrConfigPath = "/var/lib/kubelet/acr.conf"
+const (
+	acrConfigPath = "/var/lib/kubelet/acr.conf"
+
+	acrCredentialProviderConfigPath = "/var/lib/kubelet/acr-credential-provider-config.yaml"
+	acrCredentialProviderBinDir     = "/opt/bin/kubelet-credential-providers"
+	// acrCredentialProviderName is the name of the binary of the acr credential provider. It must match the name of the
+	// provider in the credential provider configuration.
+	acrCredentialProviderName              = "acr-credential-provider"
+	acrCredentialProviderInstallUnit       = "install-acr-credential-provider.service"
+	acrCredentialProviderInstallScriptPath = "/opt/bin/install-acr-credential-provider.sh"
+)
+
+// csiMigrationFeatureGates are the feature gates which migrate the in-tree volume plugins to the Azure Disk CSI driver.
+var csiMigrationFeatureGates = []string{"CSIMigration", "CSIMigrationAzureDisk"}
+
+// csiMigrationAzureFileFeatureGate is the feature gate which migrates the in-tree volume plugin to the Azure File CSI
+// driver. It is only enabled as of azure.CSIMigrationAzureFileKubernetesVersion because it is alpha before.
+const csiMigrationAzureFileFeatureGate = "CSIMigrationAzureFile"
+
+// kubeletCredentialProvidersFeatureGate is the feature gate which enables the image credential providers of the kubelet.
+// It is only enabled before azure.KubeletCredentialProvidersBetaKubernetesVersion because it is beta as of this version.
+const kubeletCredentialProvidersFeatureGate = "KubeletCredentialProviders"
 
 // NewEnsurer creates a new controlplane ensurer.
-func NewEnsurer(logger logr.Logger) genericmutator.Ensurer {
+func NewEnsurer(acrCredentialProvider *config.AcrCredentialProviderConfig, logger logr.Logger) genericmutator.Ensurer {
 	return &ensurer{
-		logger: logger.WithName("azure-controlplane-ensurer"),
+		acrCredentialProvider: acrCredentialProvider,
+		logger:                logger.WithName("azure-controlplane-ensurer"),
 	}
 }
 
 type ensurer struct {
 	genericmutator.NoopEnsurer
-	client client.Client
-	logger logr.Logger
+	acrCredentialProvider *config.AcrCredentialProviderConfig
+	client                client.Client
+	logger                logr.Logger
 }
 
 // InjectClient injects the given client into the ensurer.
@@ -69,11 +97,30 @@ func (e *ensurer) EnsureKubeAPIServerDeployment(ctx context.Context, ectx generi
 		return err
 	}
 
+	featureGates, err := getCSIMigrationFeatureGates(cluster.Shoot.Spec.Kubernetes.Version)
+	if err != nil {
+		return err
+	}
+
+	cpConfig, err := azureapihelper.ControlPlaneConfigFromCluster(cluster)
+	if err != nil {
+		return err
+	}
+
 	if c := extensionswebhook.ContainerWithName(ps.Containers, "kube-apiserver"); c != nil {
-		ensureKubeAPIServerCommandLineArgs(c)
+		ensureKubeAPIServerCommandLineArgs(c, featureGates)
 		ensureVolumeMounts(c, cluster.Shoot.Spec.Kubernetes.Version)
+		if cpConfig != nil && cpConfig.KMS != nil {
+			ensureKMSPlugin(ps, c, cpConfig.KMS, imagevector.KMSPluginImage())
+		}
 	}
 	ensureVolumes(ps, cluster.Shoot.Spec.Kubernetes.Version)
+
+	if cpConfig != nil && cpConfig.KMS != nil {
+		if err := controlplane.EnsureSecretChecksumAnnotation(ctx, template, e.client, new.Namespace, azure.KMSPluginSecretName); err != nil {
+			return err
+		}
+	}
 	return e.ensureChecksumAnnotations(ctx, &new.Spec.Template, new.Namespace)
 }
 
@@ -87,8 +134,13 @@ func (e *ensurer) EnsureKubeControllerManagerDeployment(ctx context.Context, ect
 		return err
 	}
 
+	featureGates, err := getCSIMigrationFeatureGates(cluster.Shoot.Spec.Kubernetes.Version)
+	if err != nil {
+		return err
+	}
+
 	if c := extensionswebhook.ContainerWithName(ps.Containers, "kube-controller-manager"); c != nil {
-		ensureKubeControllerManagerCommandLineArgs(c)
+		ensureKubeControllerManagerCommandLineArgs(c, featureGates)
 		ensureVolumeMounts(c, cluster.Shoot.Spec.Kubernetes.Version)
 	}
 	ensureKubeControllerManagerAnnotations(template)
@@ -96,23 +148,56 @@ func (e *ensurer) EnsureKubeControllerManagerDeployment(ctx context.Context, ect
 	return e.ensureChecksumAnnotations(ctx, &new.Spec.Template, new.Namespace)
 }
 
-func ensureKubeAPIServerCommandLineArgs(c *corev1.Container) {
+// ensureKubeAPIServerCommandLineArgs ensures the command line arguments of the kube-apiserver. The given CSI migration
+// feature gates are empty if the in-tree volume plugins are not migrated to the CSI drivers.
+func ensureKubeAPIServerCommandLineArgs(c *corev1.Container, csiMigrationFeatureGates []string) {
 	c.Command = extensionswebhook.EnsureStringWithPrefix(c.Command, "--cloud-provider=", "azure")
 	c.Command = extensionswebhook.EnsureStringWithPrefix(c.Command, "--cloud-config=",
 		"/etc/kubernetes/cloudprovider/cloudprovider.conf")
+
+	// The volumes of the CSI drivers are labelled by the drivers themselves, hence the admission plugin which labels
+	// the volumes of the in-tree volume plugins is not needed anymore.
+	if len(csiMigrationFeatureGates) > 0 {
+		c.Command = extensionswebhook.EnsureNoStringWithPrefixContains(c.Command, "--enable-admission-plugins=",
+			"PersistentVolumeLabel", ",")
+		c.Command = extensionswebhook.EnsureStringWithPrefixContains(c.Command, "--disable-admission-plugins=",
+			"PersistentVolumeLabel", ",")
+		c.Command = ensureCSIMigrationFeatureGates(c.Command, csiMigrationFeatureGates)
+		return
+	}
+
 	c.Command = extensionswebhook.EnsureStringWithPrefixContains(c.Command, "--enable-admission-plugins=",
 		"PersistentVolumeLabel", ",")
 	c.Command = extensionswebhook.EnsureNoStringWithPrefixContains(c.Command, "--disable-admission-plugins=",
 		"PersistentVolumeLabel", ",")
 }
 
-func ensureKubeControllerManagerCommandLineArgs(c *corev1.Container) {
+// ensureKubeControllerManagerCommandLineArgs ensures the command line arguments of the kube-controller-manager. The
+// given CSI migration feature gates are empty if the in-tree volume plugins are not migrated to the CSI drivers.
+func ensureKubeControllerManagerCommandLineArgs(c *corev1.Container, csiMigrationFeatureGates []string) {
 	c.Command = extensionswebhook.EnsureStringWithPrefix(c.Command, "--cloud-provider=", "external")
 	c.Command = extensionswebhook.EnsureStringWithPrefix(c.Command, "--cloud-config=",
 		"/etc/kubernetes/cloudprovider/cloudprovider.conf")
+
+	if len(csiMigrationFeatureGates) > 0 {
+		c.Command = extensionswebhook.EnsureNoStringWithPrefix(c.Command, "--external-cloud-volume-plugin=")
+		c.Command = ensureCSIMigrationFeatureGates(c.Command, csiMigrationFeatureGates)
+		return
+	}
+
 	c.Command = extensionswebhook.EnsureStringWithPrefix(c.Command, "--external-cloud-volume-plugin=", "azure")
 }
 
+// ensureCSIMigrationFeatureGates ensures that the given feature gates for the CSI migration are enabled, even if they
+// have been disabled explicitly.
+func ensureCSIMigrationFeatureGates(command []string, featureGates []string) []string {
+	for _, featureGate := range featureGates {
+		command = extensionswebhook.EnsureNoStringWithPrefixContains(command, "--feature-gates=", featureGate+"=false", ",")
+		command = extensionswebhook.EnsureStringWithPrefixContains(command, "--feature-gates=", featureGate+"=true", ",")
+	}
+	return command
+}
+
 func ensureKubeControllerManagerAnnotations(t *corev1.PodTemplateSpec) {
 	t.Labels = extensionswebhook.EnsureAnnotationOrLabel(t.Labels, v1beta1constants.LabelNetworkPolicyToPublicNetworks, v1beta1constants.LabelNetworkPolicyAllowed)
 	t.Labels = extensionswebhook.EnsureAnnotationOrLabel(t.Labels, v1beta1constants.LabelNetworkPolicyToPrivateNetworks, v1beta1constants.LabelNetworkPolicyAllowed)
@@ -177,6 +262,58 @@ func mustMountEtcSSLFolder(version string) bool {
 	return k8sVersionAtLeast117
 }
 
+// isCSIEnabled checks whether the in-tree volume plugins are migrated to the CSI drivers for the given Kubernetes
+// version.
+func isCSIEnabled(version string) (bool, error) {
+	return versionutils.CompareVersions(version, ">=", azure.CSIMigrationKubernetesVersion)
+}
+
+// getCSIMigrationFeatureGates returns the feature gates which migrate the in-tree volume plugins to the CSI drivers
+// for the given Kubernetes version. It returns no feature gates if the in-tree volume plugins are not migrated.
+func getCSIMigrationFeatureGates(version string) ([]string, error) {
+	csiEnabled, err := isCSIEnabled(version)
+	if err != nil || !csiEnabled {
+		return nil, err
+	}
+
+	azureFileMigrationEnabled, err := versionutils.CompareVersions(version, ">=", azure.CSIMigrationAzureFileKubernetesVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	featureGates := append([]string{}, csiMigrationFeatureGates...)
+	if azureFileMigrationEnabled {
+		featureGates = append(featureGates, csiMigrationAzureFileFeatureGate)
+	}
+	return featureGates, nil
+}
+
+// getAcrCredentialProviderFeatureGates returns the feature gate which enables the image credential providers of the
+// kubelet if the acr credential provider is used for the given Kubernetes version and the feature is still alpha.
+func (e *ensurer) getAcrCredentialProviderFeatureGates(version string) ([]string, error) {
+	if e.acrCredentialProvider == nil || len(e.acrCredentialProvider.Binaries) == 0 {
+		return nil, nil
+	}
+
+	useAcrCredentialProvider, err := versionutils.CompareVersions(version, ">=", azure.AcrCredentialProviderKubernetesVersion)
+	if err != nil || !useAcrCredentialProvider {
+		return nil, err
+	}
+
+	credentialProvidersBeta, err := versionutils.CompareVersions(version, ">=", azure.KubeletCredentialProvidersBetaKubernetesVersion)
+	if err != nil || credentialProvidersBeta {
+		return nil, err
+	}
+	return []string{kubeletCredentialProvidersFeatureGate}, nil
+}
+
+// isExternalCloudProviderEnabled checks whether the kubelet uses the external cloud provider for the given Kubernetes
+// version. The in-tree volume plugins of the kubelet require the in-tree cloud provider, hence the external cloud
+// provider can only be used once they are migrated to the CSI drivers.
+func isExternalCloudProviderEnabled(version string) (bool, error) {
+	return isCSIEnabled(version)
+}
+
 func (e *ensurer) ensureChecksumAnnotations(ctx context.Context, template *corev1.PodTemplateSpec, namespace string) error {
 	return controlplane.EnsureConfigMapChecksumAnnotation(ctx, template, e.client, namespace, azure.CloudProviderConfigName)
 }
@@ -195,21 +332,73 @@ func (e *ensurer) EnsureKubeletServiceUnitOptions(ctx context.Context, ectx gene
 }
 
 func (e *ensurer) ensureKubeletCommandLineArgs(ctx context.Context, ectx genericmutator.EnsurerContext, command []string) ([]string, error) {
-	command = extensionswebhook.EnsureStringWithPrefix(command, "--cloud-provider=", "azure")
-	command = extensionswebhook.EnsureStringWithPrefix(command, "--cloud-config=", "/var/lib/kubelet/cloudprovider.conf")
+	cluster, err := ectx.GetCluster(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	externalCloudProvider, err := isExternalCloudProviderEnabled(cluster.Shoot.Spec.Kubernetes.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	if externalCloudProvider {
+		// The nodes are initialized by the cloud-node-manager, hence the kubelet does not need the cloud provider config.
+		command = extensionswebhook.EnsureStringWithPrefix(command, "--cloud-provider=", "external")
+		command = extensionswebhook.EnsureNoStringWithPrefix(command, "--cloud-config=")
+	} else {
+		command = extensionswebhook.EnsureStringWithPrefix(command, "--cloud-provider=", "azure")
+		command = extensionswebhook.EnsureStringWithPrefix(command, "--cloud-config=", "/var/lib/kubelet/cloudprovider.conf")
+	}
 
 	acrConfigMap, err := e.getAcrConfigMap(ctx, ectx)
 	if err != nil {
 		return nil, err
 	}
-	if acrConfigMap != nil {
-		command = extensionswebhook.EnsureStringWithPrefix(command, "--azure-container-registry-config=", acrConfigPath)
+	if acrConfigMap == nil {
+		return command, nil
+	}
+
+	credentialProviderConfigMap, err := e.getAcrCredentialProviderConfigMap(ctx, ectx)
+	if err != nil {
+		return nil, err
+	}
+	if credentialProviderConfigMap != nil {
+		command = extensionswebhook.EnsureNoStringWithPrefix(command, "--azure-container-registry-config=")
+		command = extensionswebhook.EnsureStringWithPrefix(command, "--image-credential-provider-config=", acrCredentialProviderConfigPath)
+		command = extensionswebhook.EnsureStringWithPrefix(command, "--image-credential-provider-bin-dir=", acrCredentialProviderBinDir)
+		return command, nil
 	}
+
+	command = extensionswebhook.EnsureStringWithPrefix(command, "--azure-container-registry-config=", acrConfigPath)
 	return command, nil
 }
 
 // EnsureKubeletConfiguration ensures that the kubelet configuration conforms to the provider requirements.
 func (e *ensurer) EnsureKubeletConfiguration(ctx context.Context, ectx genericmutator.EnsurerContext, new, old *kubeletconfigv1beta1.KubeletConfiguration) error {
+	cluster, err := ectx.GetCluster(ctx)
+	if err != nil {
+		return err
+	}
+
+	// The feature gate is enabled even if the shoot does not pull from an acr, the kubelet only uses the image
+	// credential providers if they are configured on its command line.
+	acrCredentialProviderFeatureGates, err := e.getAcrCredentialProviderFeatureGates(cluster.Shoot.Spec.Kubernetes.Version)
+	if err != nil {
+		return err
+	}
+	ensureFeatureGates(new, acrCredentialProviderFeatureGates)
+
+	featureGates, err := getCSIMigrationFeatureGates(cluster.Shoot.Spec.Kubernetes.Version)
+	if err != nil {
+		return err
+	}
+
+	if len(featureGates) > 0 {
+		ensureFeatureGates(new, featureGates)
+		return nil
+	}
+
 	// Make sure CSI-related feature gates are not enabled
 	// TODO Leaving these enabled shouldn't do any harm, perhaps remove this code when properly tested?
 	delete(new.FeatureGates, "VolumeSnapshotDataSource")
@@ -218,6 +407,19 @@ func (e *ensurer) EnsureKubeletConfiguration(ctx context.Context, ectx genericmu
 	return nil
 }
 
+// ensureFeatureGates ensures that the given feature gates are enabled in the kubelet configuration.
+func ensureFeatureGates(kubeletConfig *kubeletconfigv1beta1.KubeletConfiguration, featureGates []string) {
+	if len(featureGates) == 0 {
+		return
+	}
+	if kubeletConfig.FeatureGates == nil {
+		kubeletConfig.FeatureGates = make(map[string]bool)
+	}
+	for _, featureGate := range featureGates {
+		kubeletConfig.FeatureGates[featureGate] = true
+	}
+}
+
 // ShouldProvisionKubeletCloudProviderConfig returns true if the cloud provider config file should be added to the kubelet configuration.
 func (e *ensurer) ShouldProvisionKubeletCloudProviderConfig() bool {
 	return true
@@ -246,6 +448,11 @@ func (e *ensurer) EnsureKubeletCloudProviderConfig(ctx context.Context, ectx gen
 	return nil
 }
 
+// EnsureAdditionalUnits ensures additional systemd units
+func (e *ensurer) EnsureAdditionalUnits(ctx context.Context, ectx genericmutator.EnsurerContext, new, old *[]extensionsv1alpha1.Unit) error {
+	return e.ensureAcrCredentialProviderInstallUnit(ctx, ectx, new)
+}
+
 // EnsureAdditionalFile ensures additional systemd files
 func (e *ensurer) EnsureAdditionalFiles(ctx context.Context, ectx genericmutator.EnsurerContext, new, old *[]extensionsv1alpha1.File) error {
 	return e.ensureAcrConfigFile(ctx, ectx, new)
@@ -261,24 +468,48 @@ func (e *ensurer) ensureAcrConfigFile(ctx context.Context, ectx genericmutator.E
 		return nil
 	}
 
+	if err := ensureFile(files, acrConfigPath, cm.Data[azure.CloudProviderAcrConfigMapKey]); err != nil {
+		return errors.Wrap(err, "could not encode acr cloud provider config")
+	}
+
+	// Check if the ACR credential provider configmap exists, if not the kubelet uses the in-tree acr credentials.
+	credentialProviderConfigMap, err := e.getAcrCredentialProviderConfigMap(ctx, ectx)
+	if err != nil {
+		return err
+	}
+	if credentialProviderConfigMap == nil {
+		return nil
+	}
+
+	if err := ensureFile(files, acrCredentialProviderConfigPath, credentialProviderConfigMap.Data[azure.CloudProviderAcrCredentialProviderConfigMapKey]); err != nil {
+		return errors.Wrap(err, "could not encode acr credential provider config")
+	}
+	if err := ensureFile(files, acrCredentialProviderInstallScriptPath, e.acrCredentialProviderInstallScript()); err != nil {
+		return errors.Wrap(err, "could not encode acr credential provider install script")
+	}
+	return nil
+}
+
+// ensureFile replaces the file with the given path by a file with the given content.
+func ensureFile(files *[]extensionsv1alpha1.File, path, content string) error {
 	// Write the content of the file.
 	fciCodec := controlplane.NewFileContentInlineCodec()
-	fci, err := fciCodec.Encode([]byte(cm.Data[azure.CloudProviderAcrConfigMapKey]), string(cloudinit.B64FileCodecID))
+	fci, err := fciCodec.Encode([]byte(content), string(cloudinit.B64FileCodecID))
 	if err != nil {
-		return errors.Wrap(err, "could not encode acr cloud provider config")
+		return err
 	}
 
-	// Remove old ACR systemd file(s) before adding a new one.
+	// Remove old file(s) before adding a new one.
 	for i, f := range *files {
-		if f.Path == acrConfigPath {
+		if f.Path == path {
 			l := *files
 			*files = append(l[:i], l[i+1:]...)
 		}
 	}
 
-	// Add new ACR systemd file.
+	// Add new file.
 	*files = append(*files, extensionsv1alpha1.File{
-		Path:        acrConfigPath,
+		Path:        path,
 		Permissions: util.Int32Ptr(0644),
 		Content: extensionsv1alpha1.FileContent{
 			Inline: fci,
@@ -287,13 +518,89 @@ func (e *ensurer) ensureAcrConfigFile(ctx context.Context, ectx genericmutator.E
 	return nil
 }
 
+// ensureAcrCredentialProviderInstallUnit adds a unit which installs the binary of the acr credential provider before
+// the kubelet is started if the kubelet uses the acr credential provider.
+func (e *ensurer) ensureAcrCredentialProviderInstallUnit(ctx context.Context, ectx genericmutator.EnsurerContext, units *[]extensionsv1alpha1.Unit) error {
+	// The acr credential provider is only used if both ACR configmaps exist and its downloads are configured.
+	cm, err := e.getAcrConfigMap(ctx, ectx)
+	if err != nil || cm == nil {
+		return err
+	}
+	credentialProviderConfigMap, err := e.getAcrCredentialProviderConfigMap(ctx, ectx)
+	if err != nil || credentialProviderConfigMap == nil {
+		return err
+	}
+
+	// Remove old unit(s) before adding a new one.
+	for i, u := range *units {
+		if u.Name == acrCredentialProviderInstallUnit {
+			l := *units
+			*units = append(l[:i], l[i+1:]...)
+		}
+	}
+
+	*units = append(*units, extensionsv1alpha1.Unit{
+		Name:    acrCredentialProviderInstallUnit,
+		Command: util.StringPtr("start"),
+		Enable:  util.BoolPtr(true),
+		Content: util.StringPtr(acrCredentialProviderInstallUnitContent),
+	})
+	return nil
+}
+
+var acrCredentialProviderInstallUnitContent = fmt.Sprintf(`[Unit]
+Description=Installs the kubelet image credential provider for the Azure Container Registry
+Before=kubelet.service
+[Install]
+WantedBy=kubelet.service
+[Service]
+Type=oneshot
+RemainAfterExit=yes
+ExecStart=/bin/sh %s
+`, acrCredentialProviderInstallScriptPath)
+
+// acrCredentialProviderInstallScript returns a script which downloads the binary of the acr credential provider for the
+// architecture of the worker machine and installs it after its checksum has been verified.
+func (e *ensurer) acrCredentialProviderInstallScript() string {
+	var downloads strings.Builder
+	for _, binary := range e.acrCredentialProvider.Binaries {
+		fmt.Fprintf(&downloads, "%s)\n  url='%s'\n  sha256='%s'\n  ;;\n", binary.Architecture, binary.URL, binary.SHA256)
+	}
+
+	return fmt.Sprintf(`#!/bin/sh
+set -e
+
+case "$(uname -m)" in
+x86_64) arch=amd64 ;;
+aarch64) arch=arm64 ;;
+*) arch="$(uname -m)" ;;
+esac
+
+case "$arch" in
+%[3]s*)
+  echo "no acr credential provider configured for architecture $arch" >&2
+  exit 1
+  ;;
+esac
+
+mkdir -p %[1]s
+curl -sSfL --retry 5 -o %[1]s/%[2]s.tmp "$url"
+if ! echo "$sha256  %[1]s/%[2]s.tmp" | sha256sum -c -; then
+  rm -f %[1]s/%[2]s.tmp
+  exit 1
+fi
+chmod 0755 %[1]s/%[2]s.tmp
+mv %[1]s/%[2]s.tmp %[1]s/%[2]s
+`, acrCredentialProviderBinDir, acrCredentialProviderName, downloads.String())
+}
+
 func (e *ensurer) getAcrConfigMap(ctx context.Context, ectx genericmutator.EnsurerContext) (*corev1.ConfigMap, error) {
 	cluster, err := ectx.GetCluster(ctx)
 	if err != nil {
 		return nil, err
 	}
 	if cluster == nil || cluster.Shoot == nil {
-		return nil, errors.Wrap(err, "could not get cluster resource or cluster resource is invalid")
+		return nil, errors.New("could not get cluster resource or cluster resource is invalid")
 	}
 
 	var (
@@ -308,3 +615,31 @@ func (e *ensurer) getAcrConfigMap(ctx context.Context, ectx genericmutator.Ensur
 	}
 	return &cm, nil
 }
+
+// getAcrCredentialProviderConfigMap returns the acr credential provider configmap of the shoot. It returns nil if the
+// downloads of the acr credential provider are not configured so that the kubelet uses the in-tree acr credentials.
+func (e *ensurer) getAcrCredentialProviderConfigMap(ctx context.Context, ectx genericmutator.EnsurerContext) (*corev1.ConfigMap, error) {
+	if e.acrCredentialProvider == nil || len(e.acrCredentialProvider.Binaries) == 0 {
+		return nil, nil
+	}
+
+	cluster, err := ectx.GetCluster(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if cluster == nil || cluster.Shoot == nil {
+		return nil, errors.New("could not get cluster resource or cluster resource is invalid")
+	}
+
+	var (
+		cm        corev1.ConfigMap
+		namespace = cluster.Shoot.Status.TechnicalID
+	)
+	if err := e.client.Get(ctx, kutil.Key(namespace, azure.CloudProviderAcrCredentialProviderConfigName), &cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "could not get acr credential provider configmap '%s/%s'", namespace, azure.CloudProviderAcrCredentialProviderConfigName)
+	}
+	return &cm, nil
+}
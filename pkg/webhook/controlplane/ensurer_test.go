@@ -16,8 +16,10 @@ package controlplane
 
 import (
 	"context"
+	"encoding/base64"
 	"testing"
 
+	"github.com/gardener/gardener-extension-provider-azure/pkg/apis/config"
 	"github.com/gardener/gardener-extension-provider-azure/pkg/azure"
 	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
 	mockclient "github.com/gardener/gardener-extensions/pkg/mock/controller-runtime/client"
@@ -29,6 +31,7 @@ import (
 	"github.com/coreos/go-systemd/unit"
 	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -55,7 +58,14 @@ func TestController(t *testing.T) {
 
 var _ = Describe("Ensurer", func() {
 	var (
-		ctrl           *gomock.Controller
+		ctrl *gomock.Controller
+
+		acrCredentialProvider = &config.AcrCredentialProviderConfig{
+			Binaries: []config.AcrCredentialProviderBinary{
+				{Architecture: "amd64", URL: "https://example.com/acr-credential-provider-amd64", SHA256: "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"},
+				{Architecture: "arm64", URL: "https://example.com/acr-credential-provider-arm64", SHA256: "fedcba9876543210fedcba9876543210fedcba9876543210fedcba9876543210"},
+			},
+		}
 		dummyContext   = genericmutator.NewEnsurerContext(nil, nil)
 		eContextK8s116 = genericmutator.NewInternalEnsurerContext(
 			&extensionscontroller.Cluster{
@@ -79,6 +89,28 @@ var _ = Describe("Ensurer", func() {
 				},
 			},
 		)
+		eContextK8s119 = genericmutator.NewInternalEnsurerContext(
+			&extensionscontroller.Cluster{
+				Shoot: &gardencorev1beta1.Shoot{
+					Spec: gardencorev1beta1.ShootSpec{
+						Kubernetes: gardencorev1beta1.Kubernetes{
+							Version: "1.19.0",
+						},
+					},
+				},
+			},
+		)
+		eContextK8s121 = genericmutator.NewInternalEnsurerContext(
+			&extensionscontroller.Cluster{
+				Shoot: &gardencorev1beta1.Shoot{
+					Spec: gardencorev1beta1.ShootSpec{
+						Kubernetes: gardencorev1beta1.Kubernetes{
+							Version: "1.21.0",
+						},
+					},
+				},
+			},
+		)
 
 		cmKey = client.ObjectKey{Namespace: namespace, Name: azure.CloudProviderConfigName}
 		cm    = &corev1.ConfigMap{
@@ -129,7 +161,7 @@ var _ = Describe("Ensurer", func() {
 			client.EXPECT().Get(context.TODO(), cmKey, &corev1.ConfigMap{}).DoAndReturn(clientGet(cm))
 
 			// Create ensurer
-			ensurer := NewEnsurer(logger)
+			ensurer := NewEnsurer(acrCredentialProvider, logger)
 			err := ensurer.(inject.Client).InjectClient(client)
 			Expect(err).To(Not(HaveOccurred()))
 
@@ -162,7 +194,7 @@ var _ = Describe("Ensurer", func() {
 			client.EXPECT().Get(context.TODO(), cmKey, &corev1.ConfigMap{}).DoAndReturn(clientGet(cm))
 
 			// Create ensurer
-			ensurer := NewEnsurer(logger)
+			ensurer := NewEnsurer(acrCredentialProvider, logger)
 			err := ensurer.(inject.Client).InjectClient(client)
 			Expect(err).To(Not(HaveOccurred()))
 
@@ -207,7 +239,7 @@ var _ = Describe("Ensurer", func() {
 			client.EXPECT().Get(context.TODO(), cmKey, &corev1.ConfigMap{}).DoAndReturn(clientGet(cm))
 
 			// Create ensurer
-			ensurer := NewEnsurer(logger)
+			ensurer := NewEnsurer(acrCredentialProvider, logger)
 			err := ensurer.(inject.Client).InjectClient(client)
 			Expect(err).To(Not(HaveOccurred()))
 
@@ -216,6 +248,138 @@ var _ = Describe("Ensurer", func() {
 			Expect(err).To(Not(HaveOccurred()))
 			checkKubeAPIServerDeployment(dep, annotations, true)
 		})
+
+		It("should disable the PersistentVolumeLabel admission plugin and enable the CSI migration (k8s >= 1.19)", func() {
+			var (
+				dep = &appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: v1beta1constants.DeploymentNameKubeAPIServer},
+					Spec: appsv1.DeploymentSpec{
+						Template: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{
+										Name: "kube-apiserver",
+										Command: []string{
+											"--enable-admission-plugins=Priority,PersistentVolumeLabel",
+											"--feature-gates=Foo=true,CSIMigration=false",
+										},
+									},
+								},
+							},
+						},
+					},
+				}
+			)
+
+			// Create mock client
+			client := mockclient.NewMockClient(ctrl)
+			client.EXPECT().Get(context.TODO(), cmKey, &corev1.ConfigMap{}).DoAndReturn(clientGet(cm))
+
+			// Create ensurer
+			ensurer := NewEnsurer(acrCredentialProvider, logger)
+			err := ensurer.(inject.Client).InjectClient(client)
+			Expect(err).To(Not(HaveOccurred()))
+
+			// Call EnsureKubeAPIServerDeployment method and check the result
+			err = ensurer.EnsureKubeAPIServerDeployment(context.TODO(), eContextK8s119, dep, nil)
+			Expect(err).To(Not(HaveOccurred()))
+
+			c := extensionswebhook.ContainerWithName(dep.Spec.Template.Spec.Containers, "kube-apiserver")
+			Expect(c).To(Not(BeNil()))
+			Expect(c.Command).To(ContainElement("--cloud-provider=azure"))
+			Expect(c.Command).To(ContainElement("--enable-admission-plugins=Priority"))
+			Expect(c.Command).To(ContainElement("--disable-admission-plugins=PersistentVolumeLabel"))
+			Expect(c.Command).To(ContainElement("--feature-gates=Foo=true,CSIMigration=true,CSIMigrationAzureDisk=true"))
+		})
+
+		It("should add the kms plugins and the encryption configuration to the kube-apiserver deployment", func() {
+			var (
+				eContextKMS = genericmutator.NewInternalEnsurerContext(
+					&extensionscontroller.Cluster{
+						Shoot: &gardencorev1beta1.Shoot{
+							Spec: gardencorev1beta1.ShootSpec{
+								Kubernetes: gardencorev1beta1.Kubernetes{
+									Version: "1.17.0",
+								},
+								Provider: gardencorev1beta1.Provider{
+									ControlPlaneConfig: &gardencorev1beta1.ProviderConfig{
+										RawExtension: runtime.RawExtension{
+											Raw: []byte(`{"apiVersion":"azure.provider.extensions.gardener.cloud/v1alpha1","kind":"ControlPlaneConfig","kms":{"keyVaultName":"vault","keyName":"key","keyVersion":"00000000000000000000000000000002","previousKeyVersions":["00000000000000000000000000000001"]}}`),
+										},
+									},
+								},
+							},
+						},
+					},
+				)
+				dep = &appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: v1beta1constants.DeploymentNameKubeAPIServer},
+					Spec: appsv1.DeploymentSpec{
+						Template: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{
+										Name: "kube-apiserver",
+										Command: []string{
+											"--encryption-provider-config=/etc/kubernetes/etcd-encryption-secret/encryption-configuration.yaml",
+										},
+									},
+									{Name: "azure-kms-plugin-2"},
+								},
+							},
+						},
+					},
+				}
+				secretKey = client.ObjectKey{Namespace: namespace, Name: azure.KMSPluginSecretName}
+				secret    = &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: azure.KMSPluginSecretName},
+					Data:       map[string][]byte{azure.KMSEncryptionConfigurationKey: []byte("foo")},
+				}
+			)
+
+			// Create mock client
+			client := mockclient.NewMockClient(ctrl)
+			client.EXPECT().Get(context.TODO(), cmKey, &corev1.ConfigMap{}).DoAndReturn(clientGet(cm))
+			client.EXPECT().Get(context.TODO(), secretKey, &corev1.Secret{}).DoAndReturn(clientGet(secret))
+
+			// Create ensurer
+			ensurer := NewEnsurer(acrCredentialProvider, logger)
+			err := ensurer.(inject.Client).InjectClient(client)
+			Expect(err).To(Not(HaveOccurred()))
+
+			// Call EnsureKubeAPIServerDeployment method and check the result
+			err = ensurer.EnsureKubeAPIServerDeployment(context.TODO(), eContextKMS, dep, nil)
+			Expect(err).To(Not(HaveOccurred()))
+
+			ps := dep.Spec.Template.Spec
+			c := extensionswebhook.ContainerWithName(ps.Containers, "kube-apiserver")
+			Expect(c).To(Not(BeNil()))
+			Expect(c.Command).To(ContainElement("--encryption-provider-config=/etc/kubernetes/kms-plugin/encryption-configuration.yaml"))
+			Expect(c.Command).NotTo(ContainElement("--encryption-provider-config=/etc/kubernetes/etcd-encryption-secret/encryption-configuration.yaml"))
+			Expect(c.VolumeMounts).To(ContainElement(corev1.VolumeMount{Name: "kms-plugin", MountPath: "/etc/kubernetes/kms-plugin", ReadOnly: true}))
+			Expect(c.VolumeMounts).To(ContainElement(corev1.VolumeMount{Name: "kms-plugin-socket", MountPath: "/var/run/kmsplugin"}))
+
+			current := extensionswebhook.ContainerWithName(ps.Containers, "azure-kms-plugin-0")
+			Expect(current).To(Not(BeNil()))
+			Expect(current.Args).To(ConsistOf(
+				"--listen-addr=unix:///var/run/kmsplugin/00000000000000000000000000000002.sock",
+				"--keyvault-name=vault",
+				"--key-name=key",
+				"--key-version=00000000000000000000000000000002",
+				"--config-file-path=/etc/kubernetes/kms-plugin/azure.json",
+				"--healthz-port=8787",
+				"--healthz-path=/healthz",
+			))
+			previous := extensionswebhook.ContainerWithName(ps.Containers, "azure-kms-plugin-1")
+			Expect(previous).To(Not(BeNil()))
+			Expect(previous.Args).To(ContainElement("--key-version=00000000000000000000000000000001"))
+			Expect(previous.Args).To(ContainElement("--healthz-port=8788"))
+			Expect(extensionswebhook.ContainerWithName(ps.Containers, "azure-kms-plugin-2")).To(BeNil())
+
+			Expect(ps.Volumes).To(ContainElement(corev1.Volume{Name: "kms-plugin", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "kms-plugin"}}}))
+			Expect(ps.Volumes).To(ContainElement(corev1.Volume{Name: "kms-plugin-socket", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}))
+			Expect(dep.Spec.Template.Annotations).To(HaveKey("checksum/secret-kms-plugin"))
+		})
 	})
 
 	Describe("#EnsureKubeControllerManagerDeployment", func() {
@@ -242,7 +406,7 @@ var _ = Describe("Ensurer", func() {
 			client.EXPECT().Get(context.TODO(), cmKey, &corev1.ConfigMap{}).DoAndReturn(clientGet(cm))
 
 			// Create ensurer
-			ensurer := NewEnsurer(logger)
+			ensurer := NewEnsurer(acrCredentialProvider, logger)
 			err := ensurer.(inject.Client).InjectClient(client)
 			Expect(err).To(Not(HaveOccurred()))
 
@@ -275,7 +439,7 @@ var _ = Describe("Ensurer", func() {
 			client.EXPECT().Get(context.TODO(), cmKey, &corev1.ConfigMap{}).DoAndReturn(clientGet(cm))
 
 			// Create ensurer
-			ensurer := NewEnsurer(logger)
+			ensurer := NewEnsurer(acrCredentialProvider, logger)
 			err := ensurer.(inject.Client).InjectClient(client)
 			Expect(err).To(Not(HaveOccurred()))
 
@@ -319,7 +483,7 @@ var _ = Describe("Ensurer", func() {
 			client.EXPECT().Get(context.TODO(), cmKey, &corev1.ConfigMap{}).DoAndReturn(clientGet(cm))
 
 			// Create ensurer
-			ensurer := NewEnsurer(logger)
+			ensurer := NewEnsurer(acrCredentialProvider, logger)
 			err := ensurer.(inject.Client).InjectClient(client)
 			Expect(err).To(Not(HaveOccurred()))
 
@@ -328,14 +492,116 @@ var _ = Describe("Ensurer", func() {
 			Expect(err).To(Not(HaveOccurred()))
 			checkKubeControllerManagerDeployment(dep, annotations, kubeControllerManagerLabels, true)
 		})
+
+		It("should remove the external cloud volume plugin and enable the CSI migration (k8s >= 1.19)", func() {
+			var (
+				dep = &appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: v1beta1constants.DeploymentNameKubeControllerManager},
+					Spec: appsv1.DeploymentSpec{
+						Template: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{
+										Name: "kube-controller-manager",
+										Command: []string{
+											"--external-cloud-volume-plugin=azure",
+										},
+									},
+								},
+							},
+						},
+					},
+				}
+			)
+
+			// Create mock client
+			client := mockclient.NewMockClient(ctrl)
+			client.EXPECT().Get(context.TODO(), cmKey, &corev1.ConfigMap{}).DoAndReturn(clientGet(cm))
+
+			// Create ensurer
+			ensurer := NewEnsurer(acrCredentialProvider, logger)
+			err := ensurer.(inject.Client).InjectClient(client)
+			Expect(err).To(Not(HaveOccurred()))
+
+			// Call EnsureKubeControllerManagerDeployment method and check the result
+			err = ensurer.EnsureKubeControllerManagerDeployment(context.TODO(), eContextK8s119, dep, nil)
+			Expect(err).To(Not(HaveOccurred()))
+
+			c := extensionswebhook.ContainerWithName(dep.Spec.Template.Spec.Containers, "kube-controller-manager")
+			Expect(c).To(Not(BeNil()))
+			Expect(c.Command).To(ContainElement("--cloud-provider=external"))
+			Expect(c.Command).To(Not(test.ContainElementWithPrefixContaining("--external-cloud-volume-plugin=", "azure", ",")))
+			Expect(c.Command).To(ContainElement("--feature-gates=CSIMigration=true,CSIMigrationAzureDisk=true"))
+		})
+
+		It("should remove the external cloud volume plugin and enable the CSI migration including Azure File (k8s >= 1.21)", func() {
+			var (
+				dep = &appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: v1beta1constants.DeploymentNameKubeControllerManager},
+					Spec: appsv1.DeploymentSpec{
+						Template: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{
+										Name: "kube-controller-manager",
+										Command: []string{
+											"--external-cloud-volume-plugin=azure",
+										},
+									},
+								},
+							},
+						},
+					},
+				}
+			)
+
+			// Create mock client
+			client := mockclient.NewMockClient(ctrl)
+			client.EXPECT().Get(context.TODO(), cmKey, &corev1.ConfigMap{}).DoAndReturn(clientGet(cm))
+
+			// Create ensurer
+			ensurer := NewEnsurer(acrCredentialProvider, logger)
+			err := ensurer.(inject.Client).InjectClient(client)
+			Expect(err).To(Not(HaveOccurred()))
+
+			// Call EnsureKubeControllerManagerDeployment method and check the result
+			err = ensurer.EnsureKubeControllerManagerDeployment(context.TODO(), eContextK8s121, dep, nil)
+			Expect(err).To(Not(HaveOccurred()))
+
+			c := extensionswebhook.ContainerWithName(dep.Spec.Template.Spec.Containers, "kube-controller-manager")
+			Expect(c).To(Not(BeNil()))
+			Expect(c.Command).To(ContainElement("--cloud-provider=external"))
+			Expect(c.Command).To(Not(test.ContainElementWithPrefixContaining("--external-cloud-volume-plugin=", "azure", ",")))
+			Expect(c.Command).To(ContainElement("--feature-gates=CSIMigration=true,CSIMigrationAzureDisk=true,CSIMigrationAzureFile=true"))
+		})
 	})
 
 	Describe("#EnsureKubeletServiceUnitOptions", func() {
 		var (
-			acrCmKey   = client.ObjectKey{Namespace: namespace, Name: azure.CloudProviderAcrConfigName}
-			acrContext = genericmutator.NewInternalEnsurerContext(
+			acrCmKey                   = client.ObjectKey{Namespace: namespace, Name: azure.CloudProviderAcrConfigName}
+			acrCredentialProviderCmKey = client.ObjectKey{Namespace: namespace, Name: azure.CloudProviderAcrCredentialProviderConfigName}
+			acrContext                 = genericmutator.NewInternalEnsurerContext(
+				&extensionscontroller.Cluster{
+					Shoot: &gardencorev1beta1.Shoot{
+						Spec: gardencorev1beta1.ShootSpec{
+							Kubernetes: gardencorev1beta1.Kubernetes{
+								Version: "1.16.0",
+							},
+						},
+						Status: gardencorev1beta1.ShootStatus{
+							TechnicalID: namespace,
+						},
+					},
+				},
+			)
+			acrContextK8s119 = genericmutator.NewInternalEnsurerContext(
 				&extensionscontroller.Cluster{
 					Shoot: &gardencorev1beta1.Shoot{
+						Spec: gardencorev1beta1.ShootSpec{
+							Kubernetes: gardencorev1beta1.Kubernetes{
+								Version: "1.19.0",
+							},
+						},
 						Status: gardencorev1beta1.ShootStatus{
 							TechnicalID: namespace,
 						},
@@ -371,7 +637,7 @@ var _ = Describe("Ensurer", func() {
 			client.EXPECT().Get(context.TODO(), acrCmKey, &corev1.ConfigMap{}).Return(errors.NewNotFound(schema.GroupResource{}, azure.CloudProviderAcrConfigName))
 
 			// Create ensurer
-			ensurer := NewEnsurer(logger)
+			ensurer := NewEnsurer(acrCredentialProvider, logger)
 			err := ensurer.(inject.Client).InjectClient(client)
 			Expect(err).NotTo(HaveOccurred())
 
@@ -381,6 +647,44 @@ var _ = Describe("Ensurer", func() {
 			Expect(opts).To(Equal(newUnitOptions))
 		})
 
+		It("should modify existing elements of kubelet.service unit options and use the external cloud provider (k8s >= 1.19)", func() {
+			var (
+				oldUnitOptions = []*unit.UnitOption{
+					{
+						Section: "Service",
+						Name:    "ExecStart",
+						Value: `/opt/bin/hyperkube kubelet \
+    --config=/var/lib/kubelet/config/kubelet \
+    --cloud-provider=azure \
+    --cloud-config=/var/lib/kubelet/cloudprovider.conf`,
+					},
+				}
+				newUnitOptions = []*unit.UnitOption{
+					{
+						Section: "Service",
+						Name:    "ExecStart",
+						Value: `/opt/bin/hyperkube kubelet \
+    --config=/var/lib/kubelet/config/kubelet \
+    --cloud-provider=external`,
+					},
+				}
+			)
+
+			// Create mock client
+			client := mockclient.NewMockClient(ctrl)
+			client.EXPECT().Get(context.TODO(), acrCmKey, &corev1.ConfigMap{}).Return(errors.NewNotFound(schema.GroupResource{}, azure.CloudProviderAcrConfigName))
+
+			// Create ensurer
+			ensurer := NewEnsurer(acrCredentialProvider, logger)
+			err := ensurer.(inject.Client).InjectClient(client)
+			Expect(err).NotTo(HaveOccurred())
+
+			// Call EnsureKubeletServiceUnitOptions method and check the result
+			opts, err := ensurer.EnsureKubeletServiceUnitOptions(context.TODO(), acrContextK8s119, oldUnitOptions, nil)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(opts).To(Equal(newUnitOptions))
+		})
+
 		It("should modify existing elements of kubelet.service unit options and add acr config", func() {
 			var (
 				acrCM = &corev1.ConfigMap{
@@ -411,9 +715,100 @@ var _ = Describe("Ensurer", func() {
 			// Create mock client
 			client := mockclient.NewMockClient(ctrl)
 			client.EXPECT().Get(context.TODO(), acrCmKey, &corev1.ConfigMap{}).DoAndReturn(clientGet(acrCM))
+			client.EXPECT().Get(context.TODO(), acrCredentialProviderCmKey, &corev1.ConfigMap{}).Return(errors.NewNotFound(schema.GroupResource{}, azure.CloudProviderAcrCredentialProviderConfigName))
 
 			// Create ensurer
-			ensurer := NewEnsurer(logger)
+			ensurer := NewEnsurer(acrCredentialProvider, logger)
+			err := ensurer.(inject.Client).InjectClient(client)
+			Expect(err).NotTo(HaveOccurred())
+
+			// Call EnsureKubeletServiceUnitOptions method and check the result
+			opts, err := ensurer.EnsureKubeletServiceUnitOptions(context.TODO(), acrContext, oldUnitOptions, nil)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(opts).To(Equal(newUnitOptions))
+		})
+
+		It("should modify existing elements of kubelet.service unit options and add the acr credential provider", func() {
+			var (
+				acrCM = &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: azure.CloudProviderAcrConfigName},
+					Data:       map[string]string{},
+				}
+				acrCredentialProviderCM = &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: azure.CloudProviderAcrCredentialProviderConfigName},
+					Data:       map[string]string{},
+				}
+				oldUnitOptions = []*unit.UnitOption{
+					{
+						Section: "Service",
+						Name:    "ExecStart",
+						Value: `/opt/bin/hyperkube kubelet \
+    --config=/var/lib/kubelet/config/kubelet`,
+					},
+				}
+				newUnitOptions = []*unit.UnitOption{
+					{
+						Section: "Service",
+						Name:    "ExecStart",
+						Value: `/opt/bin/hyperkube kubelet \
+    --config=/var/lib/kubelet/config/kubelet \
+    --cloud-provider=azure \
+    --cloud-config=/var/lib/kubelet/cloudprovider.conf \
+    --image-credential-provider-config=/var/lib/kubelet/acr-credential-provider-config.yaml \
+    --image-credential-provider-bin-dir=/opt/bin/kubelet-credential-providers`,
+					},
+				}
+			)
+
+			// Create mock client
+			client := mockclient.NewMockClient(ctrl)
+			client.EXPECT().Get(context.TODO(), acrCmKey, &corev1.ConfigMap{}).DoAndReturn(clientGet(acrCM))
+			client.EXPECT().Get(context.TODO(), acrCredentialProviderCmKey, &corev1.ConfigMap{}).DoAndReturn(clientGet(acrCredentialProviderCM))
+
+			// Create ensurer
+			ensurer := NewEnsurer(acrCredentialProvider, logger)
+			err := ensurer.(inject.Client).InjectClient(client)
+			Expect(err).NotTo(HaveOccurred())
+
+			// Call EnsureKubeletServiceUnitOptions method and check the result
+			opts, err := ensurer.EnsureKubeletServiceUnitOptions(context.TODO(), acrContext, oldUnitOptions, nil)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(opts).To(Equal(newUnitOptions))
+		})
+
+		It("should use the in-tree acr credentials if the downloads of the acr credential provider are not configured", func() {
+			var (
+				acrCM = &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: azure.CloudProviderAcrConfigName},
+					Data:       map[string]string{},
+				}
+				oldUnitOptions = []*unit.UnitOption{
+					{
+						Section: "Service",
+						Name:    "ExecStart",
+						Value: `/opt/bin/hyperkube kubelet \
+    --config=/var/lib/kubelet/config/kubelet`,
+					},
+				}
+				newUnitOptions = []*unit.UnitOption{
+					{
+						Section: "Service",
+						Name:    "ExecStart",
+						Value: `/opt/bin/hyperkube kubelet \
+    --config=/var/lib/kubelet/config/kubelet \
+    --cloud-provider=azure \
+    --cloud-config=/var/lib/kubelet/cloudprovider.conf \
+    --azure-container-registry-config=/var/lib/kubelet/acr.conf`,
+					},
+				}
+			)
+
+			// Create mock client
+			client := mockclient.NewMockClient(ctrl)
+			client.EXPECT().Get(context.TODO(), acrCmKey, &corev1.ConfigMap{}).DoAndReturn(clientGet(acrCM))
+
+			// Create ensurer
+			ensurer := NewEnsurer(&config.AcrCredentialProviderConfig{}, logger)
 			err := ensurer.(inject.Client).InjectClient(client)
 			Expect(err).NotTo(HaveOccurred())
 
@@ -424,6 +819,132 @@ var _ = Describe("Ensurer", func() {
 		})
 	})
 
+	Describe("#EnsureAdditionalUnits", func() {
+		var (
+			acrCmKey                   = client.ObjectKey{Namespace: namespace, Name: azure.CloudProviderAcrConfigName}
+			acrCredentialProviderCmKey = client.ObjectKey{Namespace: namespace, Name: azure.CloudProviderAcrCredentialProviderConfigName}
+			acrContext                 = genericmutator.NewInternalEnsurerContext(
+				&extensionscontroller.Cluster{
+					Shoot: &gardencorev1beta1.Shoot{
+						Status: gardencorev1beta1.ShootStatus{
+							TechnicalID: namespace,
+						},
+					},
+				},
+			)
+			acrCM = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: azure.CloudProviderAcrConfigName},
+			}
+			acrCredentialProviderCM = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: azure.CloudProviderAcrCredentialProviderConfigName},
+			}
+		)
+
+		It("should not add the install unit of the acr credential provider if it is not used", func() {
+			// Create mock client
+			client := mockclient.NewMockClient(ctrl)
+			client.EXPECT().Get(context.TODO(), acrCmKey, &corev1.ConfigMap{}).DoAndReturn(clientGet(acrCM))
+			client.EXPECT().Get(context.TODO(), acrCredentialProviderCmKey, &corev1.ConfigMap{}).Return(errors.NewNotFound(schema.GroupResource{}, azure.CloudProviderAcrCredentialProviderConfigName))
+
+			// Create ensurer
+			ensurer := NewEnsurer(acrCredentialProvider, logger)
+			err := ensurer.(inject.Client).InjectClient(client)
+			Expect(err).NotTo(HaveOccurred())
+
+			// Call EnsureAdditionalUnits method and check the result
+			units := []extensionsv1alpha1.Unit{{Name: "foo.service"}}
+			err = ensurer.EnsureAdditionalUnits(context.TODO(), acrContext, &units, nil)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(units).To(Equal([]extensionsv1alpha1.Unit{{Name: "foo.service"}}))
+		})
+
+		It("should add the install unit of the acr credential provider", func() {
+			// Create mock client
+			client := mockclient.NewMockClient(ctrl)
+			client.EXPECT().Get(context.TODO(), acrCmKey, &corev1.ConfigMap{}).DoAndReturn(clientGet(acrCM))
+			client.EXPECT().Get(context.TODO(), acrCredentialProviderCmKey, &corev1.ConfigMap{}).DoAndReturn(clientGet(acrCredentialProviderCM))
+
+			// Create ensurer
+			ensurer := NewEnsurer(acrCredentialProvider, logger)
+			err := ensurer.(inject.Client).InjectClient(client)
+			Expect(err).NotTo(HaveOccurred())
+
+			// Call EnsureAdditionalUnits method and check the result
+			units := []extensionsv1alpha1.Unit{{Name: "foo.service"}, {Name: acrCredentialProviderInstallUnit}}
+			err = ensurer.EnsureAdditionalUnits(context.TODO(), acrContext, &units, nil)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(units).To(Equal([]extensionsv1alpha1.Unit{
+				{Name: "foo.service"},
+				{
+					Name:    acrCredentialProviderInstallUnit,
+					Command: util.StringPtr("start"),
+					Enable:  util.BoolPtr(true),
+					Content: util.StringPtr(acrCredentialProviderInstallUnitContent),
+				},
+			}))
+			Expect(*units[1].Content).To(ContainSubstring("ExecStart=/bin/sh " + acrCredentialProviderInstallScriptPath))
+		})
+	})
+
+	Describe("#EnsureAdditionalFiles", func() {
+		var (
+			acrCmKey                   = client.ObjectKey{Namespace: namespace, Name: azure.CloudProviderAcrConfigName}
+			acrCredentialProviderCmKey = client.ObjectKey{Namespace: namespace, Name: azure.CloudProviderAcrCredentialProviderConfigName}
+			acrContext                 = genericmutator.NewInternalEnsurerContext(
+				&extensionscontroller.Cluster{
+					Shoot: &gardencorev1beta1.Shoot{
+						Status: gardencorev1beta1.ShootStatus{
+							TechnicalID: namespace,
+						},
+					},
+				},
+			)
+			acrCM = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: azure.CloudProviderAcrConfigName},
+				Data:       map[string]string{azure.CloudProviderAcrConfigMapKey: "acr"},
+			}
+			acrCredentialProviderCM = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: azure.CloudProviderAcrCredentialProviderConfigName},
+				Data:       map[string]string{azure.CloudProviderAcrCredentialProviderConfigMapKey: "credential-provider"},
+			}
+		)
+
+		It("should add the files of the acr credential provider with a script which verifies its download", func() {
+			// Create mock client
+			client := mockclient.NewMockClient(ctrl)
+			client.EXPECT().Get(context.TODO(), acrCmKey, &corev1.ConfigMap{}).DoAndReturn(clientGet(acrCM))
+			client.EXPECT().Get(context.TODO(), acrCredentialProviderCmKey, &corev1.ConfigMap{}).DoAndReturn(clientGet(acrCredentialProviderCM))
+
+			// Create ensurer
+			ensurer := NewEnsurer(acrCredentialProvider, logger)
+			err := ensurer.(inject.Client).InjectClient(client)
+			Expect(err).NotTo(HaveOccurred())
+
+			// Call EnsureAdditionalFiles method and check the result
+			files := []extensionsv1alpha1.File{}
+			err = ensurer.EnsureAdditionalFiles(context.TODO(), acrContext, &files, nil)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(files).To(HaveLen(3))
+			Expect(files[0].Path).To(Equal(acrConfigPath))
+			Expect(files[1].Path).To(Equal(acrCredentialProviderConfigPath))
+			Expect(files[2].Path).To(Equal(acrCredentialProviderInstallScriptPath))
+
+			script, err := base64.StdEncoding.DecodeString(files[2].Content.Inline.Data)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(script)).To(ContainSubstring(`aarch64) arch=arm64 ;;`))
+			Expect(string(script)).To(ContainSubstring(`amd64)
+  url='https://example.com/acr-credential-provider-amd64'
+  sha256='0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef'
+  ;;
+arm64)
+  url='https://example.com/acr-credential-provider-arm64'
+  sha256='fedcba9876543210fedcba9876543210fedcba9876543210fedcba9876543210'
+  ;;
+*)`))
+			Expect(string(script)).To(ContainSubstring(`echo "$sha256  /opt/bin/kubelet-credential-providers/acr-credential-provider.tmp" | sha256sum -c -`))
+		})
+	})
+
 	Describe("#EnsureKubeletConfiguration", func() {
 		It("should modify existing elements of kubelet configuration", func() {
 			var (
@@ -442,11 +963,93 @@ var _ = Describe("Ensurer", func() {
 			)
 
 			// Create ensurer
-			ensurer := NewEnsurer(logger)
+			ensurer := NewEnsurer(acrCredentialProvider, logger)
+
+			// Call EnsureKubeletConfiguration method and check the result
+			kubeletConfig := *oldKubeletConfig
+			err := ensurer.EnsureKubeletConfiguration(context.TODO(), eContextK8s116, &kubeletConfig, nil)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(&kubeletConfig).To(Equal(newKubeletConfig))
+		})
+
+		It("should enable the CSI migration in the kubelet configuration (k8s >= 1.19)", func() {
+			var (
+				oldKubeletConfig = &kubeletconfigv1beta1.KubeletConfiguration{
+					FeatureGates: map[string]bool{
+						"Foo":          true,
+						"CSIMigration": false,
+					},
+				}
+				newKubeletConfig = &kubeletconfigv1beta1.KubeletConfiguration{
+					FeatureGates: map[string]bool{
+						"Foo":                   true,
+						"CSIMigration":          true,
+						"CSIMigrationAzureDisk": true,
+					},
+				}
+			)
+
+			// Create ensurer
+			ensurer := NewEnsurer(acrCredentialProvider, logger)
+
+			// Call EnsureKubeletConfiguration method and check the result
+			kubeletConfig := *oldKubeletConfig
+			err := ensurer.EnsureKubeletConfiguration(context.TODO(), eContextK8s119, &kubeletConfig, nil)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(&kubeletConfig).To(Equal(newKubeletConfig))
+		})
+
+		It("should enable the CSI migration including Azure File in the kubelet configuration (k8s >= 1.21)", func() {
+			var (
+				oldKubeletConfig = &kubeletconfigv1beta1.KubeletConfiguration{
+					FeatureGates: map[string]bool{
+						"Foo": true,
+					},
+				}
+				newKubeletConfig = &kubeletconfigv1beta1.KubeletConfiguration{
+					FeatureGates: map[string]bool{
+						"Foo":                   true,
+						"CSIMigration":          true,
+						"CSIMigrationAzureDisk": true,
+						"CSIMigrationAzureFile": true,
+					},
+				}
+			)
+
+			// Create ensurer
+			ensurer := NewEnsurer(nil, logger)
+
+			// Call EnsureKubeletConfiguration method and check the result
+			kubeletConfig := *oldKubeletConfig
+			err := ensurer.EnsureKubeletConfiguration(context.TODO(), eContextK8s121, &kubeletConfig, nil)
+			Expect(err).To(Not(HaveOccurred()))
+			Expect(&kubeletConfig).To(Equal(newKubeletConfig))
+		})
+
+		It("should enable the image credential providers in the kubelet configuration if the acr credential provider is configured (k8s >= 1.20)", func() {
+			var (
+				oldKubeletConfig = &kubeletconfigv1beta1.KubeletConfiguration{
+					FeatureGates: map[string]bool{
+						"Foo": true,
+					},
+				}
+				newKubeletConfig = &kubeletconfigv1beta1.KubeletConfiguration{
+					FeatureGates: map[string]bool{
+						"Foo":                        true,
+						"CSIMigration":               true,
+						"CSIMigrationAzureDisk":      true,
+						"CSIMigrationAzureFile":      true,
+						"KubeletCredentialProviders": true,
+					},
+				}
+			)
+
+			// Create ensurer
+			ensurer := NewEnsurer(acrCredentialProvider, logger)
 
 			// Call EnsureKubeletConfiguration method and check the result
 			kubeletConfig := *oldKubeletConfig
-			err := ensurer.EnsureKubeletConfiguration(context.TODO(), dummyContext, &kubeletConfig, nil)
+			err := ensurer.EnsureKubeletConfiguration(context.TODO(), eContextK8s121, &kubeletConfig, nil)
 			Expect(err).To(Not(HaveOccurred()))
 			Expect(&kubeletConfig).To(Equal(newKubeletConfig))
 		})
@@ -469,7 +1072,7 @@ var _ = Describe("Ensurer", func() {
 			client.EXPECT().Get(context.TODO(), cmKey, &corev1.ConfigMap{}).Return(errors.NewNotFound(schema.GroupResource{}, cm.Name))
 
 			// Create ensurer
-			ensurer := NewEnsurer(logger)
+			ensurer := NewEnsurer(acrCredentialProvider, logger)
 			err := ensurer.(inject.Client).InjectClient(client)
 			Expect(err).NotTo(HaveOccurred())
 
@@ -484,7 +1087,7 @@ var _ = Describe("Ensurer", func() {
 			client.EXPECT().Get(context.TODO(), cmKey, &corev1.ConfigMap{}).DoAndReturn(clientGet(cm))
 
 			// Create ensurer
-			ensurer := NewEnsurer(logger)
+			ensurer := NewEnsurer(acrCredentialProvider, logger)
 			err := ensurer.(inject.Client).InjectClient(client)
 			Expect(err).NotTo(HaveOccurred())
 
@@ -499,7 +1102,7 @@ var _ = Describe("Ensurer", func() {
 			client.EXPECT().Get(context.TODO(), cmKey, &corev1.ConfigMap{}).DoAndReturn(clientGet(cm))
 
 			// Create ensurer
-			ensurer := NewEnsurer(logger)
+			ensurer := NewEnsurer(acrCredentialProvider, logger)
 			err := ensurer.(inject.Client).InjectClient(client)
 			Expect(err).NotTo(HaveOccurred())
 
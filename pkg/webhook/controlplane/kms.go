@@ -0,0 +1,127 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplane
+
+import (
+	"fmt"
+	"path/filepath"
+
+	apisazure "github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure"
+	"github.com/gardener/gardener-extension-provider-azure/pkg/azure"
+	extensionswebhook "github.com/gardener/gardener-extensions/pkg/webhook"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	kmsPluginContainerNamePrefix = "azure-kms-plugin-"
+	kmsPluginConfigPath          = "/etc/kubernetes/kms-plugin"
+	kmsPluginSocketName          = "kms-plugin-socket"
+	// kmsPluginHealthzBasePort is the health port of the KMS plugin for the current key version, the plugins for the
+	// previous key versions use the subsequent ports.
+	kmsPluginHealthzBasePort = 8787
+)
+
+var (
+	kmsPluginVolumeMount = corev1.VolumeMount{
+		Name:      azure.KMSPluginSecretName,
+		MountPath: kmsPluginConfigPath,
+		ReadOnly:  true,
+	}
+	kmsPluginVolume = corev1.Volume{
+		Name: azure.KMSPluginSecretName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{SecretName: azure.KMSPluginSecretName},
+		},
+	}
+
+	kmsPluginSocketVolumeMount = corev1.VolumeMount{
+		Name:      kmsPluginSocketName,
+		MountPath: azure.KMSPluginSocketDir,
+	}
+	kmsPluginSocketVolume = corev1.Volume{
+		Name: kmsPluginSocketName,
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	}
+)
+
+// ensureKMSPlugin configures the kube-apiserver to use the encryption configuration of the KMS plugin secret and adds
+// a KMS plugin sidecar for the current and each previous key version.
+func ensureKMSPlugin(ps *corev1.PodSpec, c *corev1.Container, kms *apisazure.KMS, image string) {
+	c.Command = extensionswebhook.EnsureStringWithPrefix(c.Command, "--encryption-provider-config=",
+		filepath.Join(kmsPluginConfigPath, azure.KMSEncryptionConfigurationKey))
+	c.VolumeMounts = extensionswebhook.EnsureVolumeMountWithName(c.VolumeMounts, kmsPluginVolumeMount)
+	c.VolumeMounts = extensionswebhook.EnsureVolumeMountWithName(c.VolumeMounts, kmsPluginSocketVolumeMount)
+
+	keyVersions := append([]string{kms.KeyVersion}, kms.PreviousKeyVersions...)
+	for i, keyVersion := range keyVersions {
+		ps.Containers = extensionswebhook.EnsureContainerWithName(ps.Containers, getKMSPluginContainer(kms, keyVersion, image, kmsPluginHealthzBasePort+i, i))
+	}
+	// Remove the sidecars of key versions which are not used anymore.
+	for i := len(keyVersions); extensionswebhook.ContainerWithName(ps.Containers, kmsPluginContainerName(i)) != nil; i++ {
+		ps.Containers = extensionswebhook.EnsureNoContainerWithName(ps.Containers, kmsPluginContainerName(i))
+	}
+
+	ps.Volumes = extensionswebhook.EnsureVolumeWithName(ps.Volumes, kmsPluginVolume)
+	ps.Volumes = extensionswebhook.EnsureVolumeWithName(ps.Volumes, kmsPluginSocketVolume)
+}
+
+func getKMSPluginContainer(kms *apisazure.KMS, keyVersion, image string, healthzPort, index int) corev1.Container {
+	return corev1.Container{
+		Name:            kmsPluginContainerName(index),
+		Image:           image,
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Args: []string{
+			"--listen-addr=" + azure.KMSPluginEndpoint(keyVersion),
+			"--keyvault-name=" + kms.KeyVaultName,
+			"--key-name=" + kms.KeyName,
+			"--key-version=" + keyVersion,
+			"--config-file-path=" + filepath.Join(kmsPluginConfigPath, azure.KMSPluginConfigKey),
+			fmt.Sprintf("--healthz-port=%d", healthzPort),
+			"--healthz-path=/healthz",
+		},
+		LivenessProbe: &corev1.Probe{
+			Handler: corev1.Handler{
+				HTTPGet: &corev1.HTTPGetAction{
+					Path: "/healthz",
+					Port: intstr.FromInt(healthzPort),
+				},
+			},
+			InitialDelaySeconds: 10,
+			PeriodSeconds:       10,
+			TimeoutSeconds:      3,
+			FailureThreshold:    5,
+		},
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("10m"),
+				corev1.ResourceMemory: resource.MustParse("32Mi"),
+			},
+			Limits: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("50m"),
+				corev1.ResourceMemory: resource.MustParse("128Mi"),
+			},
+		},
+		VolumeMounts: []corev1.VolumeMount{kmsPluginVolumeMount, kmsPluginSocketVolumeMount},
+	}
+}
+
+func kmsPluginContainerName(index int) string {
+	return fmt.Sprintf("%s%d", kmsPluginContainerNamePrefix, index)
+}
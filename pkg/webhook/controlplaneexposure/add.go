@@ -38,6 +38,8 @@ var (
 type AddOptions struct {
 	// ETCDStorage is the etcd storage configuration.
 	ETCDStorage config.ETCDStorage
+	// KubeAPIServerExposure contains the defaults for the exposure of the kube-apiservers.
+	KubeAPIServerExposure config.KubeAPIServerExposureConfig
 }
 
 var logger = log.Log.WithName("azure-controlplaneexposure-webhook")
@@ -49,7 +51,7 @@ func AddToManagerWithOptions(mgr manager.Manager, opts AddOptions) (*extensionsw
 		Kind:     controlplane.KindSeed,
 		Provider: azure.Type,
 		Types:    []runtime.Object{&appsv1.Deployment{}, &corev1.Service{}, &druidv1alpha1.Etcd{}},
-		Mutator:  genericmutator.NewMutator(NewEnsurer(&opts.ETCDStorage, logger), nil, nil, nil, logger),
+		Mutator:  genericmutator.NewMutator(NewEnsurer(&opts.ETCDStorage, &opts.KubeAPIServerExposure, logger), nil, nil, nil, logger),
 	})
 }
 
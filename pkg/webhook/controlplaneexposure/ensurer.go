@@ -25,6 +25,7 @@ import (
 
 	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
 	v1beta1helper "github.com/gardener/gardener/pkg/apis/core/v1beta1/helper"
+	"github.com/gardener/gardener/pkg/operation/common"
 	kutil "github.com/gardener/gardener/pkg/utils/kubernetes"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
@@ -35,18 +36,20 @@ import (
 )
 
 // NewEnsurer creates a new controlplaneexposure ensurer.
-func NewEnsurer(etcdStorage *config.ETCDStorage, logger logr.Logger) genericmutator.Ensurer {
+func NewEnsurer(etcdStorage *config.ETCDStorage, kubeAPIServerExposure *config.KubeAPIServerExposureConfig, logger logr.Logger) genericmutator.Ensurer {
 	return &ensurer{
-		etcdStorage: etcdStorage,
-		logger:      logger.WithName("ensurer"),
+		etcdStorage:           etcdStorage,
+		kubeAPIServerExposure: kubeAPIServerExposure,
+		logger:                logger.WithName("ensurer"),
 	}
 }
 
 type ensurer struct {
 	genericmutator.NoopEnsurer
-	etcdStorage *config.ETCDStorage
-	client      client.Client
-	logger      logr.Logger
+	etcdStorage           *config.ETCDStorage
+	kubeAPIServerExposure *config.KubeAPIServerExposureConfig
+	client                client.Client
+	logger                logr.Logger
 }
 
 // InjectClient injects the given client into the ensurer.
@@ -61,11 +64,18 @@ func (e *ensurer) EnsureKubeAPIServerService(ctx context.Context, ectx genericmu
 		return nil
 	}
 
-	// TODO: Assuming seed kubernetes version is >= 1.12. Validate it correctly
-	if new.Annotations == nil {
-		new.Annotations = make(map[string]string)
+	cluster, err := controller.GetCluster(ctx, e.client, new.Namespace)
+	if err != nil {
+		return err
 	}
-	new.Annotations["service.beta.kubernetes.io/azure-load-balancer-tcp-idle-timeout"] = "30"
+
+	exposure, err := getKubeAPIServerExposure(ctx, e.client, e.kubeAPIServerExposure, cluster, new.Namespace)
+	if err != nil {
+		return err
+	}
+
+	// TODO: Assuming seed kubernetes version is >= 1.12. Validate it correctly
+	exposure.ensureService(new)
 	return nil
 }
 
@@ -90,9 +100,29 @@ func (e *ensurer) EnsureKubeAPIServerDeployment(ctx context.Context, ectx generi
 		return errors.Wrap(err, "could not get kube-apiserver service load balancer address")
 	}
 
+	exposure, err := getKubeAPIServerExposure(ctx, e.client, e.kubeAPIServerExposure, cluster, new.Namespace)
+	if err != nil {
+		return err
+	}
+
+	// The address of an internal load balancer is only reachable in the virtual network of the seed, hence clients
+	// outside of it use the domain of the shoot which is resolved to their private endpoint.
+	hostname := address
+	if exposure.internal && cluster.Shoot.Spec.DNS != nil && cluster.Shoot.Spec.DNS.Domain != nil {
+		hostname = common.GetAPIServerDomain(*cluster.Shoot.Spec.DNS.Domain)
+	}
+	// The static public IP and its DNS label do not change if the service is recreated.
+	if publicIP := exposure.publicIP; publicIP != nil {
+		address = publicIP.IPAddress
+		hostname = address
+		if publicIP.FQDN != nil {
+			hostname = *publicIP.FQDN
+		}
+	}
+
 	if c := extensionswebhook.ContainerWithName(new.Spec.Template.Spec.Containers, "kube-apiserver"); c != nil {
 		c.Command = extensionswebhook.EnsureStringWithPrefix(c.Command, "--advertise-address=", address)
-		c.Command = extensionswebhook.EnsureStringWithPrefix(c.Command, "--external-hostname=", address)
+		c.Command = extensionswebhook.EnsureStringWithPrefix(c.Command, "--external-hostname=", hostname)
 	}
 	return nil
 }
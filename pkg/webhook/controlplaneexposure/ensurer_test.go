@@ -79,6 +79,87 @@ var _ = Describe("Ensurer", func() {
 				},
 			},
 		}
+
+		kubeAPIServerExposure = &config.KubeAPIServerExposureConfig{
+			Internal: util.BoolPtr(true),
+			Subnet:   util.StringPtr("internal"),
+			PrivateLinkService: &config.PrivateLinkServiceConfig{
+				AllowedSubscriptions: []string{"sub1", "sub2"},
+			},
+			TCPIdleTimeoutInMinutes: util.Int32Ptr(20),
+		}
+		privateCluster = &extensionsv1alpha1.Cluster{
+			Spec: extensionsv1alpha1.ClusterSpec{
+				Shoot: runtime.RawExtension{
+					Raw: encode(&gardencorev1beta1.Shoot{
+						TypeMeta: metav1.TypeMeta{APIVersion: gardencorev1beta1.SchemeGroupVersion.String(), Kind: "Shoot"},
+						Spec: gardencorev1beta1.ShootSpec{
+							DNS: &gardencorev1beta1.DNS{Domain: util.StringPtr("shoot.example.com")},
+							Provider: gardencorev1beta1.Provider{
+								ControlPlaneConfig: &gardencorev1beta1.ProviderConfig{
+									RawExtension: runtime.RawExtension{
+										Raw: []byte(`{"apiVersion":"azure.provider.extensions.gardener.cloud/v1alpha1","kind":"ControlPlaneConfig","kubeAPIServerExposure":{"privateLinkService":{"enabled":true,"autoApprovedSubscriptions":["sub1"]},"tcpIdleTimeoutInMinutes":10}}`),
+									},
+								},
+							},
+						},
+					}),
+				},
+			},
+		}
+		publicIPExposure = &config.KubeAPIServerExposureConfig{
+			PublicIP: &config.PublicIPConfig{ResourceGroup: "public-ips"},
+		}
+		exposurePurpose = extensionsv1alpha1.Exposure
+		cpList          = &extensionsv1alpha1.ControlPlaneList{
+			Items: []extensionsv1alpha1.ControlPlane{
+				{ObjectMeta: metav1.ObjectMeta{Name: "shoot", Namespace: namespace}},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "shoot-exposure", Namespace: namespace},
+					Spec:       extensionsv1alpha1.ControlPlaneSpec{Purpose: &exposurePurpose},
+					Status: extensionsv1alpha1.ControlPlaneStatus{
+						DefaultStatus: extensionsv1alpha1.DefaultStatus{
+							ProviderStatus: &runtime.RawExtension{
+								Raw: []byte(`{"apiVersion":"azure.provider.extensions.gardener.cloud/v1alpha1","kind":"ControlPlaneStatus","publicIP":{"name":"test","resourceGroup":"public-ips","ipAddress":"5.6.7.8","fqdn":"my-api.westeurope.cloudapp.azure.com"}}`),
+							},
+						},
+					},
+				},
+			},
+		}
+		publicCPList = &extensionsv1alpha1.ControlPlaneList{
+			Items: []extensionsv1alpha1.ControlPlane{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "shoot-exposure", Namespace: namespace},
+					Spec:       extensionsv1alpha1.ControlPlaneSpec{Purpose: &exposurePurpose},
+					Status: extensionsv1alpha1.ControlPlaneStatus{
+						DefaultStatus: extensionsv1alpha1.DefaultStatus{
+							ProviderStatus: &runtime.RawExtension{
+								Raw: []byte(`{"apiVersion":"azure.provider.extensions.gardener.cloud/v1alpha1","kind":"ControlPlaneStatus","kubeAPIServerExposure":{"internal":false}}`),
+							},
+						},
+					},
+				},
+			},
+		}
+		publicCluster = &extensionsv1alpha1.Cluster{
+			Spec: extensionsv1alpha1.ClusterSpec{
+				Shoot: runtime.RawExtension{
+					Raw: encode(&gardencorev1beta1.Shoot{
+						TypeMeta: metav1.TypeMeta{APIVersion: gardencorev1beta1.SchemeGroupVersion.String(), Kind: "Shoot"},
+						Spec: gardencorev1beta1.ShootSpec{
+							Provider: gardencorev1beta1.Provider{
+								ControlPlaneConfig: &gardencorev1beta1.ProviderConfig{
+									RawExtension: runtime.RawExtension{
+										Raw: []byte(`{"apiVersion":"azure.provider.extensions.gardener.cloud/v1alpha1","kind":"ControlPlaneConfig","kubeAPIServerExposure":{"privateLinkService":{"enabled":true}}}`),
+									},
+								},
+							},
+						},
+					}),
+				},
+			},
+		}
 	)
 
 	BeforeEach(func() {
@@ -114,7 +195,7 @@ var _ = Describe("Ensurer", func() {
 			)
 
 			// Create ensurer
-			ensurer := NewEnsurer(etcdStorage, logger)
+			ensurer := NewEnsurer(etcdStorage, nil, logger)
 
 			err := ensurer.EnsureKubeAPIServerDeployment(context.TODO(), dummyContext, dep, nil)
 			Expect(err).To(Not(HaveOccurred()))
@@ -146,7 +227,7 @@ var _ = Describe("Ensurer", func() {
 			c.EXPECT().Get(context.TODO(), svcKey, &corev1.Service{}).DoAndReturn(clientGet(svc))
 
 			// Create ensurer
-			ensurer := NewEnsurer(etcdStorage, logger)
+			ensurer := NewEnsurer(etcdStorage, nil, logger)
 			err := ensurer.(inject.Client).InjectClient(c)
 			Expect(err).To(Not(HaveOccurred()))
 
@@ -181,7 +262,7 @@ var _ = Describe("Ensurer", func() {
 			c.EXPECT().Get(context.TODO(), svcKey, &corev1.Service{}).DoAndReturn(clientGet(svc))
 
 			// Create ensurer
-			ensurer := NewEnsurer(etcdStorage, logger)
+			ensurer := NewEnsurer(etcdStorage, nil, logger)
 			err := ensurer.(inject.Client).InjectClient(c)
 			Expect(err).To(Not(HaveOccurred()))
 
@@ -190,6 +271,82 @@ var _ = Describe("Ensurer", func() {
 			Expect(err).To(Not(HaveOccurred()))
 			checkKubeAPIServerDeployment(dep)
 		})
+
+		It("should use the domain of the shoot as external hostname if the kube-apiserver is exposed internally", func() {
+			var (
+				dep = &appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{Name: v1beta1constants.DeploymentNameKubeAPIServer, Namespace: namespace},
+					Spec: appsv1.DeploymentSpec{
+						Template: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{
+										Name: "kube-apiserver",
+									},
+								},
+							},
+						},
+					},
+				}
+			)
+
+			// Create mock client
+			c := mockclient.NewMockClient(ctrl)
+			c.EXPECT().Get(context.TODO(), client.ObjectKey{Name: namespace}, &extensionsv1alpha1.Cluster{}).DoAndReturn(clientGet(privateCluster))
+			c.EXPECT().Get(context.TODO(), svcKey, &corev1.Service{}).DoAndReturn(clientGet(svc))
+			c.EXPECT().List(context.TODO(), &extensionsv1alpha1.ControlPlaneList{}, client.InNamespace(namespace)).DoAndReturn(clientList(&extensionsv1alpha1.ControlPlaneList{}))
+
+			// Create ensurer
+			ensurer := NewEnsurer(etcdStorage, kubeAPIServerExposure, logger)
+			err := ensurer.(inject.Client).InjectClient(c)
+			Expect(err).To(Not(HaveOccurred()))
+
+			// Call EnsureKubeAPIServerDeployment method and check the result
+			err = ensurer.EnsureKubeAPIServerDeployment(context.TODO(), dummyContext, dep, nil)
+			Expect(err).To(Not(HaveOccurred()))
+
+			container := extensionswebhook.ContainerWithName(dep.Spec.Template.Spec.Containers, "kube-apiserver")
+			Expect(container).To(Not(BeNil()))
+			Expect(container.Command).To(ConsistOf("--advertise-address=1.2.3.4", "--external-hostname=api.shoot.example.com"))
+		})
+
+		It("should use the static public IP and its domain name", func() {
+			var (
+				dep = &appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{Name: v1beta1constants.DeploymentNameKubeAPIServer, Namespace: namespace},
+					Spec: appsv1.DeploymentSpec{
+						Template: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{
+										Name: "kube-apiserver",
+									},
+								},
+							},
+						},
+					},
+				}
+			)
+
+			// Create mock client
+			c := mockclient.NewMockClient(ctrl)
+			c.EXPECT().Get(context.TODO(), client.ObjectKey{Name: namespace}, &extensionsv1alpha1.Cluster{}).DoAndReturn(clientGet(cluster))
+			c.EXPECT().Get(context.TODO(), svcKey, &corev1.Service{}).DoAndReturn(clientGet(svc))
+			c.EXPECT().List(context.TODO(), &extensionsv1alpha1.ControlPlaneList{}, client.InNamespace(namespace)).DoAndReturn(clientList(cpList))
+
+			// Create ensurer
+			ensurer := NewEnsurer(etcdStorage, publicIPExposure, logger)
+			err := ensurer.(inject.Client).InjectClient(c)
+			Expect(err).To(Not(HaveOccurred()))
+
+			// Call EnsureKubeAPIServerDeployment method and check the result
+			err = ensurer.EnsureKubeAPIServerDeployment(context.TODO(), dummyContext, dep, nil)
+			Expect(err).To(Not(HaveOccurred()))
+
+			container := extensionswebhook.ContainerWithName(dep.Spec.Template.Spec.Containers, "kube-apiserver")
+			Expect(container).To(Not(BeNil()))
+			Expect(container.Command).To(ConsistOf("--advertise-address=5.6.7.8", "--external-hostname=my-api.westeurope.cloudapp.azure.com"))
+		})
 	})
 
 	Describe("#EnsureKubeAPIServerService", func() {
@@ -205,7 +362,7 @@ var _ = Describe("Ensurer", func() {
 					Namespace: namespace,
 				},
 			}
-			ensurer = NewEnsurer(etcdStorage, logger)
+			ensurer = NewEnsurer(etcdStorage, nil, logger)
 		})
 
 		It("should not modify kube-apiserver service if SNI is enabled", func() {
@@ -222,11 +379,103 @@ var _ = Describe("Ensurer", func() {
 			svcCopy := svc.DeepCopy()
 			svcCopy.Annotations = map[string]string{"service.beta.kubernetes.io/azure-load-balancer-tcp-idle-timeout": "30"}
 
-			err := ensurer.EnsureKubeAPIServerService(context.TODO(), dummyContext, svc, nil)
+			c := mockclient.NewMockClient(ctrl)
+			c.EXPECT().Get(context.TODO(), client.ObjectKey{Name: namespace}, &extensionsv1alpha1.Cluster{}).DoAndReturn(clientGet(cluster))
+			err := ensurer.(inject.Client).InjectClient(c)
+			Expect(err).To(Not(HaveOccurred()))
+
+			err = ensurer.EnsureKubeAPIServerService(context.TODO(), dummyContext, svc, nil)
 			Expect(err).To(Not(HaveOccurred()))
 
 			Expect(svc).To(Equal(svcCopy))
 		})
+
+		It("should expose the kube-apiserver by an internal load balancer and a private link service", func() {
+			svc.Annotations = map[string]string{
+				"foo": "bar",
+				"service.beta.kubernetes.io/azure-pls-visibility": "stale",
+			}
+			ensurer = NewEnsurer(etcdStorage, kubeAPIServerExposure, logger)
+
+			c := mockclient.NewMockClient(ctrl)
+			c.EXPECT().Get(context.TODO(), client.ObjectKey{Name: namespace}, &extensionsv1alpha1.Cluster{}).DoAndReturn(clientGet(privateCluster))
+			c.EXPECT().List(context.TODO(), &extensionsv1alpha1.ControlPlaneList{}, client.InNamespace(namespace)).DoAndReturn(clientList(&extensionsv1alpha1.ControlPlaneList{}))
+			err := ensurer.(inject.Client).InjectClient(c)
+			Expect(err).To(Not(HaveOccurred()))
+
+			err = ensurer.EnsureKubeAPIServerService(context.TODO(), dummyContext, svc, nil)
+			Expect(err).To(Not(HaveOccurred()))
+
+			Expect(svc.Annotations).To(Equal(map[string]string{
+				"foo": "bar",
+				"service.beta.kubernetes.io/azure-load-balancer-tcp-idle-timeout": "10",
+				"service.beta.kubernetes.io/azure-load-balancer-internal":         "true",
+				"service.beta.kubernetes.io/azure-load-balancer-internal-subnet":  "internal",
+				"service.beta.kubernetes.io/azure-pls-create":                     "true",
+				"service.beta.kubernetes.io/azure-pls-name":                       namespace,
+				"service.beta.kubernetes.io/azure-pls-ip-configuration-subnet":    "internal",
+				"service.beta.kubernetes.io/azure-pls-visibility":                 "sub1 sub2",
+				"service.beta.kubernetes.io/azure-pls-auto-approval":              "sub1",
+			}))
+		})
+
+		It("should bind the kube-apiserver service to the static public IP", func() {
+			ensurer = NewEnsurer(etcdStorage, publicIPExposure, logger)
+
+			c := mockclient.NewMockClient(ctrl)
+			c.EXPECT().Get(context.TODO(), client.ObjectKey{Name: namespace}, &extensionsv1alpha1.Cluster{}).DoAndReturn(clientGet(cluster))
+			c.EXPECT().List(context.TODO(), &extensionsv1alpha1.ControlPlaneList{}, client.InNamespace(namespace)).DoAndReturn(clientList(cpList))
+			err := ensurer.(inject.Client).InjectClient(c)
+			Expect(err).To(Not(HaveOccurred()))
+
+			err = ensurer.EnsureKubeAPIServerService(context.TODO(), dummyContext, svc, nil)
+			Expect(err).To(Not(HaveOccurred()))
+
+			Expect(svc.Annotations).To(Equal(map[string]string{
+				"service.beta.kubernetes.io/azure-load-balancer-tcp-idle-timeout": "30",
+				"service.beta.kubernetes.io/azure-load-balancer-resource-group":   "public-ips",
+			}))
+			Expect(svc.Spec.LoadBalancerIP).To(Equal("5.6.7.8"))
+		})
+
+		It("should unbind the kube-apiserver service from the static public IP if it is not reserved anymore", func() {
+			svc.Annotations = map[string]string{"service.beta.kubernetes.io/azure-load-balancer-resource-group": "public-ips"}
+			svc.Spec.LoadBalancerIP = "5.6.7.8"
+
+			c := mockclient.NewMockClient(ctrl)
+			c.EXPECT().Get(context.TODO(), client.ObjectKey{Name: namespace}, &extensionsv1alpha1.Cluster{}).DoAndReturn(clientGet(cluster))
+			err := ensurer.(inject.Client).InjectClient(c)
+			Expect(err).To(Not(HaveOccurred()))
+
+			err = ensurer.EnsureKubeAPIServerService(context.TODO(), dummyContext, svc, nil)
+			Expect(err).To(Not(HaveOccurred()))
+
+			Expect(svc.Annotations).To(Equal(map[string]string{
+				"service.beta.kubernetes.io/azure-load-balancer-tcp-idle-timeout": "30",
+			}))
+			Expect(svc.Spec.LoadBalancerIP).To(BeEmpty())
+		})
+
+		It("should keep the exposure which has been fixed when the shoot was created", func() {
+			svc.Annotations = map[string]string{
+				"service.beta.kubernetes.io/azure-load-balancer-internal":        "true",
+				"service.beta.kubernetes.io/azure-load-balancer-internal-subnet": "internal",
+			}
+			ensurer = NewEnsurer(etcdStorage, kubeAPIServerExposure, logger)
+
+			c := mockclient.NewMockClient(ctrl)
+			c.EXPECT().Get(context.TODO(), client.ObjectKey{Name: namespace}, &extensionsv1alpha1.Cluster{}).DoAndReturn(clientGet(publicCluster))
+			c.EXPECT().List(context.TODO(), &extensionsv1alpha1.ControlPlaneList{}, client.InNamespace(namespace)).DoAndReturn(clientList(publicCPList))
+			err := ensurer.(inject.Client).InjectClient(c)
+			Expect(err).To(Not(HaveOccurred()))
+
+			err = ensurer.EnsureKubeAPIServerService(context.TODO(), dummyContext, svc, nil)
+			Expect(err).To(Not(HaveOccurred()))
+
+			Expect(svc.Annotations).To(Equal(map[string]string{
+				"service.beta.kubernetes.io/azure-load-balancer-tcp-idle-timeout": "20",
+			}))
+		})
 	})
 
 	Describe("#EnsureETCD", func() {
@@ -238,7 +487,7 @@ var _ = Describe("Ensurer", func() {
 			)
 
 			// Create ensurer
-			ensurer := NewEnsurer(etcdStorage, logger)
+			ensurer := NewEnsurer(etcdStorage, nil, logger)
 
 			// Call EnsureETCD method and check the result
 			err := ensurer.EnsureETCD(context.TODO(), dummyContext, etcd, nil)
@@ -258,7 +507,7 @@ var _ = Describe("Ensurer", func() {
 			)
 
 			// Create ensurer
-			ensurer := NewEnsurer(etcdStorage, logger)
+			ensurer := NewEnsurer(etcdStorage, nil, logger)
 
 			// Call EnsureETCD method and check the result
 			err := ensurer.EnsureETCD(context.TODO(), dummyContext, etcd, nil)
@@ -274,7 +523,7 @@ var _ = Describe("Ensurer", func() {
 			)
 
 			// Create ensurer
-			ensurer := NewEnsurer(etcdStorage, logger)
+			ensurer := NewEnsurer(etcdStorage, nil, logger)
 
 			// Call EnsureETCD method and check the result
 			err := ensurer.EnsureETCD(context.TODO(), dummyContext, etcd, nil)
@@ -294,7 +543,7 @@ var _ = Describe("Ensurer", func() {
 			)
 
 			// Create ensurer
-			ensurer := NewEnsurer(etcdStorage, logger)
+			ensurer := NewEnsurer(etcdStorage, nil, logger)
 
 			// Call EnsureETCD method and check the result
 			err := ensurer.EnsureETCD(context.TODO(), dummyContext, etcd, nil)
@@ -334,6 +583,16 @@ func clientGet(result runtime.Object) interface{} {
 	}
 }
 
+func clientList(result runtime.Object) interface{} {
+	return func(ctx context.Context, list runtime.Object, opts ...client.ListOption) error {
+		switch list.(type) {
+		case *extensionsv1alpha1.ControlPlaneList:
+			*list.(*extensionsv1alpha1.ControlPlaneList) = *result.(*extensionsv1alpha1.ControlPlaneList)
+		}
+		return nil
+	}
+}
+
 func encode(obj runtime.Object) []byte {
 	data, _ := json.Marshal(obj)
 	return data
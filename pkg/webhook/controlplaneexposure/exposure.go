@@ -0,0 +1,204 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controlplaneexposure
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	apisazure "github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure"
+	azureapihelper "github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure/helper"
+	"github.com/gardener/gardener-extension-provider-azure/pkg/apis/config"
+	"github.com/gardener/gardener-extensions/pkg/controller"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// defaultTCPIdleTimeoutInMinutes is the TCP idle timeout of the load balancer of the kube-apiserver if neither the
+	// seed nor the shoot configure one.
+	defaultTCPIdleTimeoutInMinutes = 30
+
+	annotationTCPIdleTimeout             = "service.beta.kubernetes.io/azure-load-balancer-tcp-idle-timeout"
+	annotationResourceGroup              = "service.beta.kubernetes.io/azure-load-balancer-resource-group"
+	annotationInternal                   = "service.beta.kubernetes.io/azure-load-balancer-internal"
+	annotationInternalSubnet             = "service.beta.kubernetes.io/azure-load-balancer-internal-subnet"
+	annotationPrivateLinkServiceCreate   = "service.beta.kubernetes.io/azure-pls-create"
+	annotationPrivateLinkServiceName     = "service.beta.kubernetes.io/azure-pls-name"
+	annotationPrivateLinkServiceSubnet   = "service.beta.kubernetes.io/azure-pls-ip-configuration-subnet"
+	annotationPrivateLinkServiceVisible  = "service.beta.kubernetes.io/azure-pls-visibility"
+	annotationPrivateLinkServiceApproval = "service.beta.kubernetes.io/azure-pls-auto-approval"
+)
+
+// kubeAPIServerExposure is the exposure of the kube-apiserver of a shoot, i.e. the defaults of the seed overridden
+// by the settings in the ControlPlaneConfig of the shoot and by the status of its controlplane with purpose exposure.
+type kubeAPIServerExposure struct {
+	internal                  bool
+	subnet                    *string
+	privateLinkService        bool
+	privateLinkServiceSubnet  *string
+	allowedSubscriptions      []string
+	autoApprovedSubscriptions []string
+	tcpIdleTimeoutInMinutes   int32
+	publicIP                  *apisazure.PublicIPStatus
+}
+
+// getKubeAPIServerExposure determines the exposure of the kube-apiserver of the shoot of the given cluster in the
+// given namespace.
+func getKubeAPIServerExposure(ctx context.Context, c client.Client, defaults *config.KubeAPIServerExposureConfig, cluster *controller.Cluster, namespace string) (*kubeAPIServerExposure, error) {
+	exposure := &kubeAPIServerExposure{
+		tcpIdleTimeoutInMinutes: defaultTCPIdleTimeoutInMinutes,
+	}
+
+	if defaults != nil {
+		exposure.internal = boolOrDefault(defaults.Internal, exposure.internal)
+		exposure.subnet = defaults.Subnet
+		exposure.tcpIdleTimeoutInMinutes = int32OrDefault(defaults.TCPIdleTimeoutInMinutes, exposure.tcpIdleTimeoutInMinutes)
+		if pls := defaults.PrivateLinkService; pls != nil {
+			exposure.privateLinkService = boolOrDefault(pls.Enabled, exposure.privateLinkService)
+			exposure.privateLinkServiceSubnet = pls.Subnet
+			exposure.allowedSubscriptions = pls.AllowedSubscriptions
+			exposure.autoApprovedSubscriptions = pls.AutoApprovedSubscriptions
+		}
+	}
+
+	cpConfig, err := azureapihelper.ControlPlaneConfigFromCluster(cluster)
+	if err != nil {
+		return nil, err
+	}
+	if cpConfig != nil && cpConfig.KubeAPIServerExposure != nil {
+		shootExposure := cpConfig.KubeAPIServerExposure
+		exposure.tcpIdleTimeoutInMinutes = int32OrDefault(shootExposure.TCPIdleTimeoutInMinutes, exposure.tcpIdleTimeoutInMinutes)
+		if pls := shootExposure.PrivateLinkService; pls != nil {
+			exposure.privateLinkService = boolOrDefault(pls.Enabled, exposure.privateLinkService)
+			if len(pls.AllowedSubscriptions) > 0 {
+				exposure.allowedSubscriptions = pls.AllowedSubscriptions
+			}
+			if len(pls.AutoApprovedSubscriptions) > 0 {
+				exposure.autoApprovedSubscriptions = pls.AutoApprovedSubscriptions
+			}
+		}
+	}
+
+	// Whether the kube-apiserver is exposed internally is fixed and the static public IP is reserved by the controlplane
+	// controller when it reconciles the controlplane with purpose exposure.
+	if defaults != nil {
+		status, err := getExposureControlPlaneStatus(ctx, c, namespace)
+		if err != nil {
+			return nil, err
+		}
+		if status != nil && status.KubeAPIServerExposure != nil {
+			exposure.internal = status.KubeAPIServerExposure.Internal
+		}
+		if status != nil && !exposure.internal && defaults.PublicIP != nil {
+			exposure.publicIP = status.PublicIP
+		}
+	}
+
+	// A Private Link Service can only publish an internal load balancer.
+	exposure.privateLinkService = exposure.privateLinkService && exposure.internal
+
+	return exposure, nil
+}
+
+// getExposureControlPlaneStatus returns the provider status of the controlplane with purpose exposure in the given
+// namespace. It returns nil if the controlplane has not been reconciled yet.
+func getExposureControlPlaneStatus(ctx context.Context, c client.Client, namespace string) (*apisazure.ControlPlaneStatus, error) {
+	cpList := &extensionsv1alpha1.ControlPlaneList{}
+	if err := c.List(ctx, cpList, client.InNamespace(namespace)); err != nil {
+		return nil, errors.Wrapf(err, "could not list controlplanes in namespace '%s'", namespace)
+	}
+
+	for _, cp := range cpList.Items {
+		if cp.Spec.Purpose == nil || *cp.Spec.Purpose != extensionsv1alpha1.Exposure {
+			continue
+		}
+		return azureapihelper.ControlPlaneStatusFromControlPlane(&cp)
+	}
+	return nil, nil
+}
+
+// ensureService sets the annotations of the kube-apiserver service which configure its load balancer and removes those
+// of settings which are not used. It binds the service to the static public IP if it has been reserved.
+func (e *kubeAPIServerExposure) ensureService(svc *corev1.Service) {
+	if svc.Annotations == nil {
+		svc.Annotations = make(map[string]string)
+	}
+	annotations := svc.Annotations
+	annotations[annotationTCPIdleTimeout] = strconv.Itoa(int(e.tcpIdleTimeoutInMinutes))
+
+	if e.publicIP != nil {
+		annotations[annotationResourceGroup] = e.publicIP.ResourceGroup
+		svc.Spec.LoadBalancerIP = e.publicIP.IPAddress
+	} else if _, ok := annotations[annotationResourceGroup]; ok {
+		delete(annotations, annotationResourceGroup)
+		svc.Spec.LoadBalancerIP = ""
+	}
+
+	for _, key := range []string{
+		annotationInternal,
+		annotationInternalSubnet,
+		annotationPrivateLinkServiceCreate,
+		annotationPrivateLinkServiceName,
+		annotationPrivateLinkServiceSubnet,
+		annotationPrivateLinkServiceVisible,
+		annotationPrivateLinkServiceApproval,
+	} {
+		delete(annotations, key)
+	}
+
+	if !e.internal {
+		return
+	}
+	annotations[annotationInternal] = "true"
+	if e.subnet != nil {
+		annotations[annotationInternalSubnet] = *e.subnet
+	}
+
+	if !e.privateLinkService {
+		return
+	}
+	annotations[annotationPrivateLinkServiceCreate] = "true"
+	annotations[annotationPrivateLinkServiceName] = svc.Namespace
+	if subnet := e.privateLinkServiceSubnet; subnet != nil {
+		annotations[annotationPrivateLinkServiceSubnet] = *subnet
+	} else if e.subnet != nil {
+		annotations[annotationPrivateLinkServiceSubnet] = *e.subnet
+	}
+	if len(e.allowedSubscriptions) > 0 {
+		annotations[annotationPrivateLinkServiceVisible] = strings.Join(e.allowedSubscriptions, " ")
+	}
+	if len(e.autoApprovedSubscriptions) > 0 {
+		annotations[annotationPrivateLinkServiceApproval] = strings.Join(e.autoApprovedSubscriptions, " ")
+	}
+}
+
+func boolOrDefault(value *bool, defaultValue bool) bool {
+	if value != nil {
+		return *value
+	}
+	return defaultValue
+}
+
+func int32OrDefault(value *int32, defaultValue int32) int32 {
+	if value != nil {
+		return *value
+	}
+	return defaultValue
+}
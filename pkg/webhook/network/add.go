@@ -35,6 +35,6 @@ func AddToManager(mgr manager.Manager) (*extensionswebhook.Webhook, error) {
 		CloudProvider:   azure.Type,
 		NetworkProvider: calico.Type,
 		Types:           []runtime.Object{&extensionsv1alpha1.Network{}},
-		Mutator:         network.NewMutator(logger, mutateNetworkConfig),
+		Mutator:         NewMutator(logger),
 	})
 }
@@ -15,21 +15,73 @@
 package network
 
 import (
+	"context"
+	"fmt"
+
+	apisazure "github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure"
+	azureapihelper "github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure/helper"
+	extensionscontroller "github.com/gardener/gardener-extensions/pkg/controller"
 	extensionswebhook "github.com/gardener/gardener-extensions/pkg/webhook"
 
 	calicov1alpha1 "github.com/gardener/gardener-extension-networking-calico/pkg/apis/calico/v1alpha1"
 	"github.com/gardener/gardener-extension-networking-calico/pkg/controller"
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/go-logr/logr"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func mutateNetworkConfig(new, old *extensionsv1alpha1.Network) error {
+// NewMutator creates a new network mutator which configures the Calico backend according to the pod network mode
+// of the cluster.
+func NewMutator(logger logr.Logger) extensionswebhook.Mutator {
+	return &mutator{
+		logger: logger.WithName("mutator"),
+	}
+}
+
+type mutator struct {
+	client client.Client
+	logger logr.Logger
+}
+
+// InjectClient injects the given client into the mutator.
+func (m *mutator) InjectClient(client client.Client) error {
+	m.client = client
+	return nil
+}
+
+// Mutate validates and if needed mutates the given object.
+func (m *mutator) Mutate(ctx context.Context, new, old runtime.Object) error {
+	network, ok := new.(*extensionsv1alpha1.Network)
+	if !ok {
+		return fmt.Errorf("could not mutate, object is not of type \"Network\"")
+	}
+	// If the object does have a deletion timestamp then we don't want to mutate anything.
+	if network.DeletionTimestamp != nil {
+		return nil
+	}
+
+	cluster, err := extensionscontroller.GetCluster(ctx, m.client, network.Namespace)
+	if err != nil {
+		return err
+	}
+	infraConfig, err := azureapihelper.InfrastructureConfigFromCluster(cluster)
+	if err != nil {
+		return err
+	}
+
+	return mutateNetworkConfig(network, azureapihelper.PodNetworkModeOrDefault(infraConfig))
+}
+
+// mutateNetworkConfig configures the Calico backend for the given pod network mode. In the Routes mode, Calico does not
+// use a backend as the pod traffic is routed via the route table which is maintained by the cloud-controller-manager.
+// In the Overlay mode, the pod traffic is encapsulated via VXLAN.
+func mutateNetworkConfig(new *extensionsv1alpha1.Network, mode apisazure.PodNetworkMode) error {
 	extensionswebhook.LogMutation(logger, "Network", new.Namespace, new.Name)
 
 	var (
 		networkConfig *calicov1alpha1.NetworkConfig
-		backendNone   = calicov1alpha1.None
 		err           error
 	)
 
@@ -47,7 +99,24 @@ func mutateNetworkConfig(new, old *extensionsv1alpha1.Network) error {
 		}
 	}
 
-	networkConfig.Backend = &backendNone
+	switch mode {
+	case apisazure.PodNetworkModeOverlay:
+		var (
+			backendVXLan = calicov1alpha1.VXLan
+			poolVXLan    = calicov1alpha1.PoolVXLan
+			modeAlways   = calicov1alpha1.Always
+		)
+		networkConfig.Backend = &backendVXLan
+		if networkConfig.IPv4 == nil {
+			networkConfig.IPv4 = &calicov1alpha1.IPv4{}
+		}
+		networkConfig.IPv4.Pool = &poolVXLan
+		networkConfig.IPv4.Mode = &modeAlways
+	default:
+		backendNone := calicov1alpha1.None
+		networkConfig.Backend = &backendNone
+	}
+
 	new.Spec.ProviderConfig = &runtime.RawExtension{
 		Object: networkConfig,
 	}
@@ -0,0 +1,152 @@
+// Copyright (c) 2020 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package network
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	apisazure "github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure"
+	apiv1alpha1 "github.com/gardener/gardener-extension-provider-azure/pkg/apis/azure/v1alpha1"
+	mockclient "github.com/gardener/gardener-extensions/pkg/mock/controller-runtime/client"
+
+	calicov1alpha1 "github.com/gardener/gardener-extension-networking-calico/pkg/apis/calico/v1alpha1"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
+)
+
+const namespace = "test"
+
+func TestNetwork(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Network Webhook Suite")
+}
+
+var _ = Describe("Mutator", func() {
+	var (
+		ctrl *gomock.Controller
+		c    *mockclient.MockClient
+
+		network *extensionsv1alpha1.Network
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		c = mockclient.NewMockClient(ctrl)
+
+		network = &extensionsv1alpha1.Network{
+			ObjectMeta: metav1.ObjectMeta{Name: "network", Namespace: namespace},
+		}
+	})
+
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	Describe("#Mutate", func() {
+		It("should disable the Calico backend for the routes pod network mode", func() {
+			expectGetCluster(c, nil)
+
+			mutator := NewMutator(logger)
+			_, err := inject.ClientInto(c, mutator)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(mutator.Mutate(context.TODO(), network, nil)).To(Succeed())
+
+			networkConfig := network.Spec.ProviderConfig.Object.(*calicov1alpha1.NetworkConfig)
+			Expect(*networkConfig.Backend).To(Equal(calicov1alpha1.None))
+			Expect(networkConfig.IPv4).To(BeNil())
+		})
+
+		It("should configure a VXLAN overlay for the overlay pod network mode", func() {
+			mode := apiv1alpha1.PodNetworkMode(apisazure.PodNetworkModeOverlay)
+			expectGetCluster(c, &apiv1alpha1.InfrastructureConfig{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: apiv1alpha1.SchemeGroupVersion.String(),
+					Kind:       "InfrastructureConfig",
+				},
+				Networks: apiv1alpha1.NetworkConfig{PodNetworkMode: &mode},
+			})
+			typhaEnabled := calicov1alpha1.Typha{Enabled: true}
+			network.Spec.ProviderConfig = &runtime.RawExtension{
+				Raw: encode(&calicov1alpha1.NetworkConfig{
+					TypeMeta: metav1.TypeMeta{
+						APIVersion: calicov1alpha1.SchemeGroupVersion.String(),
+						Kind:       "NetworkConfig",
+					},
+					Typha: &typhaEnabled,
+				}),
+			}
+
+			mutator := NewMutator(logger)
+			_, err := inject.ClientInto(c, mutator)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(mutator.Mutate(context.TODO(), network, nil)).To(Succeed())
+
+			networkConfig := network.Spec.ProviderConfig.Object.(*calicov1alpha1.NetworkConfig)
+			Expect(*networkConfig.Backend).To(Equal(calicov1alpha1.VXLan))
+			Expect(*networkConfig.IPv4.Pool).To(Equal(calicov1alpha1.PoolVXLan))
+			Expect(*networkConfig.IPv4.Mode).To(Equal(calicov1alpha1.Always))
+			Expect(networkConfig.Typha).To(Equal(&typhaEnabled))
+		})
+
+		It("should not mutate a network which is being deleted", func() {
+			now := metav1.Now()
+			network.DeletionTimestamp = &now
+
+			Expect(NewMutator(logger).Mutate(context.TODO(), network, nil)).To(Succeed())
+			Expect(network.Spec.ProviderConfig).To(BeNil())
+		})
+	})
+})
+
+func expectGetCluster(c *mockclient.MockClient, infrastructureConfig *apiv1alpha1.InfrastructureConfig) {
+	shoot := &gardencorev1beta1.Shoot{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: gardencorev1beta1.SchemeGroupVersion.String(),
+			Kind:       "Shoot",
+		},
+	}
+	if infrastructureConfig != nil {
+		shoot.Spec.Provider.InfrastructureConfig = &gardencorev1beta1.ProviderConfig{
+			RawExtension: runtime.RawExtension{Raw: encode(infrastructureConfig)},
+		}
+	}
+	cluster := &extensionsv1alpha1.Cluster{
+		Spec: extensionsv1alpha1.ClusterSpec{
+			Shoot: runtime.RawExtension{Raw: encode(shoot)},
+		},
+	}
+
+	c.EXPECT().Get(context.TODO(), client.ObjectKey{Name: namespace}, &extensionsv1alpha1.Cluster{}).DoAndReturn(
+		func(_ context.Context, _ client.ObjectKey, obj *extensionsv1alpha1.Cluster) error {
+			*obj = *cluster
+			return nil
+		})
+}
+
+func encode(obj runtime.Object) []byte {
+	data, _ := json.Marshal(obj)
+	return data
+}